@@ -1,6 +1,7 @@
 package config
 
 import (
+	"app/src/money"
 	"app/src/utils"
 
 	"github.com/spf13/viper"
@@ -28,6 +29,11 @@ var (
 	GoogleClientID      string
 	GoogleClientSecret  string
 	RedirectURL         string
+	HeldSaleExpiryMins  int
+	Currency            money.Currency
+	IdempotencyKeyTTL   int
+	SelfOrderURL        string
+	SelfOrderSecret     string
 )
 
 func init() {
@@ -63,6 +69,25 @@ func init() {
 	GoogleClientID = viper.GetString("GOOGLE_CLIENT_ID")
 	GoogleClientSecret = viper.GetString("GOOGLE_CLIENT_SECRET")
 	RedirectURL = viper.GetString("REDIRECT_URL")
+
+	// sales configuration
+	viper.SetDefault("HELD_SALE_EXPIRY_MINUTES", 720)
+	HeldSaleExpiryMins = viper.GetInt("HELD_SALE_EXPIRY_MINUTES")
+	viper.SetDefault("CURRENCY", "IDR")
+	viper.SetDefault("CURRENCY_ROUNDING", string(money.RoundHalfUp))
+	Currency = money.NewCurrency(viper.GetString("CURRENCY"), money.RoundingMode(viper.GetString("CURRENCY_ROUNDING")))
+
+	// idempotency configuration
+	viper.SetDefault("IDEMPOTENCY_KEY_TTL_HOURS", 24)
+	IdempotencyKeyTTL = viper.GetInt("IDEMPOTENCY_KEY_TTL_HOURS")
+
+	// self-ordering configuration
+	viper.SetDefault("SELF_ORDER_URL", "http://localhost:3000/order")
+	SelfOrderURL = viper.GetString("SELF_ORDER_URL")
+	SelfOrderSecret = viper.GetString("SELF_ORDER_SECRET")
+	if SelfOrderSecret == "" {
+		SelfOrderSecret = JWTSecret
+	}
 }
 
 func loadConfig() {
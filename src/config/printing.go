@@ -0,0 +1,60 @@
+package config
+
+import "time"
+
+const (
+	PrinterConnectionNetwork = "network"
+	PrinterConnectionDisplay = "display" // a kitchen display, fed over WebSocket instead of printed
+)
+
+// Raw TCP port of network printers, used when the printer address has none
+const PrinterPort = "9100"
+
+const (
+	PrintJobReceipt = "receipt"
+	PrintJobTest    = "test"
+	PrintJobKitchen = "kitchen"
+)
+
+const (
+	PrintJobStatusPending  = "pending"
+	PrintJobStatusPrinting = "printing"
+	PrintJobStatusPrinted  = "printed"
+	PrintJobStatusFailed   = "failed"
+)
+
+// Delivery of print jobs. A job that cannot be delivered is retried after PrintRetryDelay,
+// doubled on every attempt up to PrintRetryMaxDelay, and fails after PrintMaxAttempts.
+const (
+	PrintMaxAttempts   = 10
+	PrintRetryDelay    = 5 * time.Second
+	PrintRetryMaxDelay = 5 * time.Minute
+	PrintTimeout       = 10 * time.Second
+	PrintLease         = 2 * time.Minute // a job still printing after this is picked up again
+)
+
+// Kitchen display states, an item only moves forward through them
+const (
+	KitchenStatusNew        = "new"
+	KitchenStatusInProgress = "in_progress"
+	KitchenStatusReady      = "ready"
+	KitchenStatusServed     = "served"
+	KitchenStatusVoid       = "void"
+)
+
+// Kitchen display feed events
+const (
+	KitchenEventFired      = "fired"
+	KitchenEventUpdated    = "updated"
+	KitchenEventVoided     = "voided"
+	KitchenEventOrderReady = "order_ready"
+)
+
+// How long served and void items can still be listed for the kitchen displays
+const KitchenHistory = 24 * time.Hour
+
+// Messages kept for a kitchen display that reads slower than they come, later ones are dropped
+const KitchenFeedBuffer = 64
+
+// Interval of WebSocket pings keeping idle kitchen display connections open
+const KitchenFeedPing = 30 * time.Second
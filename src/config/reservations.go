@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+const (
+	ReservationStatusBooked    = "booked"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusSeated    = "seated"
+	ReservationStatusCompleted = "completed"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusNoShow    = "no_show"
+)
+
+const (
+	WaitlistStatusWaiting = "waiting"
+	WaitlistStatusSeated  = "seated"
+	WaitlistStatusLeft    = "left"
+)
+
+// Defaults for outlets without the reservation_minutes and table_turn_minutes settings
+const (
+	ReservationMinutes = 90
+	TableTurnMinutes   = 60
+)
+
+const (
+	ReservationReminderLead = 3 * time.Hour   // reminders go out this long before the reservation
+	TableCleaningTime       = 5 * time.Minute // how long a table that needs cleaning takes to be ready
+	WaitRoundTo             = 5 * time.Minute // quoted wait times are rounded up to this
+)
@@ -1,10 +1,17 @@
 package config
 
 var allRoles = map[string][]string{
-	"user":  {},
-	"admin": {"getUsers", "manageUsers"},
+	"user":  {"getSales", "manageSales", "manageOutlets"},
+	"admin": {"getUsers", "manageUsers", "getSales", "manageSales", "manageOutlets"},
 }
 
+// Roles of a user within a business, see model.BusinessUser.
+const (
+	BusinessRoleOwner = "owner"
+	BusinessRoleAdmin = "admin"
+	BusinessRoleStaff = "staff"
+)
+
 var Roles = getKeys(allRoles)
 var RoleRights = allRoles
 
@@ -0,0 +1,141 @@
+package config
+
+const (
+	SaleStatusUnpaid   = "unpaid"
+	SaleStatusPaid     = "paid"
+	SaleStatusVoid     = "void"
+	SaleStatusHold     = "hold"
+	SaleStatusRefunded = "refunded"
+	SaleStatusMerged   = "merged" // its items were moved to the sale it was merged into
+)
+
+// Void reason of held sales voided when their hold runs out
+const SaleVoidReasonHoldExpired = "Hold expired"
+
+// Order types, dine-in sales are served at a table
+const (
+	OrderTypeDineIn   = "dine_in"
+	OrderTypeTakeaway = "takeaway"
+	OrderTypeDelivery = "delivery"
+)
+
+// Table statuses, a table is occupied while it has an open sale and needs cleaning once it is paid
+const (
+	TableStatusAvailable     = "available"
+	TableStatusOccupied      = "occupied"
+	TableStatusNeedsCleaning = "needs_cleaning"
+	TableStatusReserved      = "reserved"
+)
+
+const (
+	TableMoveMove    = "move"
+	TableMoveMerge   = "merge"
+	TableMoveUnmerge = "unmerge"
+)
+
+const (
+	RefundReasonDamaged         = "damaged"
+	RefundReasonWrongItem       = "wrong_item"
+	RefundReasonQualityIssue    = "quality_issue"
+	RefundReasonCustomerRequest = "customer_request"
+	RefundReasonOther           = "other"
+)
+
+const StockMovementRefund = "refund"
+
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusPaid    = "paid"
+	PaymentStatusFailed  = "failed"
+	PaymentStatusVoid    = "void"
+)
+
+const PaymentTypeCash = "cash"
+
+const (
+	TaxTypeTax           = "tax"
+	TaxTypeServiceCharge = "service_charge"
+)
+
+// Outlet setting keys
+const (
+	SettingHeldSaleExpiryMinutes = "held_sale_expiry_minutes"
+	SettingCashRoundingIncrement = "cash_rounding_increment" // in currency units, e.g. 100 or 500
+	SettingCashRoundingMode      = "cash_rounding_mode"      // nearest, up or down
+	SettingTipPoolRule           = "tip_pool_rule"           // equal, hours or role
+	SettingTipPoolRoleWeights    = "tip_pool_role_weights"   // JSON object of role to weight, e.g. {"waiter":2}
+	// Item discounts above this percentage of the line need a manager, every discount when 0
+	SettingApprovalDiscountPercent = "approval_discount_percent"
+	SettingReceiptLanguage         = "receipt_language"  // en or id
+	SettingReceiptCodePage         = "receipt_code_page" // printer code page, e.g. cp437 or wpc1252
+	SettingReceiptFooter           = "receipt_footer"
+	SettingReceiptQRCode           = "receipt_qr_code"     // printed as a QR code, {invoice} is the invoice number
+	SettingReservationMinutes      = "reservation_minutes" // how long a reservation holds its table
+	SettingTableTurnMinutes        = "table_turn_minutes"  // how long a party usually stays, for wait times
+)
+
+const (
+	CashRoundingNearest = "nearest"
+	CashRoundingUp      = "up"
+	CashRoundingDown    = "down"
+)
+
+const (
+	TipPoolEqual = "equal"
+	TipPoolHours = "hours"
+	TipPoolRole  = "role"
+)
+
+// Results of a sale uploaded by a terminal
+const (
+	SyncStatusCreated   = "created"
+	SyncStatusDuplicate = "duplicate"
+	SyncStatusRejected  = "rejected"
+)
+
+// Conflicts found while accepting a sale made offline
+const (
+	SyncConflictPriceChanged = "price_changed"
+	SyncConflictUnderpaid    = "underpaid"
+	SyncConflictOverpaid     = "overpaid"
+)
+
+const (
+	ApprovalActionVoidSale      = "void_sale"
+	ApprovalActionDiscount      = "discount"
+	ApprovalActionPriceOverride = "price_override"
+	ApprovalActionVoidPayment   = "void_payment"
+)
+
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusUsed     = "used"
+)
+
+const (
+	ApprovalMethodPin  = "pin"
+	ApprovalMethodPush = "push"
+)
+
+// Minutes a pending or unused approval stays valid
+const ApprovalExpiryMinutes = 10
+
+// Wrong PINs in a row after which a staff member is locked out, and for how many minutes
+const (
+	StaffPinMaxAttempts    = 5
+	StaffPinLockoutMinutes = 15
+)
+
+// Staff roles allowed to approve
+const (
+	StaffRoleManager = "manager"
+	StaffRoleOwner   = "owner"
+)
+
+// Coupon discount types, a percentage coupon's discount_value is the percent taken off (10.00 is 10%)
+const (
+	CouponDiscountPercentage = "percentage"
+	CouponDiscountFixed      = "fixed"
+)
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+const (
+	TableOrderStatusPending   = "pending"
+	TableOrderStatusConfirmed = "confirmed"
+	TableOrderStatusRejected  = "rejected"
+)
+
+// Guests of a table share these limits across their phones
+const (
+	SelfOrderRequestLimit  = 60 // menu and cart requests per SelfOrderRequestWindow
+	SelfOrderRequestWindow = time.Minute
+	SelfOrderOrderLimit    = 5 // orders per SelfOrderOrderWindow
+	SelfOrderOrderWindow   = 10 * time.Minute
+)
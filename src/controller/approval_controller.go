@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ApprovalController struct {
+	ApprovalService service.ApprovalService
+}
+
+func NewApprovalController(approvalService service.ApprovalService) *ApprovalController {
+	return &ApprovalController{
+		ApprovalService: approvalService,
+	}
+}
+
+// @Tags         Approvals
+// @Summary      Get manager approvals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path      string  true   "Outlet id"
+// @Param        page      query     int     false  "Page number"  default(1)
+// @Param        limit     query     int     false  "Maximum number of approvals"  default(10)
+// @Param        status    query     string  false  "Approval status"  Enums(pending, approved, rejected, used)
+// @Router       /outlets/{outletId}/approvals [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.Approval]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (a *ApprovalController) GetApprovals(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryApproval{
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 10),
+		Status: c.Query("status", ""),
+	}
+
+	approvals, totalResults, err := a.ApprovalService.GetApprovals(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.Approval]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all approvals successfully",
+			Results:      approvals,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Approvals
+// @Summary      Get a manager approval
+// @Security     BearerAuth
+// @Produce      json
+// @Param        approvalId  path  string  true  "Approval id"
+// @Router       /approvals/{approvalId} [get]
+// @Success      200  {object}  response.SuccessWithApproval
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Approval not found"
+func (a *ApprovalController) GetApprovalByID(c *fiber.Ctx) error {
+	approvalID := c.Params("approvalId")
+
+	if _, err := uuid.Parse(approvalID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid approval ID")
+	}
+
+	approval, err := a.ApprovalService.GetApprovalByID(c, approvalID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithApproval{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get approval successfully",
+			Approval: *approval,
+		})
+}
+
+// @Tags         Approvals
+// @Summary      Request a manager approval
+// @Description  Voids of paid sales, discounts above the outlet threshold and price overrides need an approval.
+// @Description  With approver_id and pin the approval is granted at once, otherwise it stays pending
+// @Description  until a manager approves or rejects it. Approved approvals expire after 10 minutes.
+// @Description  After 5 wrong PINs in a row the approver is locked out for 15 minutes.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                     true  "Outlet id"
+// @Param        request   body  validation.CreateApproval  true  "Request body"
+// @Router       /outlets/{outletId}/approvals [post]
+// @Success      201  {object}  response.SuccessWithApproval
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+// @Failure      429  {object}  response.ErrorDetails  "Too many wrong PINs"
+func (a *ApprovalController) CreateApproval(c *fiber.Ctx) error {
+	req := new(validation.CreateApproval)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	approval, err := a.ApprovalService.CreateApproval(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithApproval{
+			Code:     fiber.StatusCreated,
+			Status:   "success",
+			Message:  "Create approval successfully",
+			Approval: *approval,
+		})
+}
+
+// @Tags         Approvals
+// @Summary      Approve a pending approval
+// @Security     BearerAuth
+// @Produce      json
+// @Param        approvalId  path  string  true  "Approval id"
+// @Router       /approvals/{approvalId}/approve [post]
+// @Success      200  {object}  response.SuccessWithApproval
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Approval not found"
+func (a *ApprovalController) ApproveApproval(c *fiber.Ctx) error {
+	return a.decideApproval(c, true, "Approve approval successfully")
+}
+
+// @Tags         Approvals
+// @Summary      Reject a pending approval
+// @Security     BearerAuth
+// @Produce      json
+// @Param        approvalId  path  string  true  "Approval id"
+// @Router       /approvals/{approvalId}/reject [post]
+// @Success      200  {object}  response.SuccessWithApproval
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Approval not found"
+func (a *ApprovalController) RejectApproval(c *fiber.Ctx) error {
+	return a.decideApproval(c, false, "Reject approval successfully")
+}
+
+func (a *ApprovalController) decideApproval(c *fiber.Ctx, approve bool, message string) error {
+	approvalID := c.Params("approvalId")
+
+	if _, err := uuid.Parse(approvalID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid approval ID")
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	approval, err := a.ApprovalService.DecideApproval(c, approvalID, user, approve)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithApproval{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  message,
+			Approval: *approval,
+		})
+}
+
+// @Tags         Approvals
+// @Summary      Set a staff member's approval PIN
+// @Description  Owners and admins of the business can set any PIN, other users need current_pin.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                     true  "Outlet id"
+// @Param        staffId   path  string                     true  "Staff id"
+// @Param        request   body  validation.UpdateStaffPin  true  "Request body"
+// @Router       /outlets/{outletId}/staff/{staffId}/pin [put]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Staff not found"
+// @Failure      429  {object}  response.ErrorDetails  "Too many wrong PINs"
+func (a *ApprovalController) UpdateStaffPin(c *fiber.Ctx) error {
+	req := new(validation.UpdateStaffPin)
+	outletID := c.Params("outletId")
+	staffID := c.Params("staffId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if _, err := uuid.Parse(staffID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid staff ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := a.ApprovalService.UpdateStaffPin(c, outletID, staffID, req); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update staff PIN successfully",
+		})
+}
@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"encoding/csv"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CouponController struct {
+	CouponService service.CouponService
+}
+
+func NewCouponController(couponService service.CouponService) *CouponController {
+	return &CouponController{
+		CouponService: couponService,
+	}
+}
+
+// @Tags         Coupons
+// @Summary      Get business coupons
+// @Security     BearerAuth
+// @Produce      json
+// @Param        businessId  path      string  true   "Business id"
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        limit       query     int     false  "Maximum number of coupons"  default(10)
+// @Param        outlet_id   query     string  false  "Only coupons that can be used at this outlet"
+// @Param        campaign    query     string  false  "Campaign name"
+// @Param        search      query     string  false  "Part of the code"
+// @Router       /businesses/{businessId}/coupons [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.Coupon]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *CouponController) GetCoupons(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	query := &validation.QueryCoupon{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		OutletID: c.Query("outlet_id", ""),
+		Campaign: c.Query("campaign", ""),
+		Search:   c.Query("search", ""),
+	}
+
+	coupons, totalResults, err := s.CouponService.GetCoupons(c, businessID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.Coupon]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all coupons successfully",
+			Results:      coupons,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Get a coupon
+// @Security     BearerAuth
+// @Produce      json
+// @Param        couponId  path  string  true  "Coupon id"
+// @Router       /coupons/{couponId} [get]
+// @Success      200  {object}  response.SuccessWithCoupon
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Coupon not found"
+func (s *CouponController) GetCouponByID(c *fiber.Ctx) error {
+	couponID := c.Params("couponId")
+
+	if _, err := uuid.Parse(couponID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid coupon ID")
+	}
+
+	coupon, err := s.CouponService.GetCouponByID(c, couponID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCoupon{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get coupon successfully",
+			Coupon:  *coupon,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Get coupon usage
+// @Description  How often the coupon was redeemed at each outlet and what it took off there.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        couponId  path  string  true  "Coupon id"
+// @Router       /coupons/{couponId}/usage [get]
+// @Success      200  {object}  response.SuccessWithCouponUsage
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Coupon not found"
+func (s *CouponController) GetCouponUsage(c *fiber.Ctx) error {
+	couponID := c.Params("couponId")
+
+	if _, err := uuid.Parse(couponID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid coupon ID")
+	}
+
+	usage, err := s.CouponService.GetCouponUsage(c, couponID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCouponUsage{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get coupon usage successfully",
+			Usage:   usage,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Create a coupon
+// @Description  Codes are unique within the business and matched case insensitively. A coupon can be used at
+// @Description  every outlet of the business unless outlet_ids lists the ones it is for. max_uses limits
+// @Description  redemptions overall and max_uses_per_customer per customer, 0 is unlimited. Coupons with
+// @Description  customer_ids, customer_tiers, a per customer limit or first_order_only need a sale with a customer.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                   true  "Business id"
+// @Param        request     body  validation.CreateCoupon  true  "Request body"
+// @Router       /businesses/{businessId}/coupons [post]
+// @Success      201  {object}  response.SuccessWithCoupon
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Business not found"
+// @Failure      409  {object}  response.ErrorDetails  "Coupon code already exists"
+func (s *CouponController) CreateCoupon(c *fiber.Ctx) error {
+	req := new(validation.CreateCoupon)
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	coupon, err := s.CouponService.CreateCoupon(c, businessID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithCoupon{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create coupon successfully",
+			Coupon:  *coupon,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Generate campaign coupons
+// @Description  Creates count unique single use codes with the same offer for a campaign. Download them with
+// @Description  the export endpoint.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                      true  "Business id"
+// @Param        request     body  validation.GenerateCoupons  true  "Request body"
+// @Router       /businesses/{businessId}/coupons/generate [post]
+// @Success      201  {object}  response.SuccessWithCoupons
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Business not found"
+// @Failure      409  {object}  response.ErrorDetails  "Coupon code already exists"
+func (s *CouponController) GenerateCoupons(c *fiber.Ctx) error {
+	req := new(validation.GenerateCoupons)
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	coupons, err := s.CouponService.GenerateCoupons(c, businessID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithCoupons{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Generate coupons successfully",
+			Coupons: coupons,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Export coupons
+// @Description  Downloads the codes of the business as CSV, only those of one campaign when campaign is set.
+// @Security     BearerAuth
+// @Produce      text/csv
+// @Param        businessId  path   string  true   "Business id"
+// @Param        campaign    query  string  false  "Campaign name"
+// @Router       /businesses/{businessId}/coupons/export [get]
+// @Success      200  {file}    file
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *CouponController) ExportCoupons(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	coupons, err := s.CouponService.ExportCoupons(c, businessID, c.Query("campaign", ""))
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{{
+		"code", "campaign", "discount_type", "discount_value", "max_uses", "used_count",
+		"start_date", "end_date", "is_active",
+	}}
+	for i := range coupons {
+		coupon := &coupons[i]
+		campaign := ""
+		if coupon.Campaign != nil {
+			campaign = *coupon.Campaign
+		}
+		rows = append(rows, []string{
+			coupon.Code, campaign, coupon.DiscountType, coupon.DiscountValue.String(),
+			strconv.Itoa(coupon.MaxUses), strconv.Itoa(coupon.UsedCount),
+			coupon.StartDate.Format(time.RFC3339), coupon.EndDate.Format(time.RFC3339),
+			strconv.FormatBool(coupon.IsActive),
+		})
+	}
+
+	c.Attachment("coupons.csv")
+	c.Set(fiber.HeaderContentType, "text/csv")
+
+	return csv.NewWriter(c.Response().BodyWriter()).WriteAll(rows)
+}
+
+// @Tags         Coupons
+// @Summary      Update a coupon
+// @Description  Redemptions already made are kept, the new terms apply to the next ones.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        couponId  path  string                   true  "Coupon id"
+// @Param        request   body  validation.UpdateCoupon  true  "Request body"
+// @Router       /coupons/{couponId} [patch]
+// @Success      200  {object}  response.SuccessWithCoupon
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Coupon not found"
+func (s *CouponController) UpdateCoupon(c *fiber.Ctx) error {
+	req := new(validation.UpdateCoupon)
+	couponID := c.Params("couponId")
+
+	if _, err := uuid.Parse(couponID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid coupon ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	coupon, err := s.CouponService.UpdateCoupon(c, couponID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCoupon{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update coupon successfully",
+			Coupon:  *coupon,
+		})
+}
+
+// @Tags         Coupons
+// @Summary      Check a coupon code
+// @Description  Tells whether the code can be applied at the outlet and what it would take off, without
+// @Description  redeeming it. A rejected code comes back with valid false and a reason: not_found, inactive,
+// @Description  not_started, expired, not_at_outlet, used_up, already_applied, customer_required, not_eligible,
+// @Description  not_first_order or customer_used_up. Pass customer_id to check the customer limits without a sale.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  validation.ValidateCoupon  true  "Request body"
+// @Router       /coupons/validate [post]
+// @Success      200  {object}  response.SuccessWithCouponValidation
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *CouponController) ValidateCoupon(c *fiber.Ctx) error {
+	req := new(validation.ValidateCoupon)
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	result, err := s.CouponService.ValidateCoupon(c, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCouponValidation{
+			Code:             fiber.StatusOK,
+			Status:           "success",
+			Message:          "Validate coupon successfully",
+			CouponValidation: *result,
+		})
+}
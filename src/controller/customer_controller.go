@@ -0,0 +1,335 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CustomerController struct {
+	CustomerService service.CustomerService
+}
+
+func NewCustomerController(customerService service.CustomerService) *CustomerController {
+	return &CustomerController{
+		CustomerService: customerService,
+	}
+}
+
+// @Tags         Customers
+// @Summary      Get business customers
+// @Description  Customers are shared by all outlets of the business. The search matches part of the name or
+// @Description  email, or the digits of the phone number.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        businessId  path      string  true   "Business id"
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        limit       query     int     false  "Maximum number of customers"  default(10)
+// @Param        search      query     string  false  "Name, email or phone"
+// @Param        tier        query     string  false  "Customer tier"
+// @Router       /businesses/{businessId}/customers [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.Customer]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *CustomerController) GetCustomers(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	query := &validation.QueryCustomer{
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 10),
+		Search: c.Query("search", ""),
+		Tier:   c.Query("tier", ""),
+	}
+
+	customers, totalResults, err := s.CustomerService.GetCustomers(c, businessID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.Customer]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all customers successfully",
+			Results:      customers,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Find duplicate customers
+// @Description  Groups customers of the business that share a phone number or a name, to be merged.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        businessId  path  string  true  "Business id"
+// @Router       /businesses/{businessId}/customers/duplicates [get]
+// @Success      200  {object}  response.SuccessWithCustomerDuplicates
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *CustomerController) GetCustomerDuplicates(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	duplicates, err := s.CustomerService.GetCustomerDuplicates(c, businessID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCustomerDuplicates{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Get duplicate customers successfully",
+			Duplicates: duplicates,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Get a customer
+// @Security     BearerAuth
+// @Produce      json
+// @Param        customerId  path  string  true  "Customer id"
+// @Router       /customers/{customerId} [get]
+// @Success      200  {object}  response.SuccessWithCustomer
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Customer not found"
+func (s *CustomerController) GetCustomerByID(c *fiber.Ctx) error {
+	customerID := c.Params("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	customer, err := s.CustomerService.GetCustomerByID(c, customerID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCustomer{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get customer successfully",
+			Customer: *customer,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Get customer visits
+// @Description  The sales of the customer at every outlet of the business, latest first. Voided sales and
+// @Description  sales merged into another are left out.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        customerId  path      string  true   "Customer id"
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        limit       query     int     false  "Maximum number of visits"  default(10)
+// @Param        outlet_id   query     string  false  "Only visits to this outlet"
+// @Router       /customers/{customerId}/visits [get]
+// @Success      200  {object}  response.SuccessWithPaginate[response.CustomerVisit]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Customer not found"
+func (s *CustomerController) GetCustomerVisits(c *fiber.Ctx) error {
+	customerID := c.Params("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	query := &validation.QueryCustomerVisit{
+		Page:     c.QueryInt("page", 1),
+		Limit:    c.QueryInt("limit", 10),
+		OutletID: c.Query("outlet_id", ""),
+	}
+
+	visits, totalResults, err := s.CustomerService.GetCustomerVisits(c, customerID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[response.CustomerVisit]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get customer visits successfully",
+			Results:      visits,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Create a customer
+// @Description  Emails are optional and unique within the business.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                     true  "Business id"
+// @Param        request     body  validation.CreateCustomer  true  "Request body"
+// @Router       /businesses/{businessId}/customers [post]
+// @Success      201  {object}  response.SuccessWithCustomer
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Business not found"
+// @Failure      409  {object}  response.ErrorDetails  "Email is already in use"
+func (s *CustomerController) CreateCustomer(c *fiber.Ctx) error {
+	req := new(validation.CreateCustomer)
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	customer, err := s.CustomerService.CreateCustomer(c, businessID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithCustomer{
+			Code:     fiber.StatusCreated,
+			Status:   "success",
+			Message:  "Create customer successfully",
+			Customer: *customer,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Update a customer
+// @Description  Send an empty string to clear the email, phone, address or tier.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        customerId  path  string                     true  "Customer id"
+// @Param        request     body  validation.UpdateCustomer  true  "Request body"
+// @Router       /customers/{customerId} [patch]
+// @Success      200  {object}  response.SuccessWithCustomer
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Customer not found"
+// @Failure      409  {object}  response.ErrorDetails  "Email is already in use"
+func (s *CustomerController) UpdateCustomer(c *fiber.Ctx) error {
+	req := new(validation.UpdateCustomer)
+	customerID := c.Params("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	customer, err := s.CustomerService.UpdateCustomer(c, customerID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCustomer{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Update customer successfully",
+			Customer: *customer,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Merge duplicate customers
+// @Description  Moves the sales, reservations, waitlist entries and coupons of the listed customers to this
+// @Description  one, fills in details it is missing, adds up loyalty points and no-shows, then deletes them.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        customerId  path  string                     true  "Customer id"
+// @Param        request     body  validation.MergeCustomers  true  "Request body"
+// @Router       /customers/{customerId}/merge [post]
+// @Success      200  {object}  response.SuccessWithCustomer
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Customer not found"
+func (s *CustomerController) MergeCustomers(c *fiber.Ctx) error {
+	req := new(validation.MergeCustomers)
+	customerID := c.Params("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	customer, err := s.CustomerService.MergeCustomers(c, customerID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithCustomer{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Merge customers successfully",
+			Customer: *customer,
+		})
+}
+
+// @Tags         Customers
+// @Summary      Delete a customer
+// @Description  Sales and reservations of the customer are kept without a customer.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        customerId  path  string  true  "Customer id"
+// @Router       /customers/{customerId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Customer not found"
+func (s *CustomerController) DeleteCustomer(c *fiber.Ctx) error {
+	customerID := c.Params("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid customer ID")
+	}
+
+	if err := s.CustomerService.DeleteCustomer(c, customerID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete customer successfully",
+		})
+}
@@ -0,0 +1,350 @@
+package controller
+
+import (
+	"app/src/config"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type KitchenController struct {
+	KitchenService service.KitchenService
+}
+
+func NewKitchenController(kitchenService service.KitchenService) *KitchenController {
+	return &KitchenController{
+		KitchenService: kitchenService,
+	}
+}
+
+// @Tags         Kitchen
+// @Summary      Get the kitchen routes of an outlet
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Router       /outlets/{outletId}/kitchen-routes [get]
+// @Success      200  {object}  response.SuccessWithKitchenRoutes
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (k *KitchenController) GetKitchenRoutes(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	routes, err := k.KitchenService.GetKitchenRoutes(c, outletID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithKitchenRoutes{
+			Code:          fiber.StatusOK,
+			Status:        "success",
+			Message:       "Get kitchen routes successfully",
+			KitchenRoutes: routes,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Route a product category or a product to a kitchen printer or display
+// @Description  Product routes win over the route of their category. Items without a route are
+// @Description  not sent to the kitchen. Printers with the display connection type are kitchen
+// @Description  display stations, their items are shown on the kitchen feed instead of printed.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                         true  "Outlet id"
+// @Param        request   body  validation.CreateKitchenRoute  true  "Request body"
+// @Router       /outlets/{outletId}/kitchen-routes [post]
+// @Success      201  {object}  response.SuccessWithKitchenRoute
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+// @Failure      409  {object}  response.ErrorDetails  "Route already exists"
+func (k *KitchenController) CreateKitchenRoute(c *fiber.Ctx) error {
+	req := new(validation.CreateKitchenRoute)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	route, err := k.KitchenService.CreateKitchenRoute(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithKitchenRoute{
+			Code:         fiber.StatusCreated,
+			Status:       "success",
+			Message:      "Create kitchen route successfully",
+			KitchenRoute: *route,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Delete a kitchen route
+// @Security     BearerAuth
+// @Produce      json
+// @Param        routeId  path  string  true  "Kitchen route id"
+// @Router       /kitchen-routes/{routeId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Kitchen route not found"
+func (k *KitchenController) DeleteKitchenRoute(c *fiber.Ctx) error {
+	routeID := c.Params("routeId")
+
+	if _, err := uuid.Parse(routeID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid kitchen route ID")
+	}
+
+	if err := k.KitchenService.DeleteKitchenRoute(c, routeID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete kitchen route successfully",
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Send new items to the kitchen
+// @Description  Queues one ticket per kitchen printer with the items of the sale that were not sent
+// @Description  yet, then marks them as fired. Removing or voiding fired items prints a void ticket.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/fire [post]
+// @Success      202  {object}  response.SuccessWithPrintJobs
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (k *KitchenController) FireSale(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	jobs, err := k.KitchenService.FireSale(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).
+		JSON(response.SuccessWithPrintJobs{
+			Code:      fiber.StatusAccepted,
+			Status:    "success",
+			Message:   "Send items to the kitchen successfully",
+			PrintJobs: jobs,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Reprint the kitchen tickets of a sale
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/kitchen-tickets/reprint [post]
+// @Success      202  {object}  response.SuccessWithPrintJobs
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (k *KitchenController) ReprintKitchenTickets(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	jobs, err := k.KitchenService.ReprintKitchenTickets(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).
+		JSON(response.SuccessWithPrintJobs{
+			Code:      fiber.StatusAccepted,
+			Status:    "success",
+			Message:   "Reprint kitchen tickets successfully",
+			PrintJobs: jobs,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Get the items of the kitchen displays
+// @Description  Lists the items still to be served when status is empty, oldest first. Displays
+// @Description  load it after connecting to the kitchen feed, then apply the feed events.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        station   query  string  false  "Display station id"
+// @Param        status    query  string  false  "Item status"  Enums(new, in_progress, ready, served, void)
+// @Router       /outlets/{outletId}/kitchen-items [get]
+// @Success      200  {object}  response.SuccessWithKitchenItems
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (k *KitchenController) GetKitchenItems(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryKitchenItem{
+		StationID: c.Query("station", ""),
+		Status:    c.Query("status", ""),
+	}
+
+	items, err := k.KitchenService.GetKitchenItems(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithKitchenItems{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get kitchen items successfully",
+			KitchenItems: items,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Bump a kitchen item
+// @Description  Moves the item forward through new, in_progress, ready and served. The front of
+// @Description  house gets an order_ready event on the outlet feed once every item of the sale is ready.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        itemId   path  string                        true  "Kitchen item id"
+// @Param        request  body  validation.UpdateKitchenItem  true  "Request body"
+// @Router       /kitchen-items/{itemId} [patch]
+// @Success      200  {object}  response.SuccessWithKitchenItem
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Kitchen item not found"
+func (k *KitchenController) UpdateKitchenItem(c *fiber.Ctx) error {
+	req := new(validation.UpdateKitchenItem)
+	itemID := c.Params("itemId")
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid kitchen item ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	item, err := k.KitchenService.UpdateKitchenItem(c, itemID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithKitchenItem{
+			Code:        fiber.StatusOK,
+			Status:      "success",
+			Message:     "Update kitchen item successfully",
+			KitchenItem: *item,
+		})
+}
+
+// @Tags         Kitchen
+// @Summary      Kitchen display feed
+// @Description  WebSocket pushing response.KitchenEvent messages: fired, updated and voided items,
+// @Description  and order_ready on the outlet feed. Without station the feed has every station of
+// @Description  the outlet, which is what front of house terminals listen to. Browsers can't set the
+// @Description  Authorization header on a websocket, they send the access token as access_token instead.
+// @Security     BearerAuth
+// @Param        outletId      path   string  true   "Outlet id"
+// @Param        station       query  string  false  "Display station id"
+// @Param        access_token  query  string  false  "Access token, when the Authorization header can't be set"
+// @Router       /outlets/{outletId}/kitchen-feed [get]
+// @Success      101
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      426  {object}  response.ErrorDetails  "Upgrade Required"
+func (k *KitchenController) KitchenFeedUpgrade(c *fiber.Ctx) error {
+	if _, err := uuid.Parse(c.Params("outletId")); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if station := c.Query("station"); station != "" {
+		if _, err := uuid.Parse(station); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid station ID")
+		}
+	}
+
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	if err := k.KitchenService.CheckKitchenFeed(c, c.Params("outletId")); err != nil {
+		return err
+	}
+
+	return c.Next()
+}
+
+// KitchenFeed writes the feed to a connected display until it goes away.
+func (k *KitchenController) KitchenFeed(conn *websocket.Conn) {
+	messages, stop := k.KitchenService.SubscribeKitchenFeed(conn.Params("outletId"), conn.Query("station"))
+	defer stop()
+
+	// Displays don't send anything, reading only notices when they close the connection.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(config.KitchenFeedPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
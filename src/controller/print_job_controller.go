@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PrintJobController struct {
+	PrintJobService service.PrintJobService
+}
+
+func NewPrintJobController(printJobService service.PrintJobService) *PrintJobController {
+	return &PrintJobController{
+		PrintJobService: printJobService,
+	}
+}
+
+// @Tags         Print Jobs
+// @Summary      Get the print jobs of a printer
+// @Security     BearerAuth
+// @Produce      json
+// @Param        printerId  path      string  true   "Printer id"
+// @Param        page       query     int     false  "Page number"  default(1)
+// @Param        limit      query     int     false  "Maximum number of print jobs"  default(10)
+// @Param        status     query     string  false  "Job status"  Enums(pending, printing, printed, failed)
+// @Router       /printers/{printerId}/jobs [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.PrintJob]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (p *PrintJobController) GetPrintJobs(c *fiber.Ctx) error {
+	printerID := c.Params("printerId")
+
+	if _, err := uuid.Parse(printerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid printer ID")
+	}
+
+	query := &validation.QueryPrintJob{
+		Page:   c.QueryInt("page", 1),
+		Limit:  c.QueryInt("limit", 10),
+		Status: c.Query("status", ""),
+	}
+
+	jobs, totalResults, err := p.PrintJobService.GetPrintJobs(c, printerID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.PrintJob]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all print jobs successfully",
+			Results:      jobs,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Print Jobs
+// @Summary      Get a print job
+// @Security     BearerAuth
+// @Produce      json
+// @Param        jobId  path  string  true  "Print job id"
+// @Router       /print-jobs/{jobId} [get]
+// @Success      200  {object}  response.SuccessWithPrintJob
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Print job not found"
+func (p *PrintJobController) GetPrintJobByID(c *fiber.Ctx) error {
+	jobID := c.Params("jobId")
+
+	if _, err := uuid.Parse(jobID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid print job ID")
+	}
+
+	job, err := p.PrintJobService.GetPrintJobByID(c, jobID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPrintJob{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get print job successfully",
+			PrintJob: *job,
+		})
+}
+
+// @Tags         Print Jobs
+// @Summary      Print the receipt of a sale
+// @Description  Queues the receipt for a network printer of the outlet, the default printer when
+// @Description  printer_id is empty. Jobs are sent over TCP port 9100 and retried with backoff
+// @Description  while the printer is offline.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                   true  "Sale id"
+// @Param        request  body  validation.PrintReceipt  true  "Request body"
+// @Router       /sales/{saleId}/print [post]
+// @Success      202  {object}  response.SuccessWithPrintJob
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (p *PrintJobController) PrintReceipt(c *fiber.Ctx) error {
+	req := new(validation.PrintReceipt)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	job, err := p.PrintJobService.PrintReceipt(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).
+		JSON(response.SuccessWithPrintJob{
+			Code:     fiber.StatusAccepted,
+			Status:   "success",
+			Message:  "Queue receipt successfully",
+			PrintJob: *job,
+		})
+}
+
+// @Tags         Print Jobs
+// @Summary      Print a test page
+// @Security     BearerAuth
+// @Produce      json
+// @Param        printerId  path  string  true  "Printer id"
+// @Router       /printers/{printerId}/test-print [post]
+// @Success      202  {object}  response.SuccessWithPrintJob
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Printer not found"
+func (p *PrintJobController) TestPrint(c *fiber.Ctx) error {
+	printerID := c.Params("printerId")
+
+	if _, err := uuid.Parse(printerID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid printer ID")
+	}
+
+	job, err := p.PrintJobService.TestPrint(c, printerID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).
+		JSON(response.SuccessWithPrintJob{
+			Code:     fiber.StatusAccepted,
+			Status:   "success",
+			Message:  "Queue test print successfully",
+			PrintJob: *job,
+		})
+}
+
+// @Tags         Print Jobs
+// @Summary      Retry a failed print job
+// @Security     BearerAuth
+// @Produce      json
+// @Param        jobId  path  string  true  "Print job id"
+// @Router       /print-jobs/{jobId}/retry [post]
+// @Success      200  {object}  response.SuccessWithPrintJob
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Print job not found"
+func (p *PrintJobController) RetryPrintJob(c *fiber.Ctx) error {
+	jobID := c.Params("jobId")
+
+	if _, err := uuid.Parse(jobID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid print job ID")
+	}
+
+	job, err := p.PrintJobService.RetryPrintJob(c, jobID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPrintJob{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Retry print job successfully",
+			PrintJob: *job,
+		})
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromotionController struct {
+	PromotionService service.PromotionService
+}
+
+func NewPromotionController(promotionService service.PromotionService) *PromotionController {
+	return &PromotionController{
+		PromotionService: promotionService,
+	}
+}
+
+// @Tags         Promotions
+// @Summary      Get business promotions
+// @Description  List the promotions of a business, including the outlet specific ones, highest priority first.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        businessId  path  string  true  "Business id"
+// @Router       /businesses/{businessId}/promotions [get]
+// @Success      200  {object}  response.SuccessWithPromotions
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *PromotionController) GetPromotions(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	promotions, err := s.PromotionService.GetPromotions(c, businessID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPromotions{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Get promotions successfully",
+			Promotions: promotions,
+		})
+}
+
+// @Tags         Promotions
+// @Summary      Get a promotion
+// @Security     BearerAuth
+// @Produce      json
+// @Param        promotionId  path  string  true  "Promotion id"
+// @Router       /promotions/{promotionId} [get]
+// @Success      200  {object}  response.SuccessWithPromotion
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Promotion not found"
+func (s *PromotionController) GetPromotionByID(c *fiber.Ctx) error {
+	promotionID := c.Params("promotionId")
+
+	if _, err := uuid.Parse(promotionID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	promotion, err := s.PromotionService.GetPromotionByID(c, promotionID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPromotion{
+			Code:      fiber.StatusOK,
+			Status:    "success",
+			Message:   "Get promotion successfully",
+			Promotion: *promotion,
+		})
+}
+
+// @Tags         Promotions
+// @Summary      Create a promotion
+// @Description  Promotions are applied to sales automatically when their conditions are met: a minimum spend,
+// @Description  products or categories, dates, days and a daily time window, and customer tiers. Actions are
+// @Description  percent_off, amount_off, buy_x_get_y, cheapest_free and bundle_price. Higher priorities are
+// @Description  applied first, a promotion that does not stack is only applied on its own. Coupons come off
+// @Description  what is left after the promotions. Without outlet_id it runs at every outlet of the business.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                      true  "Business id"
+// @Param        request     body  validation.CreatePromotion  true  "Request body"
+// @Router       /businesses/{businessId}/promotions [post]
+// @Success      201  {object}  response.SuccessWithPromotion
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Business not found"
+func (s *PromotionController) CreatePromotion(c *fiber.Ctx) error {
+	req := new(validation.CreatePromotion)
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	promotion, err := s.PromotionService.CreatePromotion(c, businessID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithPromotion{
+			Code:      fiber.StatusCreated,
+			Status:    "success",
+			Message:   "Create promotion successfully",
+			Promotion: *promotion,
+		})
+}
+
+// @Tags         Promotions
+// @Summary      Update a promotion
+// @Description  Open sales pick up the change the next time they are amended.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        promotionId  path  string                      true  "Promotion id"
+// @Param        request      body  validation.UpdatePromotion  true  "Request body"
+// @Router       /promotions/{promotionId} [patch]
+// @Success      200  {object}  response.SuccessWithPromotion
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Promotion not found"
+func (s *PromotionController) UpdatePromotion(c *fiber.Ctx) error {
+	req := new(validation.UpdatePromotion)
+	promotionID := c.Params("promotionId")
+
+	if _, err := uuid.Parse(promotionID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	promotion, err := s.PromotionService.UpdatePromotion(c, promotionID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPromotion{
+			Code:      fiber.StatusOK,
+			Status:    "success",
+			Message:   "Update promotion successfully",
+			Promotion: *promotion,
+		})
+}
+
+// @Tags         Promotions
+// @Summary      Delete a promotion
+// @Description  Sales keep the promotions they got by name.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        promotionId  path  string  true  "Promotion id"
+// @Router       /promotions/{promotionId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Promotion not found"
+func (s *PromotionController) DeletePromotion(c *fiber.Ctx) error {
+	promotionID := c.Params("promotionId")
+
+	if _, err := uuid.Parse(promotionID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID")
+	}
+
+	if err := s.PromotionService.DeletePromotion(c, promotionID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete promotion successfully",
+		})
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReceiptController struct {
+	ReceiptService service.ReceiptService
+}
+
+func NewReceiptController(receiptService service.ReceiptService) *ReceiptController {
+	return &ReceiptController{
+		ReceiptService: receiptService,
+	}
+}
+
+// @Tags         Receipts
+// @Summary      Get the ESC/POS print data of a receipt
+// @Description  Renders the receipt of a sale as ESC/POS commands for a thermal printer of the outlet.
+// @Description  The default printer of the outlet is used when printer_id is not given.
+// @Security     BearerAuth
+// @Produce      octet-stream
+// @Param        saleId       path   string  true   "Sale id"
+// @Param        printer_id   query  string  false  "Printer id"
+// @Param        paper_width  query  int     false  "Paper width in millimetres"  Enums(58, 80)
+// @Param        language     query  string  false  "Receipt language"  Enums(en, id)
+// @Router       /sales/{saleId}/receipt [get]
+// @Success      200  {file}    binary
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (r *ReceiptController) GetReceipt(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	doc, err := r.ReceiptService.RenderReceipt(c, saleID, queryReceipt(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	return c.Status(fiber.StatusOK).Send(doc.Bytes())
+}
+
+// @Tags         Receipts
+// @Summary      Preview a receipt as plain text
+// @Description  Renders the receipt of a sale with the same layout as the printer, for testing.
+// @Description  The logo and QR code are shown as placeholders.
+// @Security     BearerAuth
+// @Produce      plain
+// @Param        saleId       path   string  true   "Sale id"
+// @Param        printer_id   query  string  false  "Printer id"
+// @Param        paper_width  query  int     false  "Paper width in millimetres"  Enums(58, 80)
+// @Param        language     query  string  false  "Receipt language"  Enums(en, id)
+// @Router       /sales/{saleId}/receipt/preview [get]
+// @Success      200  {string}  string
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (r *ReceiptController) PreviewReceipt(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	doc, err := r.ReceiptService.RenderReceipt(c, saleID, queryReceipt(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.Status(fiber.StatusOK).SendString(doc.String())
+}
+
+func queryReceipt(c *fiber.Ctx) *validation.QueryReceipt {
+	return &validation.QueryReceipt{
+		PrinterID:  c.Query("printer_id", ""),
+		PaperWidth: c.QueryInt("paper_width", 0),
+		Language:   c.Query("language", ""),
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RefundController struct {
+	RefundService service.RefundService
+}
+
+func NewRefundController(refundService service.RefundService) *RefundController {
+	return &RefundController{
+		RefundService: refundService,
+	}
+}
+
+// @Tags         Refunds
+// @Summary      Get refunds of a sale
+// @Description  List every refund recorded against a sale, including its refunded items.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/refunds [get]
+// @Success      200  {object}  response.SuccessWithRefunds
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (r *RefundController) GetRefunds(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	refunds, err := r.RefundService.GetRefundsBySaleID(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithRefunds{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get refunds successfully",
+			Refunds: refunds,
+		})
+}
+
+// @Tags         Refunds
+// @Summary      Get a refund
+// @Security     BearerAuth
+// @Produce      json
+// @Param        refundId  path  string  true  "Refund id"
+// @Router       /refunds/{refundId} [get]
+// @Success      200  {object}  response.SuccessWithRefund
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (r *RefundController) GetRefundByID(c *fiber.Ctx) error {
+	refundID := c.Params("refundId")
+
+	if _, err := uuid.Parse(refundID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid refund ID")
+	}
+
+	refund, err := r.RefundService.GetRefundByID(c, refundID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithRefund{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get refund successfully",
+			Refund:  *refund,
+		})
+}
+
+// @Tags         Refunds
+// @Summary      Refund sale items
+// @Description  Refund some or all units of a paid sale's items. Quantities cannot exceed what is left to refund.
+// @Description  When restock is set, a stock movement is posted for every refunded item. Refunds never add up
+// @Description  to more than was paid, the delivery fee and cash rounding are not refunded.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                   true  "Sale id"
+// @Param        request  body  validation.CreateRefund  true  "Request body"
+// @Router       /sales/{saleId}/refunds [post]
+// @Success      201  {object}  response.SuccessWithRefund
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (r *RefundController) CreateRefund(c *fiber.Ctx) error {
+	req := new(validation.CreateRefund)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	refund, err := r.RefundService.CreateRefund(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithRefund{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create refund successfully",
+			Refund:  *refund,
+		})
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReportController struct {
+	ReportService service.ReportService
+}
+
+func NewReportController(reportService service.ReportService) *ReportController {
+	return &ReportController{
+		ReportService: reportService,
+	}
+}
+
+// @Tags         Reports
+// @Summary      Get the end of day report
+// @Description  Sales totals of an outlet for one day, including cash rounding, refunds and payments per method.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        date      query  string  false  "Day to report as YYYY-MM-DD, defaults to today"
+// @Router       /outlets/{outletId}/reports/end-of-day [get]
+// @Success      200  {object}  response.SuccessWithEndOfDayReport
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *ReportController) GetEndOfDay(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryEndOfDay{
+		Date: c.Query("date", ""),
+	}
+
+	report, err := s.ReportService.GetEndOfDay(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithEndOfDayReport{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get end of day report successfully",
+			Report:  *report,
+		})
+}
+
+// @Tags         Reports
+// @Summary      Get the tip pool report
+// @Description  Pools the tips paid during a shift and shares them between the staff who worked it, using the
+// @Description  outlet's tip_pool_rule setting: equal, hours or role (weighted by tip_pool_role_weights).
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true  "Outlet id"
+// @Param        from      query  string  true  "Start of the shift, RFC 3339"
+// @Param        to        query  string  true  "End of the shift, RFC 3339"
+// @Router       /outlets/{outletId}/reports/tip-pool [get]
+// @Success      200  {object}  response.SuccessWithTipPoolReport
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *ReportController) GetTipPool(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryTipPool{
+		From: c.Query("from", ""),
+		To:   c.Query("to", ""),
+	}
+
+	report, err := s.ReportService.GetTipPool(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTipPoolReport{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get tip pool report successfully",
+			Report:  *report,
+		})
+}
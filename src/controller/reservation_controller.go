@@ -0,0 +1,371 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReservationController struct {
+	ReservationService service.ReservationService
+}
+
+func NewReservationController(reservationService service.ReservationService) *ReservationController {
+	return &ReservationController{
+		ReservationService: reservationService,
+	}
+}
+
+// @Tags         Reservations
+// @Summary      Get the reservations of an outlet
+// @Description  The reservations of a day, today when date is left out, by time.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        date      query  string  false  "Day, YYYY-MM-DD"
+// @Param        status    query  string  false  "booked, confirmed, seated, completed, cancelled or no_show"
+// @Router       /outlets/{outletId}/reservations [get]
+// @Success      200  {object}  response.SuccessWithReservations
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (r *ReservationController) GetReservations(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryReservation{
+		Date:   c.Query("date", ""),
+		Status: c.Query("status", ""),
+	}
+
+	reservations, err := r.ReservationService.GetReservations(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithReservations{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get reservations successfully",
+			Reservations: reservations,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Create a reservation
+// @Description  Books table_id, or the smallest free table that fits the party when it is left out. The table
+// @Description  must seat the party and not be booked at an overlapping time. The duration defaults to the
+// @Description  reservation_minutes setting. A confirmation is emailed when the guest has an email.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                        true  "Outlet id"
+// @Param        request   body  validation.CreateReservation  true  "Request body"
+// @Router       /outlets/{outletId}/reservations [post]
+// @Success      201  {object}  response.SuccessWithReservation
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+// @Failure      409  {object}  response.ErrorDetails  "Table is already booked at that time"
+func (r *ReservationController) CreateReservation(c *fiber.Ctx) error {
+	req := new(validation.CreateReservation)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	reservation, err := r.ReservationService.CreateReservation(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithReservation{
+			Code:        fiber.StatusCreated,
+			Status:      "success",
+			Message:     "Create reservation successfully",
+			Reservation: *reservation,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Suggest tables for a reservation
+// @Description  The tables that seat the party and are free for the whole reservation, the best fit first.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId          path   string  true   "Outlet id"
+// @Param        party_size        query  int     true   "Party size"
+// @Param        reserved_at       query  string  true   "Start, RFC 3339"
+// @Param        duration_minutes  query  int     false  "Duration in minutes"
+// @Router       /outlets/{outletId}/table-suggestions [get]
+// @Success      200  {object}  response.SuccessWithTables
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (r *ReservationController) SuggestTables(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryTableSuggestion{
+		PartySize:       c.QueryInt("party_size", 0),
+		ReservedAt:      c.Query("reserved_at", ""),
+		DurationMinutes: c.QueryInt("duration_minutes", 0),
+	}
+
+	tables, err := r.ReservationService.SuggestTables(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTables{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Suggest tables successfully",
+			Tables:  tables,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Get a reservation
+// @Security     BearerAuth
+// @Produce      json
+// @Param        reservationId  path  string  true  "Reservation id"
+// @Router       /reservations/{reservationId} [get]
+// @Success      200  {object}  response.SuccessWithReservation
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Reservation not found"
+func (r *ReservationController) GetReservationByID(c *fiber.Ctx) error {
+	reservationID := c.Params("reservationId")
+
+	if _, err := uuid.Parse(reservationID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID")
+	}
+
+	reservation, err := r.ReservationService.GetReservationByID(c, reservationID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithReservation{
+			Code:        fiber.StatusOK,
+			Status:      "success",
+			Message:     "Get reservation successfully",
+			Reservation: *reservation,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Update a reservation
+// @Description  Only booked and confirmed reservations can be changed. The table is checked again when the
+// @Description  party, time or table change, and a new time is reminded again.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        reservationId  path  string                        true  "Reservation id"
+// @Param        request        body  validation.UpdateReservation  true  "Request body"
+// @Router       /reservations/{reservationId} [patch]
+// @Success      200  {object}  response.SuccessWithReservation
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Reservation not found"
+// @Failure      409  {object}  response.ErrorDetails  "Table is already booked at that time"
+func (r *ReservationController) UpdateReservation(c *fiber.Ctx) error {
+	req := new(validation.UpdateReservation)
+	reservationID := c.Params("reservationId")
+
+	if _, err := uuid.Parse(reservationID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	reservation, err := r.ReservationService.UpdateReservation(c, reservationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithReservation{
+			Code:        fiber.StatusOK,
+			Status:      "success",
+			Message:     "Update reservation successfully",
+			Reservation: *reservation,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Set the status of a reservation
+// @Description  Booked reservations can be confirmed, seated, cancelled or marked no_show, confirmed ones
+// @Description  seated, cancelled or marked no_show, and seated ones completed. No-shows are counted on the
+// @Description  reservation's customer.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        reservationId  path  string                              true  "Reservation id"
+// @Param        request        body  validation.UpdateReservationStatus  true  "Request body"
+// @Router       /reservations/{reservationId}/status [patch]
+// @Success      200  {object}  response.SuccessWithReservation
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Reservation not found"
+func (r *ReservationController) UpdateReservationStatus(c *fiber.Ctx) error {
+	req := new(validation.UpdateReservationStatus)
+	reservationID := c.Params("reservationId")
+
+	if _, err := uuid.Parse(reservationID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	reservation, err := r.ReservationService.UpdateReservationStatus(c, reservationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithReservation{
+			Code:        fiber.StatusOK,
+			Status:      "success",
+			Message:     "Update reservation status successfully",
+			Reservation: *reservation,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Get the waitlist of an outlet
+// @Description  The waiting walk-in parties in the order they arrived. The estimated wait is worked out from
+// @Description  when the tables that fit each party are expected to free up and the parties ahead of it.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Router       /outlets/{outletId}/waitlist [get]
+// @Success      200  {object}  response.SuccessWithWaitlist
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (r *ReservationController) GetWaitlist(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	waitlist, err := r.ReservationService.GetWaitlist(c, outletID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithWaitlist{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get waitlist successfully",
+			Waitlist: waitlist,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Add a walk-in party to the waitlist
+// @Description  The party is quoted its estimated wait, rounded up to five minutes.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                          true  "Outlet id"
+// @Param        request   body  validation.CreateWaitlistEntry  true  "Request body"
+// @Router       /outlets/{outletId}/waitlist [post]
+// @Success      201  {object}  response.SuccessWithWaitlistEntry
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (r *ReservationController) CreateWaitlistEntry(c *fiber.Ctx) error {
+	req := new(validation.CreateWaitlistEntry)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	entry, err := r.ReservationService.CreateWaitlistEntry(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithWaitlistEntry{
+			Code:          fiber.StatusCreated,
+			Status:        "success",
+			Message:       "Create waitlist entry successfully",
+			WaitlistEntry: *entry,
+		})
+}
+
+// @Tags         Reservations
+// @Summary      Seat a waiting party or mark it gone
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        entryId  path  string                          true  "Waitlist entry id"
+// @Param        request  body  validation.UpdateWaitlistEntry  true  "Request body"
+// @Router       /waitlist/{entryId} [patch]
+// @Success      200  {object}  response.SuccessWithWaitlistEntry
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Waitlist entry not found"
+func (r *ReservationController) UpdateWaitlistEntry(c *fiber.Ctx) error {
+	req := new(validation.UpdateWaitlistEntry)
+	entryID := c.Params("entryId")
+
+	if _, err := uuid.Parse(entryID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid waitlist entry ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	entry, err := r.ReservationService.UpdateWaitlistEntry(c, entryID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithWaitlistEntry{
+			Code:          fiber.StatusOK,
+			Status:        "success",
+			Message:       "Update waitlist entry successfully",
+			WaitlistEntry: *entry,
+		})
+}
@@ -0,0 +1,480 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SaleController struct {
+	SaleService service.SaleService
+}
+
+func NewSaleController(saleService service.SaleService) *SaleController {
+	return &SaleController{
+		SaleService: saleService,
+	}
+}
+
+// @Tags         Sales
+// @Summary      Get sales
+// @Description  List sales of an outlet, a table or a staff member. Use status open to get unpaid and held sales.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page             query     int     false  "Page number"  default(1)
+// @Param        limit            query     int     false  "Maximum number of sales"  default(10)
+// @Param        outlet_id        query     string  false  "Outlet id"
+// @Param        table_id         query     string  false  "Table id"
+// @Param        order_type       query     string  false  "Order type"  Enums(dine_in, takeaway, delivery)
+// @Param        outlet_staff_id  query     string  false  "Staff id"
+// @Param        status           query     string  false  "open, hold, unpaid, paid, void or refunded"
+// @Router       /sales [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.Sale]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *SaleController) GetSales(c *fiber.Ctx) error {
+	query := &validation.QuerySale{
+		Page:          c.QueryInt("page", 1),
+		Limit:         c.QueryInt("limit", 10),
+		OutletID:      c.Query("outlet_id", ""),
+		TableID:       c.Query("table_id", ""),
+		OrderType:     c.Query("order_type", ""),
+		OutletStaffID: c.Query("outlet_staff_id", ""),
+		Status:        c.Query("status", ""),
+	}
+
+	sales, totalResults, err := s.SaleService.GetSales(c, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.Sale]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all sales successfully",
+			Results:      sales,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Get a sale
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId} [get]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (s *SaleController) GetSaleByID(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	sale, err := s.SaleService.GetSaleByID(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Split a sale into several checks
+// @Description  Split an open sale by moving items, units or shares of items to new checks (mode items),
+// @Description  by seat number (mode seats), or into N equal parts (mode equal).
+// @Description  Every resulting sale keeps the table and gets its own prorated discount and tax.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                true  "Sale id"
+// @Param        request  body  validation.SplitSale  true  "Request body"
+// @Router       /sales/{saleId}/split [post]
+// @Success      201  {object}  response.SuccessWithSales
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) SplitSale(c *fiber.Ctx) error {
+	req := new(validation.SplitSale)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sales, err := s.SaleService.SplitSale(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithSales{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Split sale successfully",
+			Sales:   sales,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Void a sale
+// @Description  An open sale can be voided once its payments are voided. A paid sale needs an approved
+// @Description  void_sale approval covering its grand total, and its payments are voided with it.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string               true  "Sale id"
+// @Param        request  body  validation.VoidSale  true  "Request body"
+// @Router       /sales/{saleId}/void [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) VoidSale(c *fiber.Ctx) error {
+	req := new(validation.VoidSale)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sale, err := s.SaleService.VoidSale(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Void sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Open a sale
+// @Description  Dine-in sales need a table. Takeaway sales can have a pickup time and delivery sales
+// @Description  need an address and a contact. Prices, taxes and service charges follow the order type.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  validation.CreateSale  true  "Request body"
+// @Router       /sales [post]
+// @Success      201  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (s *SaleController) CreateSale(c *fiber.Ctx) error {
+	req := new(validation.CreateSale)
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sale, err := s.SaleService.CreateSale(c, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Add items to an open sale
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                   true  "Sale id"
+// @Param        request  body  validation.AddSaleItems  true  "Request body"
+// @Router       /sales/{saleId}/items [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) AddSaleItems(c *fiber.Ctx) error {
+	req := new(validation.AddSaleItems)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sale, err := s.SaleService.AddSaleItems(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Add sale items successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Remove an item from an open sale
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Param        itemId  path  string  true  "Sale item id"
+// @Router       /sales/{saleId}/items/{itemId} [delete]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (s *SaleController) RemoveSaleItem(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+	itemID := c.Params("itemId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale item ID")
+	}
+
+	sale, err := s.SaleService.RemoveSaleItem(c, saleID, itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Remove sale item successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Park an open sale
+// @Description  Put a sale on hold so the next customer can be served. Held sales are voided once the outlet's
+// @Description  held_sale_expiry_minutes setting (or the server default) has passed without being resumed.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/hold [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) HoldSale(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	sale, err := s.SaleService.HoldSale(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Hold sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Resume a held sale
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/resume [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) ResumeSale(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	sale, err := s.SaleService.ResumeSale(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Resume sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Transfer an open sale to another staff member
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                   true  "Sale id"
+// @Param        request  body  validation.TransferSale  true  "Request body"
+// @Router       /sales/{saleId}/transfer [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (s *SaleController) TransferSale(c *fiber.Ctx) error {
+	req := new(validation.TransferSale)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sale, err := s.SaleService.TransferSale(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Transfer sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Apply a coupon to an open sale
+// @Description  Redeems one use of the code, its discount comes off the sale total before service charges
+// @Description  and taxes. The use is given back when the coupon is removed or the sale is voided or refunded.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                  true  "Sale id"
+// @Param        request  body  validation.ApplyCoupon  true  "Request body"
+// @Router       /sales/{saleId}/coupons [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+// @Failure      409  {object}  response.ErrorDetails  "Coupon used up or already applied"
+func (s *SaleController) ApplyCoupon(c *fiber.Ctx) error {
+	req := new(validation.ApplyCoupon)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sale, err := s.SaleService.ApplyCoupon(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Apply coupon successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Sales
+// @Summary      Remove a coupon from an open sale
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId    path  string  true  "Sale id"
+// @Param        couponId  path  string  true  "Coupon id"
+// @Router       /sales/{saleId}/coupons/{couponId} [delete]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (s *SaleController) RemoveCoupon(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+	couponID := c.Params("couponId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if _, err := uuid.Parse(couponID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid coupon ID")
+	}
+
+	sale, err := s.SaleService.RemoveCoupon(c, saleID, couponID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Remove coupon successfully",
+			Sale:    *sale,
+		})
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SalePaymentController struct {
+	SalePaymentService service.SalePaymentService
+}
+
+func NewSalePaymentController(salePaymentService service.SalePaymentService) *SalePaymentController {
+	return &SalePaymentController{
+		SalePaymentService: salePaymentService,
+	}
+}
+
+// @Tags         Sale Payments
+// @Summary      Get payments of a sale
+// @Description  List the payments recorded against a sale together with the paid and remaining balance.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        saleId  path  string  true  "Sale id"
+// @Router       /sales/{saleId}/payments [get]
+// @Success      200  {object}  response.SuccessWithSalePayments
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (p *SalePaymentController) GetPayments(c *fiber.Ctx) error {
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	payments, summary, err := p.SalePaymentService.GetPayments(c, saleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSalePayments{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get sale payments successfully",
+			Payments: payments,
+			Summary:  *summary,
+		})
+}
+
+// @Tags         Sale Payments
+// @Summary      Add a payment to a sale
+// @Description  Pay part or all of a sale with one payment method. Cash may be over-tendered and the change
+// @Description  due is returned. The sale becomes paid once its paid payments cover the grand total.
+// @Description  A tip, fixed or as a percentage of the amount, is paid on top and goes to the staff on the sale
+// @Description  unless another outlet_staff_id is given.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string                        true  "Sale id"
+// @Param        request  body  validation.CreateSalePayment  true  "Request body"
+// @Router       /sales/{saleId}/payments [post]
+// @Success      201  {object}  response.SuccessWithSalePayment
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (p *SalePaymentController) CreatePayment(c *fiber.Ctx) error {
+	req := new(validation.CreateSalePayment)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	payment, summary, err := p.SalePaymentService.CreatePayment(c, saleID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithSalePayment{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create sale payment successfully",
+			Payment: *payment,
+			Summary: *summary,
+		})
+}
+
+// @Tags         Sale Payments
+// @Summary      Update a payment status
+// @Description  Confirm, fail or void a payment. Voiding a payment of a paid sale reopens the sale. Voiding a
+// @Description  paid payment needs a void_payment approval covering its amount. Payments of void, merged or
+// @Description  refunded sales can't change.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId     path  string                        true  "Sale id"
+// @Param        paymentId  path  string                        true  "Payment id"
+// @Param        request    body  validation.UpdateSalePayment  true  "Request body"
+// @Router       /sales/{saleId}/payments/{paymentId} [patch]
+// @Success      200  {object}  response.SuccessWithSalePayment
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (p *SalePaymentController) UpdatePayment(c *fiber.Ctx) error {
+	req := new(validation.UpdateSalePayment)
+	saleID := c.Params("saleId")
+	paymentID := c.Params("paymentId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if _, err := uuid.Parse(paymentID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	payment, summary, err := p.SalePaymentService.UpdatePayment(c, saleID, paymentID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSalePayment{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update sale payment successfully",
+			Payment: *payment,
+			Summary: *summary,
+		})
+}
@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SelfOrderController struct {
+	SelfOrderService service.SelfOrderService
+}
+
+func NewSelfOrderController(selfOrderService service.SelfOrderService) *SelfOrderController {
+	return &SelfOrderController{
+		SelfOrderService: selfOrderService,
+	}
+}
+
+// @Tags         Self-ordering
+// @Summary      Get the menu of a table
+// @Description  Public, for guests who scanned the QR code on their table. Products are at their dine-in price.
+// @Produce      json
+// @Param        token  path  string  true  "Ordering token of the table"
+// @Router       /self-order/{token}/menu [get]
+// @Success      200  {object}  response.SuccessWithSelfOrderMenu
+// @Failure      404  {object}  response.ErrorDetails  "Ordering link is not valid"
+// @Failure      410  {object}  response.ErrorDetails  "Ordering link has expired"
+// @Failure      429  {object}  response.ErrorDetails  "Too many requests from this table"
+func (s *SelfOrderController) GetMenu(c *fiber.Ctx) error {
+	menu, err := s.SelfOrderService.GetMenu(c, c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSelfOrderMenu{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get menu successfully",
+			Menu:    *menu,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Price a cart
+// @Description  Public. Prices the guests' cart with the taxes and service charges of the outlet, nothing is saved.
+// @Accept       json
+// @Produce      json
+// @Param        token    path  string                    true  "Ordering token of the table"
+// @Param        request  body  validation.SelfOrderCart  true  "Request body"
+// @Router       /self-order/{token}/cart [post]
+// @Success      200  {object}  response.SuccessWithSelfOrderCart
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      404  {object}  response.ErrorDetails  "Ordering link is not valid"
+// @Failure      410  {object}  response.ErrorDetails  "Ordering link has expired"
+// @Failure      429  {object}  response.ErrorDetails  "Too many requests from this table"
+func (s *SelfOrderController) PriceCart(c *fiber.Ctx) error {
+	req := new(validation.SelfOrderCart)
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	cart, err := s.SelfOrderService.PriceCart(c, c.Params("token"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSelfOrderCart{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Price cart successfully",
+			Cart:    *cart,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Place an order from a table
+// @Description  Public. The order waits for staff to confirm it onto the open sale of the table.
+// @Accept       json
+// @Produce      json
+// @Param        token    path  string                       true  "Ordering token of the table"
+// @Param        request  body  validation.CreateTableOrder  true  "Request body"
+// @Router       /self-order/{token}/orders [post]
+// @Success      201  {object}  response.SuccessWithTableOrder
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      404  {object}  response.ErrorDetails  "Ordering link is not valid"
+// @Failure      410  {object}  response.ErrorDetails  "Ordering link has expired"
+// @Failure      429  {object}  response.ErrorDetails  "Too many requests from this table"
+func (s *SelfOrderController) CreateTableOrder(c *fiber.Ctx) error {
+	req := new(validation.CreateTableOrder)
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	order, err := s.SelfOrderService.CreateTableOrder(c, c.Params("token"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithTableOrder{
+			Code:       fiber.StatusCreated,
+			Status:     "success",
+			Message:    "Create table order successfully",
+			TableOrder: *order,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Get an order placed from a table
+// @Description  Public, for guests to follow whether their order was confirmed.
+// @Produce      json
+// @Param        token    path  string  true  "Ordering token of the table"
+// @Param        orderId  path  string  true  "Table order id"
+// @Router       /self-order/{token}/orders/{orderId} [get]
+// @Success      200  {object}  response.SuccessWithTableOrder
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      404  {object}  response.ErrorDetails  "Table order not found"
+// @Failure      410  {object}  response.ErrorDetails  "Ordering link has expired"
+// @Failure      429  {object}  response.ErrorDetails  "Too many requests from this table"
+func (s *SelfOrderController) GetTableOrderStatus(c *fiber.Ctx) error {
+	orderID := c.Params("orderId")
+
+	if _, err := uuid.Parse(orderID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table order ID")
+	}
+
+	order, err := s.SelfOrderService.GetTableOrderStatus(c, c.Params("token"), orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTableOrder{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Get table order successfully",
+			TableOrder: *order,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Get the ordering link of a table
+// @Description  The link to print as the QR code on the table.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        tableId  path  string  true  "Table id"
+// @Router       /tables/{tableId}/ordering-link [get]
+// @Success      200  {object}  response.SuccessWithOrderingLink
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (s *SelfOrderController) GetOrderingLink(c *fiber.Ctx) error {
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	link, err := s.SelfOrderService.GetOrderingLink(c, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithOrderingLink{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get ordering link successfully",
+			OrderingLink: *link,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Replace the ordering link of a table
+// @Description  Signs a new link for the table, the QR codes printed before stop working.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        tableId  path  string  true  "Table id"
+// @Router       /tables/{tableId}/ordering-link/rotate [post]
+// @Success      200  {object}  response.SuccessWithOrderingLink
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (s *SelfOrderController) RotateOrderingLink(c *fiber.Ctx) error {
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	link, err := s.SelfOrderService.RotateOrderingLink(c, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithOrderingLink{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Rotate ordering link successfully",
+			OrderingLink: *link,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Get the orders placed from the tables of an outlet
+// @Description  The oldest first, filter on status pending for the orders waiting for staff.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        page      query  int     false  "Page number"  default(1)
+// @Param        limit     query  int     false  "Maximum number of table orders"  default(10)
+// @Param        status    query  string  false  "pending, confirmed or rejected"
+// @Param        table_id  query  string  false  "Table id"
+// @Router       /outlets/{outletId}/table-orders [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.TableOrder]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *SelfOrderController) GetTableOrders(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryTableOrder{
+		Page:    c.QueryInt("page", 1),
+		Limit:   c.QueryInt("limit", 10),
+		Status:  c.Query("status", ""),
+		TableID: c.Query("table_id", ""),
+	}
+
+	orders, totalResults, err := s.SelfOrderService.GetTableOrders(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.TableOrder]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get table orders successfully",
+			Results:      orders,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Confirm an order placed from a table
+// @Description  Adds its items to the open sale of the table at the current menu price, for the kitchen to
+// @Description  be fired as usual. When the table has no open sale one is opened for outlet_staff_id.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        orderId  path  string                        true   "Table order id"
+// @Param        request  body  validation.ConfirmTableOrder  false  "Request body"
+// @Router       /table-orders/{orderId}/confirm [post]
+// @Success      200  {object}  response.SuccessWithConfirmedTableOrder
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table order not found"
+func (s *SelfOrderController) ConfirmTableOrder(c *fiber.Ctx) error {
+	req := new(validation.ConfirmTableOrder)
+	orderID := c.Params("orderId")
+
+	if _, err := uuid.Parse(orderID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table order ID")
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	order, sale, err := s.SelfOrderService.ConfirmTableOrder(c, orderID, user, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithConfirmedTableOrder{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Confirm table order successfully",
+			TableOrder: *order,
+			Sale:       *sale,
+		})
+}
+
+// @Tags         Self-ordering
+// @Summary      Reject an order placed from a table
+// @Description  The guests see the reason when they follow the order.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        orderId  path  string                       true  "Table order id"
+// @Param        request  body  validation.RejectTableOrder  true  "Request body"
+// @Router       /table-orders/{orderId}/reject [post]
+// @Success      200  {object}  response.SuccessWithTableOrder
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table order not found"
+func (s *SelfOrderController) RejectTableOrder(c *fiber.Ctx) error {
+	req := new(validation.RejectTableOrder)
+	orderID := c.Params("orderId")
+
+	if _, err := uuid.Parse(orderID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table order ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	order, err := s.SelfOrderService.RejectTableOrder(c, orderID, user, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTableOrder{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Reject table order successfully",
+			TableOrder: *order,
+		})
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SettingController struct {
+	SettingService service.SettingService
+}
+
+func NewSettingController(settingService service.SettingService) *SettingController {
+	return &SettingController{
+		SettingService: settingService,
+	}
+}
+
+// @Tags         Settings
+// @Summary      Get outlet settings
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Router       /outlets/{outletId}/settings [get]
+// @Success      200  {object}  response.SuccessWithSettings
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *SettingController) GetSettings(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	settings, err := s.SettingService.GetSettings(c, outletID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSettings{
+			Code:     fiber.StatusOK,
+			Status:   "success",
+			Message:  "Get settings successfully",
+			Settings: settings,
+		})
+}
+
+// @Tags         Settings
+// @Summary      Create or update an outlet setting
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                    true  "Outlet id"
+// @Param        key       path  string                    true  "Setting key"
+// @Param        request   body  validation.UpsertSetting  true  "Request body"
+// @Router       /outlets/{outletId}/settings/{key} [put]
+// @Success      200  {object}  response.SuccessWithSetting
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (s *SettingController) UpsertSetting(c *fiber.Ctx) error {
+	req := new(validation.UpsertSetting)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	setting, err := s.SettingService.UpsertSetting(c, outletID, c.Params("key"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSetting{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Save setting successfully",
+			Setting: *setting,
+		})
+}
+
+// @Tags         Settings
+// @Summary      Delete an outlet setting
+// @Description  The outlet falls back to the default value once its setting is deleted.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Param        key       path  string  true  "Setting key"
+// @Router       /outlets/{outletId}/settings/{key} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Not found"
+func (s *SettingController) DeleteSetting(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := s.SettingService.DeleteSetting(c, outletID, c.Params("key")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete setting successfully",
+		})
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type StaffShiftController struct {
+	StaffShiftService service.StaffShiftService
+}
+
+func NewStaffShiftController(staffShiftService service.StaffShiftService) *StaffShiftController {
+	return &StaffShiftController{
+		StaffShiftService: staffShiftService,
+	}
+}
+
+// @Tags         Staff Shifts
+// @Summary      Get staff shifts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId         path      string  true   "Outlet id"
+// @Param        page             query     int     false  "Page number"  default(1)
+// @Param        limit            query     int     false  "Maximum number of shifts"  default(10)
+// @Param        outlet_staff_id  query     string  false  "Staff id"
+// @Param        date             query     string  false  "Day the shifts started, YYYY-MM-DD"
+// @Router       /outlets/{outletId}/shifts [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.StaffShift]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *StaffShiftController) GetShifts(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryStaffShift{
+		Page:          c.QueryInt("page", 1),
+		Limit:         c.QueryInt("limit", 10),
+		OutletStaffID: c.Query("outlet_staff_id", ""),
+		Date:          c.Query("date", ""),
+	}
+
+	shifts, totalResults, err := s.StaffShiftService.GetShifts(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.StaffShift]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get all shifts successfully",
+			Results:      shifts,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
+
+// @Tags         Staff Shifts
+// @Summary      Clock in a staff member
+// @Description  Starts a shift for a staff member of the outlet. A staff member can only have one open shift.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string              true  "Outlet id"
+// @Param        request   body  validation.ClockIn  true  "Request body"
+// @Router       /outlets/{outletId}/shifts [post]
+// @Success      201  {object}  response.SuccessWithStaffShift
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      409  {object}  response.ErrorDetails  "Staff is already clocked in"
+func (s *StaffShiftController) ClockIn(c *fiber.Ctx) error {
+	req := new(validation.ClockIn)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	shift, err := s.StaffShiftService.ClockIn(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithStaffShift{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Clock in successfully",
+			Shift:   *shift,
+		})
+}
+
+// @Tags         Staff Shifts
+// @Summary      Clock out a staff member
+// @Security     BearerAuth
+// @Produce      json
+// @Param        shiftId  path  string  true  "Shift id"
+// @Router       /shifts/{shiftId}/clock-out [post]
+// @Success      200  {object}  response.SuccessWithStaffShift
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Shift not found"
+func (s *StaffShiftController) ClockOut(c *fiber.Ctx) error {
+	shiftID := c.Params("shiftId")
+
+	if _, err := uuid.Parse(shiftID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid shift ID")
+	}
+
+	shift, err := s.StaffShiftService.ClockOut(c, shiftID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithStaffShift{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Clock out successfully",
+			Shift:   *shift,
+		})
+}
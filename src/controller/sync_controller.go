@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SyncController struct {
+	SyncService service.SyncService
+}
+
+func NewSyncController(syncService service.SyncService) *SyncController {
+	return &SyncController{
+		SyncService: syncService,
+	}
+}
+
+// @Tags         Sync
+// @Summary      Download catalog changes
+// @Description  Products, categories, taxes, staff and payment methods of an outlet that changed after the cursor,
+// @Description  with the ids of deleted rows. Cursor 0 returns a full snapshot. Keep the returned cursor for the
+// @Description  next request.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        cursor    query  int     false  "Cursor returned by the previous sync"  default(0)
+// @Router       /outlets/{outletId}/sync [get]
+// @Success      200  {object}  response.SuccessWithSyncChanges
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (s *SyncController) GetChanges(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QuerySync{
+		Cursor: int64(c.QueryInt("cursor", 0)),
+	}
+
+	changes, err := s.SyncService.GetChanges(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSyncChanges{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get sync changes successfully",
+			Changes: *changes,
+		})
+}
+
+// @Tags         Sync
+// @Summary      Upload sales made offline
+// @Description  Sales keep the id and timestamps generated by the terminal. Every sale gets its own result:
+// @Description  created, duplicate when it was uploaded before, or rejected with the reason. Prices that changed
+// @Description  since and payments that do not match the total are accepted and reported as conflicts.
+// @Description  Discounts above the outlet threshold and prices overridden on the terminal need approval_ids, as
+// @Description  for sales made online, and the approvals must still be valid when the sale is uploaded.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                true  "Outlet id"
+// @Param        request   body  validation.SyncSales  true  "Request body"
+// @Router       /outlets/{outletId}/sync/sales [post]
+// @Success      200  {object}  response.SuccessWithSyncResults
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (s *SyncController) UploadSales(c *fiber.Ctx) error {
+	req := new(validation.SyncSales)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	results, err := s.SyncService.UploadSales(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSyncResults{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Upload sales successfully",
+			Results: results,
+		})
+}
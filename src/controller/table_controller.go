@@ -0,0 +1,604 @@
+package controller
+
+import (
+	"app/src/model"
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TableController struct {
+	TableService service.TableService
+}
+
+func NewTableController(tableService service.TableService) *TableController {
+	return &TableController{
+		TableService: tableService,
+	}
+}
+
+// @Tags         Tables
+// @Summary      Get the floor areas of an outlet
+// @Description  Areas are ordered by sort_order, each with the tables placed on it.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Router       /outlets/{outletId}/floor-areas [get]
+// @Success      200  {object}  response.SuccessWithFloorAreas
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (t *TableController) GetFloorAreas(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	areas, err := t.TableService.GetFloorAreas(c, outletID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithFloorAreas{
+			Code:       fiber.StatusOK,
+			Status:     "success",
+			Message:    "Get floor areas successfully",
+			FloorAreas: areas,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Create a floor area
+// @Description  Width and height are the size of the area's floor plan canvas, 1000 by 1000 when left out.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                      true  "Outlet id"
+// @Param        request   body  validation.CreateFloorArea  true  "Request body"
+// @Router       /outlets/{outletId}/floor-areas [post]
+// @Success      201  {object}  response.SuccessWithFloorArea
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+// @Failure      409  {object}  response.ErrorDetails  "Floor area name is already in use"
+func (t *TableController) CreateFloorArea(c *fiber.Ctx) error {
+	req := new(validation.CreateFloorArea)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	area, err := t.TableService.CreateFloorArea(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithFloorArea{
+			Code:      fiber.StatusCreated,
+			Status:    "success",
+			Message:   "Create floor area successfully",
+			FloorArea: *area,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Update a floor area
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        areaId   path  string                      true  "Floor area id"
+// @Param        request  body  validation.UpdateFloorArea  true  "Request body"
+// @Router       /floor-areas/{areaId} [patch]
+// @Success      200  {object}  response.SuccessWithFloorArea
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Floor area not found"
+// @Failure      409  {object}  response.ErrorDetails  "Floor area name is already in use"
+func (t *TableController) UpdateFloorArea(c *fiber.Ctx) error {
+	req := new(validation.UpdateFloorArea)
+	areaID := c.Params("areaId")
+
+	if _, err := uuid.Parse(areaID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid floor area ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	area, err := t.TableService.UpdateFloorArea(c, areaID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithFloorArea{
+			Code:      fiber.StatusOK,
+			Status:    "success",
+			Message:   "Update floor area successfully",
+			FloorArea: *area,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Delete a floor area
+// @Description  Its tables are kept and taken off the floor plan.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        areaId  path  string  true  "Floor area id"
+// @Router       /floor-areas/{areaId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Floor area not found"
+func (t *TableController) DeleteFloorArea(c *fiber.Ctx) error {
+	areaID := c.Params("areaId")
+
+	if _, err := uuid.Parse(areaID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid floor area ID")
+	}
+
+	if err := t.TableService.DeleteFloorArea(c, areaID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete floor area successfully",
+		})
+}
+
+// @Tags         Tables
+// @Summary      Get the tables of an outlet
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId    path   string  true   "Outlet id"
+// @Param        floor_area  query  string  false  "Floor area id"
+// @Param        status      query  string  false  "available, occupied, needs_cleaning or reserved"
+// @Router       /outlets/{outletId}/tables [get]
+// @Success      200  {object}  response.SuccessWithTables
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (t *TableController) GetTables(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryTable{
+		FloorAreaID: c.Query("floor_area", ""),
+		Status:      c.Query("status", ""),
+	}
+
+	tables, err := t.TableService.GetTables(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTables{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get tables successfully",
+			Tables:  tables,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Get the live table status of an outlet
+// @Description  Every table with its status and the number, total and start of the open sales at it, and
+// @Description  the orders guests placed from it waiting for staff. Meant to be polled by the floor plan of
+// @Description  the front of house.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path  string  true  "Outlet id"
+// @Router       /outlets/{outletId}/table-status [get]
+// @Success      200  {object}  response.SuccessWithTableStatuses
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (t *TableController) GetTableStatuses(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	statuses, err := t.TableService.GetTableStatuses(c, outletID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTableStatuses{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get table status successfully",
+			Tables:  statuses,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Get a table
+// @Security     BearerAuth
+// @Produce      json
+// @Param        tableId  path  string  true  "Table id"
+// @Router       /tables/{tableId} [get]
+// @Success      200  {object}  response.SuccessWithTable
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (t *TableController) GetTableByID(c *fiber.Ctx) error {
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	table, err := t.TableService.GetTableByID(c, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTable{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get table successfully",
+			Table:   *table,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Create a table
+// @Description  New tables are available. Without floor_area_id the table is not on the floor plan.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        outletId  path  string                  true  "Outlet id"
+// @Param        request   body  validation.CreateTable  true  "Request body"
+// @Router       /outlets/{outletId}/tables [post]
+// @Success      201  {object}  response.SuccessWithTable
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Outlet not found"
+func (t *TableController) CreateTable(c *fiber.Ctx) error {
+	req := new(validation.CreateTable)
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	table, err := t.TableService.CreateTable(c, outletID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithTable{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create table successfully",
+			Table:   *table,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Update a table
+// @Description  Moves, resizes or renames a table. An empty floor_area_id takes it off the floor plan.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tableId  path  string                  true  "Table id"
+// @Param        request  body  validation.UpdateTable  true  "Request body"
+// @Router       /tables/{tableId} [patch]
+// @Success      200  {object}  response.SuccessWithTable
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (t *TableController) UpdateTable(c *fiber.Ctx) error {
+	req := new(validation.UpdateTable)
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	table, err := t.TableService.UpdateTable(c, tableID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTable{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update table successfully",
+			Table:   *table,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Set the status of a table
+// @Description  Tables become occupied when a sale is opened at them and need cleaning once it is paid.
+// @Description  Staff mark them available after cleaning, or reserved. Not allowed while sales are open.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tableId  path  string                        true  "Table id"
+// @Param        request  body  validation.UpdateTableStatus  true  "Request body"
+// @Router       /tables/{tableId}/status [patch]
+// @Success      200  {object}  response.SuccessWithTable
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (t *TableController) UpdateTableStatus(c *fiber.Ctx) error {
+	req := new(validation.UpdateTableStatus)
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	table, err := t.TableService.UpdateTableStatus(c, tableID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTable{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update table status successfully",
+			Table:   *table,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Delete a table
+// @Description  Only tables that were never sold at can be deleted, sales keep their table.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        tableId  path  string  true  "Table id"
+// @Router       /tables/{tableId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+// @Failure      409  {object}  response.ErrorDetails  "Table has sales history"
+func (t *TableController) DeleteTable(c *fiber.Ctx) error {
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	if err := t.TableService.DeleteTable(c, tableID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete table successfully",
+		})
+}
+
+// @Tags         Tables
+// @Summary      Move a sale to another table
+// @Description  The sale's kitchen tickets follow it. The table it leaves needs cleaning once no other
+// @Description  sale is open at it. The move is kept in the table moves of the outlet.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        saleId   path  string               true  "Sale id"
+// @Param        request  body  validation.MoveSale  true  "Request body"
+// @Router       /sales/{saleId}/move [post]
+// @Success      200  {object}  response.SuccessWithSale
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Sale not found"
+func (t *TableController) MoveSale(c *fiber.Ctx) error {
+	req := new(validation.MoveSale)
+	saleID := c.Params("saleId")
+
+	if _, err := uuid.Parse(saleID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	sale, err := t.TableService.MoveSale(c, saleID, user, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithSale{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Move sale successfully",
+			Sale:    *sale,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Merge tables
+// @Description  Joins the tables to this table for a group. Their open sales are merged into the oldest
+// @Description  open sale of this table and closed with the status merged. Sales with payments cannot be
+// @Description  merged. Merged tables follow this table's status and take no sales of their own.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tableId  path  string                  true  "Table id"
+// @Param        request  body  validation.MergeTables  true  "Request body"
+// @Router       /tables/{tableId}/merge [post]
+// @Success      200  {object}  response.SuccessWithTableMerge
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (t *TableController) MergeTables(c *fiber.Ctx) error {
+	req := new(validation.MergeTables)
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	tables, sale, err := t.TableService.MergeTables(c, tableID, user, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTableMerge{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Merge tables successfully",
+			Tables:  tables,
+			Sale:    sale,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Unmerge tables
+// @Description  Splits the tables merged into this table apart, the open sale stays at this table. Sales that
+// @Description  were merged in from those tables get their items back and reopen at their table, coupons stay.
+// @Description  The open sale must have no payments then.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        tableId  path  string                    true   "Table id"
+// @Param        request  body  validation.UnmergeTables  false  "Request body"
+// @Router       /tables/{tableId}/unmerge [post]
+// @Success      200  {object}  response.SuccessWithTables
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Table not found"
+func (t *TableController) UnmergeTables(c *fiber.Ctx) error {
+	req := new(validation.UnmergeTables)
+	tableID := c.Params("tableId")
+
+	if _, err := uuid.Parse(tableID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid table ID")
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	user, _ := c.Locals("user").(*model.User)
+
+	tables, err := t.TableService.UnmergeTables(c, tableID, user, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTables{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Unmerge tables successfully",
+			Tables:  tables,
+		})
+}
+
+// @Tags         Tables
+// @Summary      Get the table moves of an outlet
+// @Description  Sales moved between tables and tables merged and unmerged, the latest first.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        outletId  path   string  true   "Outlet id"
+// @Param        page      query  int     false  "Page number"  default(1)
+// @Param        limit     query  int     false  "Maximum number of moves"  default(10)
+// @Param        table_id  query  string  false  "Moves from or to this table"
+// @Param        sale_id   query  string  false  "Moves of this sale"
+// @Router       /outlets/{outletId}/table-moves [get]
+// @Success      200  {object}  response.SuccessWithPaginate[model.TableMove]
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (t *TableController) GetTableMoves(c *fiber.Ctx) error {
+	outletID := c.Params("outletId")
+
+	if _, err := uuid.Parse(outletID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid outlet ID")
+	}
+
+	query := &validation.QueryTableMove{
+		Page:    c.QueryInt("page", 1),
+		Limit:   c.QueryInt("limit", 10),
+		TableID: c.Query("table_id", ""),
+		SaleID:  c.Query("sale_id", ""),
+	}
+
+	moves, totalResults, err := t.TableService.GetTableMoves(c, outletID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithPaginate[model.TableMove]{
+			Code:         fiber.StatusOK,
+			Status:       "success",
+			Message:      "Get table moves successfully",
+			Results:      moves,
+			Page:         query.Page,
+			Limit:        query.Limit,
+			TotalPages:   int64(math.Ceil(float64(totalResults) / float64(query.Limit))),
+			TotalResults: totalResults,
+		})
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"app/src/response"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TaxController struct {
+	TaxService service.TaxService
+}
+
+func NewTaxController(taxService service.TaxService) *TaxController {
+	return &TaxController{
+		TaxService: taxService,
+	}
+}
+
+// @Tags         Taxes
+// @Summary      Get business taxes
+// @Description  List the taxes and service charges of a business, including the outlet specific ones.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        businessId  path  string  true  "Business id"
+// @Router       /businesses/{businessId}/taxes [get]
+// @Success      200  {object}  response.SuccessWithTaxes
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+func (s *TaxController) GetTaxes(c *fiber.Ctx) error {
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	taxes, err := s.TaxService.GetTaxes(c, businessID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTaxes{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get taxes successfully",
+			Taxes:   taxes,
+		})
+}
+
+// @Tags         Taxes
+// @Summary      Get a tax
+// @Security     BearerAuth
+// @Produce      json
+// @Param        taxId  path  string  true  "Tax id"
+// @Router       /taxes/{taxId} [get]
+// @Success      200  {object}  response.SuccessWithTax
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Tax not found"
+func (s *TaxController) GetTaxByID(c *fiber.Ctx) error {
+	taxID := c.Params("taxId")
+
+	if _, err := uuid.Parse(taxID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid tax ID")
+	}
+
+	tax, err := s.TaxService.GetTaxByID(c, taxID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTax{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Get tax successfully",
+			Tax:     *tax,
+		})
+}
+
+// @Tags         Taxes
+// @Summary      Create a tax or service charge
+// @Description  Without outlet_id the rule applies to every outlet of the business. Rules of an outlet replace
+// @Description  the business wide rules of the same type. Only taxes can be inclusive and only service charges
+// @Description  can be taxable.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        businessId  path  string                true  "Business id"
+// @Param        request     body  validation.CreateTax  true  "Request body"
+// @Router       /businesses/{businessId}/taxes [post]
+// @Success      201  {object}  response.SuccessWithTax
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Business not found"
+func (s *TaxController) CreateTax(c *fiber.Ctx) error {
+	req := new(validation.CreateTax)
+	businessID := c.Params("businessId")
+
+	if _, err := uuid.Parse(businessID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tax, err := s.TaxService.CreateTax(c, businessID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).
+		JSON(response.SuccessWithTax{
+			Code:    fiber.StatusCreated,
+			Status:  "success",
+			Message: "Create tax successfully",
+			Tax:     *tax,
+		})
+}
+
+// @Tags         Taxes
+// @Summary      Update a tax or service charge
+// @Description  Open sales pick up the change the next time they are amended.
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        taxId    path  string                true  "Tax id"
+// @Param        request  body  validation.UpdateTax  true  "Request body"
+// @Router       /taxes/{taxId} [patch]
+// @Success      200  {object}  response.SuccessWithTax
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Tax not found"
+func (s *TaxController) UpdateTax(c *fiber.Ctx) error {
+	req := new(validation.UpdateTax)
+	taxID := c.Params("taxId")
+
+	if _, err := uuid.Parse(taxID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid tax ID")
+	}
+
+	if err := c.BodyParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tax, err := s.TaxService.UpdateTax(c, taxID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.SuccessWithTax{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Update tax successfully",
+			Tax:     *tax,
+		})
+}
+
+// @Tags         Taxes
+// @Summary      Delete a tax or service charge
+// @Description  Sales keep the breakdown they were charged with.
+// @Security     BearerAuth
+// @Produce      json
+// @Param        taxId  path  string  true  "Tax id"
+// @Router       /taxes/{taxId} [delete]
+// @Success      200  {object}  response.Common
+// @Failure      400  {object}  response.ErrorDetails  "Bad Request"
+// @Failure      401  {object}  response.ErrorDetails  "Unauthorized"
+// @Failure      403  {object}  response.ErrorDetails  "Forbidden"
+// @Failure      404  {object}  response.ErrorDetails  "Tax not found"
+func (s *TaxController) DeleteTax(c *fiber.Ctx) error {
+	taxID := c.Params("taxId")
+
+	if _, err := uuid.Parse(taxID); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid tax ID")
+	}
+
+	if err := s.TaxService.DeleteTax(c, taxID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).
+		JSON(response.Common{
+			Code:    fiber.StatusOK,
+			Status:  "success",
+			Message: "Delete tax successfully",
+		})
+}
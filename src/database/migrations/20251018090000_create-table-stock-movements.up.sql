@@ -0,0 +1,19 @@
+CREATE TABLE stock_movements (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id       UUID NOT NULL,
+    product_id      UUID NOT NULL,
+    quantity        INT NOT NULL, -- positive for stock in, negative for stock out
+    type            VARCHAR(50) NOT NULL, -- sale, refund, adjustment
+    reference_id    UUID NULL,
+    note            TEXT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product
+        FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_stock_movements_outlet_id ON stock_movements(outlet_id);
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id);
+CREATE INDEX idx_stock_movements_reference_id ON stock_movements(reference_id);
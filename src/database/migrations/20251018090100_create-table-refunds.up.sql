@@ -0,0 +1,26 @@
+CREATE TABLE refunds (
+    id                UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sale_id           UUID NOT NULL,
+    outlet_id         UUID NOT NULL,
+    outlet_staff_id   UUID NULL,
+    payment_method_id UUID NOT NULL,
+    refund_number     VARCHAR(100) NOT NULL UNIQUE,
+    reason_code       VARCHAR(50) NOT NULL, -- damaged, wrong_item, quality_issue, customer_request, other
+    note              TEXT NULL,
+    restock           BOOLEAN NOT NULL DEFAULT FALSE,
+    total             NUMERIC(10, 2) NOT NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_outlet_staff
+        FOREIGN KEY (outlet_staff_id) REFERENCES outlet_staff(id) ON DELETE SET NULL,
+    CONSTRAINT fk_payment_method
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_refunds_sale_id ON refunds(sale_id);
+CREATE INDEX idx_refunds_outlet_id ON refunds(outlet_id);
+CREATE INDEX idx_refunds_created_at ON refunds(created_at);
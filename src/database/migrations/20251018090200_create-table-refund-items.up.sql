@@ -0,0 +1,20 @@
+CREATE TABLE refund_items (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    refund_id       UUID NOT NULL,
+    sale_item_id    UUID NOT NULL,
+    product_id      UUID NOT NULL,
+    quantity        INT NOT NULL,
+    total           NUMERIC(10, 2) NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_refund
+        FOREIGN KEY (refund_id) REFERENCES refunds(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale_item
+        FOREIGN KEY (sale_item_id) REFERENCES sales_items(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product
+        FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_refund_items_quantity CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_refund_items_refund_id ON refund_items(refund_id);
+CREATE INDEX idx_refund_items_sale_item_id ON refund_items(sale_item_id);
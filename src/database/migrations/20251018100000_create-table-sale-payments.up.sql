@@ -0,0 +1,21 @@
+CREATE TABLE sale_payments (
+    id                UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sale_id           UUID NOT NULL,
+    payment_method_id UUID NOT NULL,
+    amount            NUMERIC(10, 2) NOT NULL, -- part of the grand total settled by this payment
+    tendered          NUMERIC(10, 2) NOT NULL, -- amount handed over by the customer
+    change            NUMERIC(10, 2) DEFAULT 0 NOT NULL,
+    status            VARCHAR(50) NOT NULL, -- pending, paid, failed, void
+    reference         VARCHAR(255) NULL, -- card approval code, e-wallet transaction id, etc.
+    paid_at           TIMESTAMP NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    CONSTRAINT fk_payment_method
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_sale_payments_amount CHECK (amount > 0 AND tendered >= amount)
+);
+
+CREATE INDEX idx_sale_payments_sale_id ON sale_payments(sale_id);
+CREATE INDEX idx_sale_payments_payment_method_id ON sale_payments(payment_method_id);
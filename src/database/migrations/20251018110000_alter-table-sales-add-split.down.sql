@@ -0,0 +1,7 @@
+ALTER TABLE sales_items
+    DROP COLUMN IF EXISTS seat_number,
+    DROP COLUMN IF EXISTS portion;
+
+ALTER TABLE sales
+    DROP CONSTRAINT IF EXISTS fk_split_from,
+    DROP COLUMN IF EXISTS split_from_id;
@@ -0,0 +1,10 @@
+ALTER TABLE sales
+    ADD COLUMN split_from_id UUID NULL,
+    ADD CONSTRAINT fk_split_from
+        FOREIGN KEY (split_from_id) REFERENCES sales(id) ON DELETE SET NULL;
+
+ALTER TABLE sales_items
+    ADD COLUMN seat_number INT NULL,
+    ADD COLUMN portion     NUMERIC(7, 4) DEFAULT 1 NOT NULL; -- share of the line kept after a fractional split
+
+CREATE INDEX idx_sales_split_from_id ON sales(split_from_id);
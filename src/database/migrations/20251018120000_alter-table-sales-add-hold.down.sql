@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_settings_outlet_id_key;
+DROP INDEX IF EXISTS idx_sales_status_hold_expires_at;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS held_at,
+    DROP COLUMN IF EXISTS hold_expires_at;
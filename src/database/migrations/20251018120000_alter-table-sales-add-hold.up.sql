@@ -0,0 +1,6 @@
+ALTER TABLE sales
+    ADD COLUMN held_at         TIMESTAMP NULL,
+    ADD COLUMN hold_expires_at TIMESTAMP NULL;
+
+CREATE INDEX idx_sales_status_hold_expires_at ON sales(status, hold_expires_at);
+CREATE UNIQUE INDEX idx_settings_outlet_id_key ON settings(outlet_id, key);
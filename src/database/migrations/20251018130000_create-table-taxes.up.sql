@@ -0,0 +1,22 @@
+CREATE TABLE taxes (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    business_id     UUID NOT NULL,
+    outlet_id       UUID NULL, -- NULL applies to every outlet of the business
+    name            VARCHAR(100) NOT NULL,
+    type            VARCHAR(20) NOT NULL DEFAULT 'tax',
+    rate            NUMERIC(6, 3) NOT NULL, -- percentage, e.g. 11.000
+    inclusive       BOOLEAN NOT NULL DEFAULT FALSE,
+    taxable         BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_business
+        FOREIGN KEY (business_id) REFERENCES business(id) ON DELETE CASCADE,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT chk_taxes_type CHECK (type IN ('tax', 'service_charge')),
+    CONSTRAINT chk_taxes_rate CHECK (rate > 0 AND rate <= 100)
+);
+
+CREATE INDEX idx_taxes_business_id ON taxes(business_id);
+CREATE INDEX idx_taxes_outlet_id ON taxes(outlet_id);
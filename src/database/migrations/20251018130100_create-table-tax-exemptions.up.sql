@@ -0,0 +1,13 @@
+CREATE TABLE tax_exemptions (
+    id                  UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tax_id              UUID NOT NULL,
+    product_category_id UUID NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tax
+        FOREIGN KEY (tax_id) REFERENCES taxes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_category
+        FOREIGN KEY (product_category_id) REFERENCES product_categories(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_tax_exemptions_tax_id_product_category_id ON tax_exemptions(tax_id, product_category_id);
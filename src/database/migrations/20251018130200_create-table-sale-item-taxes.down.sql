@@ -0,0 +1,10 @@
+ALTER TABLE sales_items
+    DROP COLUMN IF EXISTS service_charge,
+    DROP COLUMN IF EXISTS tax,
+    DROP COLUMN IF EXISTS tax_included;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS service_charge,
+    DROP COLUMN IF EXISTS tax_included;
+
+DROP TABLE IF EXISTS sale_item_taxes CASCADE;
@@ -0,0 +1,29 @@
+CREATE TABLE sale_item_taxes (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sale_item_id    UUID NOT NULL,
+    tax_id          UUID NULL,
+    name            VARCHAR(100) NOT NULL, -- name, type and rate are copied so history survives tax changes
+    type            VARCHAR(20) NOT NULL,
+    rate            NUMERIC(6, 3) NOT NULL,
+    inclusive       BOOLEAN NOT NULL DEFAULT FALSE,
+    base            NUMERIC(10, 2) NOT NULL,
+    amount          NUMERIC(10, 2) NOT NULL,
+    included        NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sale_item
+        FOREIGN KEY (sale_item_id) REFERENCES sales_items(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tax
+        FOREIGN KEY (tax_id) REFERENCES taxes(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_sale_item_taxes_sale_item_id ON sale_item_taxes(sale_item_id);
+
+ALTER TABLE sales
+    ADD COLUMN service_charge NUMERIC(10, 2) DEFAULT 0 NOT NULL,
+    ADD COLUMN tax_included   NUMERIC(10, 2) DEFAULT 0 NOT NULL; -- part of tax already in the item prices
+
+ALTER TABLE sales_items
+    ADD COLUMN service_charge NUMERIC(10, 2) DEFAULT 0 NOT NULL,
+    ADD COLUMN tax            NUMERIC(10, 2) DEFAULT 0 NOT NULL,
+    ADD COLUMN tax_included   NUMERIC(10, 2) DEFAULT 0 NOT NULL;
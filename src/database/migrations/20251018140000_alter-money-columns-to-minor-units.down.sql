@@ -0,0 +1,57 @@
+ALTER TABLE sale_item_taxes
+    ALTER COLUMN included DROP DEFAULT,
+    ALTER COLUMN base     TYPE NUMERIC(10, 2) USING base / 100.0,
+    ALTER COLUMN amount   TYPE NUMERIC(10, 2) USING amount / 100.0,
+    ALTER COLUMN included TYPE NUMERIC(10, 2) USING included / 100.0,
+    ALTER COLUMN included SET DEFAULT 0;
+
+ALTER TABLE sale_payments
+    ALTER COLUMN change DROP DEFAULT,
+    ALTER COLUMN amount   TYPE NUMERIC(10, 2) USING amount / 100.0,
+    ALTER COLUMN tendered TYPE NUMERIC(10, 2) USING tendered / 100.0,
+    ALTER COLUMN change   TYPE NUMERIC(10, 2) USING change / 100.0,
+    ALTER COLUMN change SET DEFAULT 0;
+
+ALTER TABLE refund_items
+    ALTER COLUMN total TYPE NUMERIC(10, 2) USING total / 100.0;
+
+ALTER TABLE refunds
+    ALTER COLUMN total TYPE NUMERIC(10, 2) USING total / 100.0;
+
+ALTER TABLE coupons
+    ALTER COLUMN discount_value TYPE NUMERIC(10, 2) USING discount_value / 100.0;
+
+ALTER TABLE sales_items
+    ALTER COLUMN discount DROP DEFAULT,
+    ALTER COLUMN service_charge DROP DEFAULT,
+    ALTER COLUMN tax DROP DEFAULT,
+    ALTER COLUMN tax_included DROP DEFAULT,
+    ALTER COLUMN price          TYPE NUMERIC(10, 2) USING price / 100.0,
+    ALTER COLUMN discount       TYPE NUMERIC(10, 2) USING discount / 100.0,
+    ALTER COLUMN total          TYPE NUMERIC(10, 2) USING total / 100.0,
+    ALTER COLUMN service_charge TYPE NUMERIC(10, 2) USING service_charge / 100.0,
+    ALTER COLUMN tax            TYPE NUMERIC(10, 2) USING tax / 100.0,
+    ALTER COLUMN tax_included   TYPE NUMERIC(10, 2) USING tax_included / 100.0,
+    ALTER COLUMN discount SET DEFAULT 0,
+    ALTER COLUMN service_charge SET DEFAULT 0,
+    ALTER COLUMN tax SET DEFAULT 0,
+    ALTER COLUMN tax_included SET DEFAULT 0;
+
+ALTER TABLE sales
+    ALTER COLUMN discount DROP DEFAULT,
+    ALTER COLUMN service_charge DROP DEFAULT,
+    ALTER COLUMN tax DROP DEFAULT,
+    ALTER COLUMN tax_included DROP DEFAULT,
+    ALTER COLUMN total          TYPE NUMERIC(10, 2) USING total / 100.0,
+    ALTER COLUMN discount       TYPE NUMERIC(10, 2) USING discount / 100.0,
+    ALTER COLUMN service_charge TYPE NUMERIC(10, 2) USING service_charge / 100.0,
+    ALTER COLUMN tax            TYPE NUMERIC(10, 2) USING tax / 100.0,
+    ALTER COLUMN tax_included   TYPE NUMERIC(10, 2) USING tax_included / 100.0,
+    ALTER COLUMN grand_total    TYPE NUMERIC(10, 2) USING grand_total / 100.0,
+    ALTER COLUMN discount SET DEFAULT 0,
+    ALTER COLUMN service_charge SET DEFAULT 0,
+    ALTER COLUMN tax SET DEFAULT 0,
+    ALTER COLUMN tax_included SET DEFAULT 0;
+
+ALTER TABLE products
+    ALTER COLUMN price TYPE NUMERIC(10, 2) USING price / 100.0;
@@ -0,0 +1,58 @@
+-- Money is stored in hundredths of the currency unit, e.g. 12.50 becomes 1250
+ALTER TABLE products
+    ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+
+ALTER TABLE sales
+    ALTER COLUMN discount DROP DEFAULT,
+    ALTER COLUMN service_charge DROP DEFAULT,
+    ALTER COLUMN tax DROP DEFAULT,
+    ALTER COLUMN tax_included DROP DEFAULT,
+    ALTER COLUMN total          TYPE BIGINT USING ROUND(total * 100)::BIGINT,
+    ALTER COLUMN discount       TYPE BIGINT USING ROUND(discount * 100)::BIGINT,
+    ALTER COLUMN service_charge TYPE BIGINT USING ROUND(service_charge * 100)::BIGINT,
+    ALTER COLUMN tax            TYPE BIGINT USING ROUND(tax * 100)::BIGINT,
+    ALTER COLUMN tax_included   TYPE BIGINT USING ROUND(tax_included * 100)::BIGINT,
+    ALTER COLUMN grand_total    TYPE BIGINT USING ROUND(grand_total * 100)::BIGINT,
+    ALTER COLUMN discount SET DEFAULT 0,
+    ALTER COLUMN service_charge SET DEFAULT 0,
+    ALTER COLUMN tax SET DEFAULT 0,
+    ALTER COLUMN tax_included SET DEFAULT 0;
+
+ALTER TABLE sales_items
+    ALTER COLUMN discount DROP DEFAULT,
+    ALTER COLUMN service_charge DROP DEFAULT,
+    ALTER COLUMN tax DROP DEFAULT,
+    ALTER COLUMN tax_included DROP DEFAULT,
+    ALTER COLUMN price          TYPE BIGINT USING ROUND(price * 100)::BIGINT,
+    ALTER COLUMN discount       TYPE BIGINT USING ROUND(discount * 100)::BIGINT,
+    ALTER COLUMN total          TYPE BIGINT USING ROUND(total * 100)::BIGINT,
+    ALTER COLUMN service_charge TYPE BIGINT USING ROUND(service_charge * 100)::BIGINT,
+    ALTER COLUMN tax            TYPE BIGINT USING ROUND(tax * 100)::BIGINT,
+    ALTER COLUMN tax_included   TYPE BIGINT USING ROUND(tax_included * 100)::BIGINT,
+    ALTER COLUMN discount SET DEFAULT 0,
+    ALTER COLUMN service_charge SET DEFAULT 0,
+    ALTER COLUMN tax SET DEFAULT 0,
+    ALTER COLUMN tax_included SET DEFAULT 0;
+
+ALTER TABLE coupons
+    ALTER COLUMN discount_value TYPE BIGINT USING ROUND(discount_value * 100)::BIGINT;
+
+ALTER TABLE refunds
+    ALTER COLUMN total TYPE BIGINT USING ROUND(total * 100)::BIGINT;
+
+ALTER TABLE refund_items
+    ALTER COLUMN total TYPE BIGINT USING ROUND(total * 100)::BIGINT;
+
+ALTER TABLE sale_payments
+    ALTER COLUMN change DROP DEFAULT,
+    ALTER COLUMN amount   TYPE BIGINT USING ROUND(amount * 100)::BIGINT,
+    ALTER COLUMN tendered TYPE BIGINT USING ROUND(tendered * 100)::BIGINT,
+    ALTER COLUMN change   TYPE BIGINT USING ROUND(change * 100)::BIGINT,
+    ALTER COLUMN change SET DEFAULT 0;
+
+ALTER TABLE sale_item_taxes
+    ALTER COLUMN included DROP DEFAULT,
+    ALTER COLUMN base     TYPE BIGINT USING ROUND(base * 100)::BIGINT,
+    ALTER COLUMN amount   TYPE BIGINT USING ROUND(amount * 100)::BIGINT,
+    ALTER COLUMN included TYPE BIGINT USING ROUND(included * 100)::BIGINT,
+    ALTER COLUMN included SET DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS cash_rounding;
@@ -0,0 +1,2 @@
+ALTER TABLE sales
+    ADD COLUMN cash_rounding BIGINT DEFAULT 0 NOT NULL; -- added to the grand total when a cash tender is rounded
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS staff_shifts CASCADE;
@@ -0,0 +1,17 @@
+CREATE TABLE staff_shifts (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id       UUID NOT NULL,
+    outlet_staff_id UUID NOT NULL,
+    clock_in        TIMESTAMP NOT NULL,
+    clock_out       TIMESTAMP NULL, -- empty while the staff member is still working
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_outlet_staff
+        FOREIGN KEY (outlet_staff_id) REFERENCES outlet_staff(id) ON DELETE CASCADE,
+    CONSTRAINT chk_staff_shifts_clock_out CHECK (clock_out IS NULL OR clock_out >= clock_in)
+);
+
+CREATE INDEX idx_staff_shifts_outlet_id_clock_in ON staff_shifts(outlet_id, clock_in);
+CREATE UNIQUE INDEX idx_staff_shifts_open ON staff_shifts(outlet_staff_id) WHERE clock_out IS NULL;
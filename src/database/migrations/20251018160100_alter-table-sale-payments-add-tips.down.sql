@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_sale_payments_outlet_staff_id;
+
+ALTER TABLE sale_payments
+    DROP CONSTRAINT IF EXISTS chk_sale_payments_tip,
+    DROP CONSTRAINT IF EXISTS fk_outlet_staff,
+    DROP COLUMN IF EXISTS outlet_staff_id,
+    DROP COLUMN IF EXISTS tip;
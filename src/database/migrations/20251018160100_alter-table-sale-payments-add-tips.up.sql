@@ -0,0 +1,8 @@
+ALTER TABLE sale_payments
+    ADD COLUMN tip             BIGINT DEFAULT 0 NOT NULL, -- on top of the amount, not part of the grand total
+    ADD COLUMN outlet_staff_id UUID NULL, -- staff member the tip is attributed to
+    ADD CONSTRAINT fk_outlet_staff
+        FOREIGN KEY (outlet_staff_id) REFERENCES outlet_staff(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_sale_payments_tip CHECK (tip >= 0);
+
+CREATE INDEX idx_sale_payments_outlet_staff_id ON sale_payments(outlet_staff_id);
@@ -0,0 +1,15 @@
+CREATE TABLE idempotency_keys (
+    id            UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    scope         VARCHAR(255) NOT NULL, -- user id of the bearer token, empty for anonymous requests
+    key           VARCHAR(255) NOT NULL, -- value of the Idempotency-Key header
+    request_hash  CHAR(64) NOT NULL, -- sha256 of the method, url and body
+    status_code   INT NULL, -- empty while the first request is still running
+    content_type  VARCHAR(255) NULL,
+    response_body BYTEA NULL,
+    expires_at    TIMESTAMP NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_scope_key ON idempotency_keys(scope, key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
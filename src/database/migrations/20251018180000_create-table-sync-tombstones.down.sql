@@ -0,0 +1,27 @@
+DROP TRIGGER IF EXISTS trg_tax_exemptions_sync_version ON tax_exemptions;
+DROP FUNCTION IF EXISTS bump_tax_sync_version();
+
+DROP TRIGGER IF EXISTS trg_products_sync_tombstone ON products;
+DROP TRIGGER IF EXISTS trg_product_categories_sync_tombstone ON product_categories;
+DROP TRIGGER IF EXISTS trg_taxes_sync_tombstone ON taxes;
+DROP TRIGGER IF EXISTS trg_outlet_staff_sync_tombstone ON outlet_staff;
+DROP TRIGGER IF EXISTS trg_payment_methods_sync_tombstone ON payment_methods;
+
+DROP TRIGGER IF EXISTS trg_products_sync_version ON products;
+DROP TRIGGER IF EXISTS trg_product_categories_sync_version ON product_categories;
+DROP TRIGGER IF EXISTS trg_taxes_sync_version ON taxes;
+DROP TRIGGER IF EXISTS trg_outlet_staff_sync_version ON outlet_staff;
+DROP TRIGGER IF EXISTS trg_payment_methods_sync_version ON payment_methods;
+
+DROP FUNCTION IF EXISTS record_sync_tombstone();
+DROP FUNCTION IF EXISTS bump_sync_version();
+
+DROP TABLE IF EXISTS sync_tombstones;
+
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS sync_version;
+ALTER TABLE outlet_staff DROP COLUMN IF EXISTS sync_version;
+ALTER TABLE taxes DROP COLUMN IF EXISTS sync_version;
+ALTER TABLE product_categories DROP COLUMN IF EXISTS sync_version;
+ALTER TABLE products DROP COLUMN IF EXISTS sync_version;
+
+DROP SEQUENCE IF EXISTS sync_version_seq;
@@ -0,0 +1,77 @@
+-- Every change to the data terminals keep offline takes the next version, terminals ask for
+-- the changes after the last version they have seen.
+CREATE SEQUENCE sync_version_seq;
+
+ALTER TABLE products ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq');
+ALTER TABLE product_categories ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq');
+ALTER TABLE taxes ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq');
+ALTER TABLE outlet_staff ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq');
+ALTER TABLE payment_methods ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq');
+
+CREATE INDEX idx_products_sync_version ON products(sync_version);
+CREATE INDEX idx_product_categories_sync_version ON product_categories(sync_version);
+CREATE INDEX idx_taxes_sync_version ON taxes(sync_version);
+CREATE INDEX idx_outlet_staff_sync_version ON outlet_staff(sync_version);
+CREATE INDEX idx_payment_methods_sync_version ON payment_methods(sync_version);
+
+-- Deleted rows are remembered so terminals can drop them too
+CREATE TABLE sync_tombstones (
+    id           UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    table_name   VARCHAR(100) NOT NULL,
+    record_id    UUID NOT NULL,
+    business_id  UUID NULL,
+    outlet_id    UUID NULL,
+    sync_version BIGINT NOT NULL DEFAULT nextval('sync_version_seq'),
+    deleted_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sync_tombstones_sync_version ON sync_tombstones(sync_version);
+
+CREATE FUNCTION bump_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_version := nextval('sync_version_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION record_sync_tombstone() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO sync_tombstones (table_name, record_id, business_id, outlet_id)
+    VALUES (TG_TABLE_NAME, OLD.id, (to_jsonb(OLD) ->> 'business_id')::UUID, (to_jsonb(OLD) ->> 'outlet_id')::UUID);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_sync_version BEFORE UPDATE ON products
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_version();
+CREATE TRIGGER trg_product_categories_sync_version BEFORE UPDATE ON product_categories
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_version();
+CREATE TRIGGER trg_taxes_sync_version BEFORE UPDATE ON taxes
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_version();
+CREATE TRIGGER trg_outlet_staff_sync_version BEFORE UPDATE ON outlet_staff
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_version();
+CREATE TRIGGER trg_payment_methods_sync_version BEFORE UPDATE ON payment_methods
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_version();
+
+CREATE TRIGGER trg_products_sync_tombstone AFTER DELETE ON products
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone();
+CREATE TRIGGER trg_product_categories_sync_tombstone AFTER DELETE ON product_categories
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone();
+CREATE TRIGGER trg_taxes_sync_tombstone AFTER DELETE ON taxes
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone();
+CREATE TRIGGER trg_outlet_staff_sync_tombstone AFTER DELETE ON outlet_staff
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone();
+CREATE TRIGGER trg_payment_methods_sync_tombstone AFTER DELETE ON payment_methods
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone();
+
+-- A tax's exemptions are part of the tax for the terminals
+CREATE FUNCTION bump_tax_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE taxes SET sync_version = nextval('sync_version_seq')
+    WHERE id = COALESCE(NEW.tax_id, OLD.tax_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_tax_exemptions_sync_version AFTER INSERT OR DELETE ON tax_exemptions
+    FOR EACH ROW EXECUTE FUNCTION bump_tax_sync_version();
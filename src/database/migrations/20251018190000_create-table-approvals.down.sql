@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS approvals;
+
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS voided_at,
+    DROP COLUMN IF EXISTS void_reason;
+
+ALTER TABLE outlet_staff
+    DROP COLUMN IF EXISTS pin;
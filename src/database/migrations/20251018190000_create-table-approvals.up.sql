@@ -0,0 +1,38 @@
+ALTER TABLE outlet_staff
+    ADD COLUMN pin VARCHAR(255) NULL; -- bcrypt hash of the approval PIN of managers
+
+ALTER TABLE sales
+    ADD COLUMN void_reason TEXT NULL,
+    ADD COLUMN voided_at   TIMESTAMP NULL;
+
+CREATE TABLE approvals (
+    id                   UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id            UUID NOT NULL,
+    sale_id              UUID NULL, -- set when the approval is used if it was not asked for a sale
+    action               VARCHAR(50) NOT NULL, -- void_sale, discount, price_override
+    status               VARCHAR(50) NOT NULL, -- pending, approved, rejected, used
+    method               VARCHAR(50) NOT NULL, -- pin or push
+    amount               BIGINT DEFAULT 0 NOT NULL, -- most the approval covers
+    reason               TEXT NOT NULL,
+    requested_by_id      UUID NULL,
+    approved_by_staff_id UUID NULL, -- manager who entered their PIN
+    approved_by_user_id  UUID NULL, -- user who answered a push approval
+    decided_at           TIMESTAMP NULL,
+    used_at              TIMESTAMP NULL,
+    expires_at           TIMESTAMP NOT NULL,
+    created_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE SET NULL,
+    CONSTRAINT fk_requested_by
+        FOREIGN KEY (requested_by_id) REFERENCES outlet_staff(id) ON DELETE SET NULL,
+    CONSTRAINT fk_approved_by_staff
+        FOREIGN KEY (approved_by_staff_id) REFERENCES outlet_staff(id) ON DELETE SET NULL,
+    CONSTRAINT fk_approved_by_user
+        FOREIGN KEY (approved_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_approvals_outlet_id_status ON approvals(outlet_id, status);
+CREATE INDEX idx_approvals_sale_id ON approvals(sale_id);
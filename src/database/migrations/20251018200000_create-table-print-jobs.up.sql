@@ -0,0 +1,21 @@
+CREATE TABLE print_jobs (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    printer_id      UUID NOT NULL,
+    sale_id         UUID NULL,
+    kind            VARCHAR(20) NOT NULL,  -- receipt or test
+    data            BYTEA NOT NULL,        -- ESC/POS commands sent as is
+    status          VARCHAR(20) NOT NULL,  -- pending, printing, printed or failed
+    attempts        INTEGER DEFAULT 0 NOT NULL,
+    last_error      TEXT NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    printed_at      TIMESTAMP NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_printer
+        FOREIGN KEY (printer_id) REFERENCES printers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_print_jobs_printer_id_created_at ON print_jobs(printer_id, created_at);
+CREATE INDEX idx_print_jobs_due ON print_jobs(next_attempt_at) WHERE status IN ('pending', 'printing');
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS kitchen_routes CASCADE;
+
+ALTER TABLE sales_items
+    DROP COLUMN IF EXISTS fired_at,
+    DROP COLUMN IF EXISTS modifiers,
+    DROP COLUMN IF EXISTS note;
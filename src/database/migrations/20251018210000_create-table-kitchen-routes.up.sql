@@ -0,0 +1,29 @@
+ALTER TABLE sales_items
+    ADD COLUMN note      TEXT      NULL,
+    ADD COLUMN modifiers JSONB     NULL, -- free text, e.g. ["no ice", "extra shot"]
+    ADD COLUMN fired_at  TIMESTAMP NULL; -- when the item was sent to the kitchen
+
+CREATE TABLE kitchen_routes (
+    id                  UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id           UUID NOT NULL,
+    printer_id          UUID NOT NULL,
+    product_category_id UUID NULL,
+    product_id          UUID NULL, -- a product route wins over the routes of its category
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_printer
+        FOREIGN KEY (printer_id) REFERENCES printers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_category
+        FOREIGN KEY (product_category_id) REFERENCES product_categories(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product
+        FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_kitchen_routes_target CHECK ((product_category_id IS NULL) <> (product_id IS NULL))
+);
+
+CREATE INDEX idx_kitchen_routes_outlet_id ON kitchen_routes(outlet_id);
+CREATE UNIQUE INDEX idx_kitchen_routes_category ON kitchen_routes(printer_id, product_category_id)
+    WHERE product_category_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_kitchen_routes_product ON kitchen_routes(printer_id, product_id)
+    WHERE product_id IS NOT NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE kitchen_items (
+    id             UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id      UUID NOT NULL,
+    sale_id        UUID NOT NULL,
+    sale_item_id   UUID NOT NULL,
+    printer_id     UUID NOT NULL,         -- the display station preparing the item
+    invoice_number VARCHAR(255) NOT NULL, -- copied when the item is fired
+    table_name     VARCHAR(255) NULL,
+    product_name   VARCHAR(255) NOT NULL,
+    quantity       INTEGER NOT NULL,
+    seat_number    INTEGER NULL,
+    note           TEXT NULL,
+    modifiers      JSONB NULL,
+    status         VARCHAR(20) NOT NULL,  -- new, in_progress, ready, served or void
+    fired_at       TIMESTAMP NOT NULL,
+    started_at     TIMESTAMP NULL,
+    ready_at       TIMESTAMP NULL,        -- prep time is ready_at - fired_at
+    served_at      TIMESTAMP NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale_item
+        FOREIGN KEY (sale_item_id) REFERENCES sales_items(id) ON DELETE CASCADE,
+    CONSTRAINT fk_printer
+        FOREIGN KEY (printer_id) REFERENCES printers(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_kitchen_items_outlet_id_status ON kitchen_items(outlet_id, status);
+CREATE INDEX idx_kitchen_items_sale_id ON kitchen_items(sale_id);
+CREATE INDEX idx_kitchen_items_sale_item_id ON kitchen_items(sale_item_id);
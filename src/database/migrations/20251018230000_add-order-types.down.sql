@@ -0,0 +1,18 @@
+ALTER TABLE products DROP COLUMN IF EXISTS order_prices;
+
+ALTER TABLE taxes DROP COLUMN IF EXISTS order_types;
+
+ALTER TABLE kitchen_items DROP COLUMN IF EXISTS order_type;
+
+DROP INDEX IF EXISTS idx_sales_order_type;
+
+-- Fails while takeaway or delivery sales without a table are left
+ALTER TABLE sales
+    DROP CONSTRAINT IF EXISTS chk_sales_dine_in_table,
+    ALTER COLUMN table_id SET NOT NULL,
+    DROP COLUMN IF EXISTS pickup_at,
+    DROP COLUMN IF EXISTS delivery_fee,
+    DROP COLUMN IF EXISTS delivery_phone,
+    DROP COLUMN IF EXISTS delivery_contact,
+    DROP COLUMN IF EXISTS delivery_address,
+    DROP COLUMN IF EXISTS order_type;
@@ -0,0 +1,20 @@
+ALTER TABLE sales
+    ADD COLUMN order_type       VARCHAR(20) NOT NULL DEFAULT 'dine_in', -- dine_in, takeaway or delivery
+    ADD COLUMN delivery_address TEXT NULL,
+    ADD COLUMN delivery_contact VARCHAR(100) NULL,
+    ADD COLUMN delivery_phone   VARCHAR(30) NULL,
+    ADD COLUMN delivery_fee     BIGINT DEFAULT 0 NOT NULL, -- added to the grand total, not taxed
+    ADD COLUMN pickup_at        TIMESTAMP NULL,            -- when a takeaway order is collected
+    ALTER COLUMN table_id DROP NOT NULL,
+    ADD CONSTRAINT chk_sales_dine_in_table CHECK (order_type <> 'dine_in' OR table_id IS NOT NULL);
+
+CREATE INDEX idx_sales_order_type ON sales(order_type);
+
+ALTER TABLE kitchen_items ADD COLUMN order_type VARCHAR(20) NOT NULL DEFAULT 'dine_in';
+
+-- Taxes and service charges listing order types only apply to those, e.g. no service charge
+-- on takeaway. They apply to every order type when empty.
+ALTER TABLE taxes ADD COLUMN order_types JSONB NULL;
+
+-- Price list by order type, e.g. {"delivery": 27000}. Other order types are sold at price.
+ALTER TABLE products ADD COLUMN order_prices JSONB NULL;
@@ -0,0 +1,21 @@
+ALTER TABLE sales
+    DROP CONSTRAINT fk_table,
+    ADD CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_tables_floor_area_id;
+
+ALTER TABLE tables
+    DROP CONSTRAINT IF EXISTS fk_floor_area,
+    ALTER COLUMN status DROP NOT NULL,
+    ALTER COLUMN status DROP DEFAULT,
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS rotation,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS pos_y,
+    DROP COLUMN IF EXISTS pos_x,
+    DROP COLUMN IF EXISTS shape,
+    DROP COLUMN IF EXISTS floor_area_id;
+
+DROP TABLE IF EXISTS floor_areas;
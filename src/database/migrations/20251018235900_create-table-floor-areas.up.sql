@@ -0,0 +1,40 @@
+CREATE TABLE floor_areas (
+    id          UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id   UUID            NOT NULL,
+    name        VARCHAR(100)    NOT NULL, -- e.g. "Indoor", "Terrace"
+    sort_order  INTEGER         NOT NULL DEFAULT 0,
+    width       INTEGER         NOT NULL DEFAULT 1000, -- canvas size of the floor plan
+    height      INTEGER         NOT NULL DEFAULT 1000,
+    created_at  TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT uq_floor_areas_outlet_name UNIQUE (outlet_id, name)
+);
+
+CREATE INDEX idx_floor_areas_outlet_id ON floor_areas(outlet_id);
+
+UPDATE tables SET status = 'available' WHERE status IS NULL;
+
+-- Positions are in floor plan units from the top left corner of the area
+ALTER TABLE tables
+    ADD COLUMN floor_area_id     UUID NULL,
+    ADD COLUMN shape             VARCHAR(20) NOT NULL DEFAULT 'square', -- square, round or rectangle
+    ADD COLUMN pos_x             INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN pos_y             INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN width             INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN height            INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN rotation          INTEGER NOT NULL DEFAULT 0, -- degrees clockwise
+    ADD COLUMN status_changed_at TIMESTAMP NULL,
+    ALTER COLUMN status SET DEFAULT 'available', -- available, occupied, needs_cleaning or reserved
+    ALTER COLUMN status SET NOT NULL,
+    ADD CONSTRAINT fk_floor_area
+        FOREIGN KEY (floor_area_id) REFERENCES floor_areas(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tables_floor_area_id ON tables(floor_area_id);
+
+-- Removing a table must not take its sales history with it
+ALTER TABLE sales
+    DROP CONSTRAINT fk_table,
+    ADD CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS table_moves;
+
+DROP INDEX IF EXISTS idx_tables_merged_into_id;
+
+ALTER TABLE tables
+    DROP CONSTRAINT IF EXISTS fk_merged_into,
+    DROP COLUMN IF EXISTS merged_into_id;
@@ -0,0 +1,38 @@
+-- A table merged into another one follows the status of the table it is merged into, e.g. two tables
+-- pushed together for a group. Sales are opened at the host table only.
+ALTER TABLE tables
+    ADD COLUMN merged_into_id UUID NULL,
+    ADD CONSTRAINT fk_merged_into
+        FOREIGN KEY (merged_into_id) REFERENCES tables(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tables_merged_into_id ON tables(merged_into_id);
+
+-- Sales merged into another sale keep their invoice number with the status "merged", their items
+-- belong to the sale they were merged into.
+CREATE TABLE table_moves (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id       UUID            NOT NULL,
+    action          VARCHAR(20)     NOT NULL, -- move, merge or unmerge
+    sale_id         UUID            NULL,     -- the sale moved, or the sale the others were merged into
+    merged_sale_id  UUID            NULL,     -- the sale that was merged
+    from_table_id   UUID            NULL,
+    to_table_id     UUID            NULL,
+    user_id         UUID            NULL,
+    reason          VARCHAR(255)    NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE SET NULL,
+    CONSTRAINT fk_merged_sale
+        FOREIGN KEY (merged_sale_id) REFERENCES sales(id) ON DELETE SET NULL,
+    CONSTRAINT fk_from_table
+        FOREIGN KEY (from_table_id) REFERENCES tables(id) ON DELETE SET NULL,
+    CONSTRAINT fk_to_table
+        FOREIGN KEY (to_table_id) REFERENCES tables(id) ON DELETE SET NULL,
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_table_moves_outlet_id ON table_moves(outlet_id, created_at);
+CREATE INDEX idx_table_moves_sale_id ON table_moves(sale_id);
@@ -0,0 +1,7 @@
+ALTER TABLE customers
+    DROP COLUMN IF EXISTS last_no_show_at,
+    DROP COLUMN IF EXISTS no_show_count;
+
+DROP TABLE IF EXISTS waitlist_entries;
+
+DROP TABLE IF EXISTS reservations;
@@ -0,0 +1,60 @@
+-- A reservation books one table from reserved_at for duration_minutes
+CREATE TABLE reservations (
+    id                   UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id            UUID            NOT NULL,
+    table_id             UUID            NULL,
+    customer_id          UUID            NULL,
+    name                 VARCHAR(100)    NOT NULL,
+    phone                VARCHAR(30)     NULL,
+    email                VARCHAR(255)    NULL,
+    party_size           INTEGER         NOT NULL,
+    reserved_at          TIMESTAMP       NOT NULL,
+    duration_minutes     INTEGER         NOT NULL,
+    status               VARCHAR(20)     NOT NULL DEFAULT 'booked', -- booked, confirmed, seated, completed, cancelled or no_show
+    note                 TEXT            NULL,
+    confirmation_sent_at TIMESTAMP       NULL,
+    reminder_sent_at     TIMESTAMP       NULL,
+    seated_at            TIMESTAMP       NULL,
+    cancelled_at         TIMESTAMP       NULL,
+    created_at           TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE SET NULL,
+    CONSTRAINT fk_customer
+        FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_reservations_outlet_id ON reservations(outlet_id, reserved_at);
+CREATE INDEX idx_reservations_table_id ON reservations(table_id, reserved_at);
+CREATE INDEX idx_reservations_customer_id ON reservations(customer_id);
+
+CREATE TABLE waitlist_entries (
+    id                  UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id           UUID            NOT NULL,
+    customer_id         UUID            NULL,
+    table_id            UUID            NULL, -- where the party was seated
+    name                VARCHAR(100)    NOT NULL,
+    phone               VARCHAR(30)     NULL,
+    party_size          INTEGER         NOT NULL,
+    status              VARCHAR(20)     NOT NULL DEFAULT 'waiting', -- waiting, seated or left
+    quoted_wait_minutes INTEGER         NOT NULL DEFAULT 0, -- the wait the party was told when it joined
+    note                TEXT            NULL,
+    seated_at           TIMESTAMP       NULL,
+    left_at             TIMESTAMP       NULL,
+    created_at          TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_customer
+        FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE SET NULL,
+    CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_waitlist_entries_outlet_id ON waitlist_entries(outlet_id, status, created_at);
+
+ALTER TABLE customers
+    ADD COLUMN no_show_count   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_no_show_at TIMESTAMP NULL;
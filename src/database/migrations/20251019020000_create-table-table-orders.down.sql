@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS table_order_items;
+
+DROP TABLE IF EXISTS table_orders;
+
+ALTER TABLE tables
+    DROP COLUMN IF EXISTS ordering_version;
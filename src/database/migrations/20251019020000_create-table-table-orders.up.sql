@@ -0,0 +1,49 @@
+-- Ordering links of a table are signed with its ordering_version, bumping it revokes the printed codes
+ALTER TABLE tables
+    ADD COLUMN ordering_version INTEGER NOT NULL DEFAULT 1;
+
+-- An order placed by guests from the QR code on their table. It waits for staff to confirm it onto
+-- the open sale of the table, or to reject it.
+CREATE TABLE table_orders (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    outlet_id       UUID            NOT NULL,
+    table_id        UUID            NOT NULL,
+    sale_id         UUID            NULL,     -- the open sale of the table when ordered, or the sale it was confirmed onto
+    status          VARCHAR(20)     NOT NULL DEFAULT 'pending', -- pending, confirmed or rejected
+    name            VARCHAR(100)    NULL,
+    note            VARCHAR(500)    NULL,
+    reject_reason   VARCHAR(255)    NULL,
+    user_id         UUID            NULL,     -- who confirmed or rejected it
+    confirmed_at    TIMESTAMP       NULL,
+    rejected_at     TIMESTAMP       NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE SET NULL,
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_table_orders_outlet_id ON table_orders(outlet_id, status, created_at);
+CREATE INDEX idx_table_orders_table_id ON table_orders(table_id, status);
+
+CREATE TABLE table_order_items (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    table_order_id  UUID            NOT NULL,
+    product_id      UUID            NOT NULL,
+    quantity        INTEGER         NOT NULL,
+    price           BIGINT          NOT NULL, -- the menu price the guest saw
+    note            VARCHAR(255)    NULL,
+    modifiers       JSONB           NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_table_order
+        FOREIGN KEY (table_order_id) REFERENCES table_orders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product
+        FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_table_order_items_table_order_id ON table_order_items(table_order_id);
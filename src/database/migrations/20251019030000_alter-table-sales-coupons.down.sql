@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_sales_coupons_sale_id_coupon_id;
+
+ALTER TABLE sales_coupons
+    DROP COLUMN IF EXISTS discount;
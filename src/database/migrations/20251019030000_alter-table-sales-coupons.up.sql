@@ -0,0 +1,6 @@
+-- What each coupon took off the sale, recalculated whenever the sale is repriced
+ALTER TABLE sales_coupons
+    ADD COLUMN discount BIGINT NOT NULL DEFAULT 0;
+
+-- A coupon is redeemed at most once per sale
+CREATE UNIQUE INDEX idx_sales_coupons_sale_id_coupon_id ON sales_coupons(sale_id, coupon_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS sales_promotions;
+
+DROP TABLE IF EXISTS promotions;
+
+ALTER TABLE customers
+    DROP COLUMN IF EXISTS tier;
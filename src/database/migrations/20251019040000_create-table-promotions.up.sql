@@ -0,0 +1,55 @@
+-- Customers can be put in a tier such as gold, promotions can be limited to tiers
+ALTER TABLE customers
+    ADD COLUMN tier VARCHAR(50) NULL;
+
+-- Promotions are applied to sales automatically. Conditions that are left empty always match.
+CREATE TABLE promotions (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    business_id     UUID            NOT NULL,
+    outlet_id       UUID            NULL,     -- every outlet of the business when empty
+    name            VARCHAR(100)    NOT NULL,
+    description     VARCHAR(500)    NULL,
+    priority        INTEGER         NOT NULL DEFAULT 0,    -- higher priorities are applied first
+    stackable       BOOLEAN         NOT NULL DEFAULT TRUE, -- a promotion that does not stack is applied on its own
+    is_active       BOOLEAN         NOT NULL DEFAULT TRUE,
+    start_date      TIMESTAMP       NULL,
+    end_date        TIMESTAMP       NULL,
+    days_of_week    JSONB           NULL,     -- 0 is Sunday
+    start_time      VARCHAR(5)      NULL,     -- daily window, e.g. 15:00 to 18:00, may run past midnight
+    end_time        VARCHAR(5)      NULL,
+    min_spend       BIGINT          NOT NULL DEFAULT 0, -- on the products and categories of the promotion
+    product_ids     JSONB           NULL,
+    category_ids    JSONB           NULL,
+    customer_tiers  JSONB           NULL,
+    action          VARCHAR(20)     NOT NULL, -- percent_off, amount_off, buy_x_get_y, cheapest_free or bundle_price
+    value           BIGINT          NOT NULL DEFAULT 0,
+    buy_quantity    INTEGER         NOT NULL DEFAULT 0,
+    get_quantity    INTEGER         NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_business
+        FOREIGN KEY (business_id) REFERENCES business(id) ON DELETE CASCADE,
+    CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_promotions_business_id ON promotions(business_id, is_active);
+CREATE INDEX idx_promotions_outlet_id ON promotions(outlet_id);
+
+-- The promotions a sale got and what each took off, replaced whenever the sale is repriced
+CREATE TABLE sales_promotions (
+    id              UUID            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sale_id         UUID            NOT NULL,
+    promotion_id    UUID            NULL,
+    name            VARCHAR(100)    NOT NULL, -- kept when the promotion is deleted
+    discount        BIGINT          NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sale
+        FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    CONSTRAINT fk_promotion
+        FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_sales_promotions_sale_id ON sales_promotions(sale_id);
+CREATE INDEX idx_sales_promotions_promotion_id ON sales_promotions(promotion_id);
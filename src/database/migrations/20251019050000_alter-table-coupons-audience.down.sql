@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_coupons_campaign;
+
+ALTER TABLE coupons
+    DROP COLUMN IF EXISTS campaign,
+    DROP COLUMN IF EXISTS customer_tiers,
+    DROP COLUMN IF EXISTS customer_ids,
+    DROP COLUMN IF EXISTS first_order_only,
+    DROP COLUMN IF EXISTS max_uses_per_customer;
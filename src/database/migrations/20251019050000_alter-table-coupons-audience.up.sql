@@ -0,0 +1,10 @@
+-- Who a coupon is for. A coupon listing customers or tiers is only for those customers, the
+-- per customer limit and first order coupons need a customer on the sale.
+ALTER TABLE coupons
+    ADD COLUMN max_uses_per_customer INTEGER NOT NULL DEFAULT 0, -- 0 is no limit
+    ADD COLUMN first_order_only      BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN customer_ids          JSONB NULL,
+    ADD COLUMN customer_tiers        JSONB NULL,
+    ADD COLUMN campaign              VARCHAR(100) NULL; -- codes generated together in bulk
+
+CREATE INDEX idx_coupons_campaign ON coupons(outlet_id, campaign);
@@ -0,0 +1,27 @@
+ALTER TABLE coupons
+    ADD COLUMN outlet_id UUID NULL;
+
+-- A coupon goes back to the first outlet it was for, or the first outlet of its business.
+UPDATE coupons
+SET outlet_id = COALESCE(
+    (outlet_ids->>0)::UUID,
+    (SELECT id FROM outlets WHERE outlets.business_id = coupons.business_id ORDER BY created_at LIMIT 1)
+);
+
+DELETE FROM coupons WHERE outlet_id IS NULL;
+
+DROP INDEX IF EXISTS idx_coupons_campaign;
+DROP INDEX IF EXISTS idx_coupons_business_code;
+
+ALTER TABLE coupons
+    DROP CONSTRAINT IF EXISTS fk_business,
+    DROP COLUMN outlet_ids,
+    DROP COLUMN business_id,
+    ALTER COLUMN outlet_id SET NOT NULL,
+    ADD CONSTRAINT coupons_code_key UNIQUE (code),
+    ADD CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_coupons_outlet_id ON coupons(outlet_id);
+CREATE INDEX idx_coupons_code ON coupons(code);
+CREATE INDEX idx_coupons_campaign ON coupons(outlet_id, campaign);
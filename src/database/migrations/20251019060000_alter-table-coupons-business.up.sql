@@ -0,0 +1,26 @@
+-- Coupons belong to a business and can be redeemed at all of its outlets unless outlet_ids lists
+-- the ones they are for. Codes are unique per business and matched case insensitively. Existing
+-- coupons stay limited to the outlet they were created for.
+ALTER TABLE coupons
+    ADD COLUMN business_id UUID  NULL,
+    ADD COLUMN outlet_ids  JSONB NULL; -- every outlet of the business when empty
+
+UPDATE coupons
+SET business_id = outlets.business_id,
+    outlet_ids  = jsonb_build_array(coupons.outlet_id)
+FROM outlets
+WHERE outlets.id = coupons.outlet_id;
+
+DROP INDEX IF EXISTS idx_coupons_campaign;
+DROP INDEX IF EXISTS idx_coupons_code;
+DROP INDEX IF EXISTS idx_coupons_outlet_id;
+
+ALTER TABLE coupons
+    DROP CONSTRAINT IF EXISTS coupons_code_key,
+    DROP COLUMN outlet_id,
+    ALTER COLUMN business_id SET NOT NULL,
+    ADD CONSTRAINT fk_business
+        FOREIGN KEY (business_id) REFERENCES business(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_coupons_business_code ON coupons(business_id, UPPER(code));
+CREATE INDEX idx_coupons_campaign ON coupons(business_id, campaign);
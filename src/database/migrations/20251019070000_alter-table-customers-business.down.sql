@@ -0,0 +1,30 @@
+DROP INDEX IF EXISTS idx_customers_business_phone;
+DROP INDEX IF EXISTS idx_customers_business_email;
+DROP INDEX IF EXISTS idx_customers_business_id;
+
+-- Customers go back to the outlet they were added at, or the first outlet of their business, and
+-- customers without an email get a placeholder one.
+UPDATE customers
+SET outlet_id = (SELECT id FROM outlets WHERE outlets.business_id = customers.business_id ORDER BY created_at LIMIT 1)
+WHERE outlet_id IS NULL;
+
+DELETE FROM customers WHERE outlet_id IS NULL;
+
+UPDATE customers
+SET email = id || '@customer.invalid'
+WHERE email IS NULL;
+
+ALTER TABLE customers
+    DROP CONSTRAINT IF EXISTS fk_outlet,
+    DROP CONSTRAINT IF EXISTS fk_business;
+
+ALTER TABLE customers
+    DROP COLUMN business_id,
+    ALTER COLUMN outlet_id SET NOT NULL,
+    ALTER COLUMN email SET NOT NULL,
+    ADD CONSTRAINT customers_email_key UNIQUE (email),
+    ADD CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_customers_email ON customers(email);
+CREATE INDEX idx_customers_phone ON customers(phone);
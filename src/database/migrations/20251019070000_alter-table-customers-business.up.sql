@@ -0,0 +1,30 @@
+-- Customers belong to a business and are shared by all of its outlets, outlet_id is kept as the
+-- outlet they were added at. Emails are optional and unique per business, phones are compared on
+-- their digits to find duplicates.
+ALTER TABLE customers
+    ADD COLUMN business_id UUID NULL;
+
+UPDATE customers
+SET business_id = outlets.business_id
+FROM outlets
+WHERE outlets.id = customers.outlet_id;
+
+DROP INDEX IF EXISTS idx_customers_email;
+DROP INDEX IF EXISTS idx_customers_phone;
+
+ALTER TABLE customers
+    DROP CONSTRAINT IF EXISTS customers_email_key,
+    DROP CONSTRAINT IF EXISTS fk_outlet;
+
+ALTER TABLE customers
+    ALTER COLUMN business_id SET NOT NULL,
+    ALTER COLUMN outlet_id DROP NOT NULL,
+    ALTER COLUMN email DROP NOT NULL,
+    ADD CONSTRAINT fk_business
+        FOREIGN KEY (business_id) REFERENCES business(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_outlet
+        FOREIGN KEY (outlet_id) REFERENCES outlets(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_customers_business_id ON customers(business_id);
+CREATE UNIQUE INDEX idx_customers_business_email ON customers(business_id, LOWER(email)) WHERE email IS NOT NULL;
+CREATE INDEX idx_customers_business_phone ON customers(business_id, (regexp_replace(phone, '[^0-9]', '', 'g')));
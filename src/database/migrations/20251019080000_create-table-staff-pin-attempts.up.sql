@@ -0,0 +1,10 @@
+-- Wrong PINs entered for a staff member in a row, they are locked out for a while when there are
+-- too many. Kept apart from outlet_staff so failed attempts don't bump its sync_version.
+CREATE TABLE staff_pin_attempts (
+    outlet_staff_id UUID PRIMARY KEY,
+    failed_count    INT NOT NULL DEFAULT 0,
+    locked_until    TIMESTAMP NULL,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_outlet_staff
+        FOREIGN KEY (outlet_staff_id) REFERENCES outlet_staff(id) ON DELETE CASCADE
+);
@@ -0,0 +1,30 @@
+-- The sequence continues past every version handed out by next_sync_version()
+SELECT setval('sync_version_seq', GREATEST(
+    (SELECT last_value FROM sync_version_seq),
+    (SELECT next_sync_version())
+));
+
+CREATE OR REPLACE FUNCTION bump_tax_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE taxes SET sync_version = nextval('sync_version_seq')
+    WHERE id = COALESCE(NEW.tax_id, OLD.tax_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION bump_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_version := nextval('sync_version_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE sync_tombstones ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+ALTER TABLE payment_methods ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+ALTER TABLE outlet_staff ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+ALTER TABLE taxes ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+ALTER TABLE product_categories ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+ALTER TABLE products ALTER COLUMN sync_version SET DEFAULT nextval('sync_version_seq');
+
+DROP FUNCTION IF EXISTS sync_version_horizon();
+DROP FUNCTION IF EXISTS next_sync_version();
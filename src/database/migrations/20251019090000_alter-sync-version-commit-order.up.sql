@@ -0,0 +1,36 @@
+-- Versions taken from sync_version_seq follow the order transactions write, not the order they
+-- commit, so a cursor could move past a change that was committed afterwards. A version is now
+-- the id of the transaction that wrote the row, offset past the versions handed out so far, and
+-- sync_version_horizon() tells below which version every transaction has finished.
+DO $$
+DECLARE
+    version_offset BIGINT := GREATEST(nextval('sync_version_seq') - txid_current(), 0);
+BEGIN
+    EXECUTE format('CREATE FUNCTION next_sync_version() RETURNS BIGINT AS %L LANGUAGE SQL STABLE',
+        'SELECT txid_current() + ' || version_offset);
+    EXECUTE format('CREATE FUNCTION sync_version_horizon() RETURNS BIGINT AS %L LANGUAGE SQL STABLE',
+        'SELECT txid_snapshot_xmin(txid_current_snapshot()) + ' || version_offset);
+END;
+$$;
+
+ALTER TABLE products ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+ALTER TABLE product_categories ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+ALTER TABLE taxes ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+ALTER TABLE outlet_staff ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+ALTER TABLE payment_methods ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+ALTER TABLE sync_tombstones ALTER COLUMN sync_version SET DEFAULT next_sync_version();
+
+CREATE OR REPLACE FUNCTION bump_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_version := next_sync_version();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION bump_tax_sync_version() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE taxes SET sync_version = next_sync_version()
+    WHERE id = COALESCE(NEW.tax_id, OLD.tax_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
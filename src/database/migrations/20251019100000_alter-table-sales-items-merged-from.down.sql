@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sales_items_merged_from_sale_id;
+
+ALTER TABLE sales_items
+    DROP CONSTRAINT IF EXISTS fk_merged_from_sale,
+    DROP COLUMN IF EXISTS merged_from_sale_id;
@@ -0,0 +1,8 @@
+-- The sale an item came from when its sale was merged into another, so unmerging the tables can
+-- give the items back.
+ALTER TABLE sales_items
+    ADD COLUMN merged_from_sale_id UUID NULL,
+    ADD CONSTRAINT fk_merged_from_sale
+        FOREIGN KEY (merged_from_sale_id) REFERENCES sales(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_sales_items_merged_from_sale_id ON sales_items(merged_from_sale_id);
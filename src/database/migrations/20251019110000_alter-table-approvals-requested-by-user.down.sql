@@ -0,0 +1,3 @@
+ALTER TABLE approvals
+    DROP CONSTRAINT IF EXISTS fk_requested_by_user,
+    DROP COLUMN IF EXISTS requested_by_user_id;
@@ -0,0 +1,5 @@
+-- The user signed in on the terminal that asked for an approval, they cannot answer it themselves.
+ALTER TABLE approvals
+    ADD COLUMN requested_by_user_id UUID NULL,
+    ADD CONSTRAINT fk_requested_by_user
+        FOREIGN KEY (requested_by_user_id) REFERENCES users(id) ON DELETE SET NULL;
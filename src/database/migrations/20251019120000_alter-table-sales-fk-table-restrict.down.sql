@@ -0,0 +1,4 @@
+ALTER TABLE sales
+    DROP CONSTRAINT fk_table,
+    ADD CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE SET NULL;
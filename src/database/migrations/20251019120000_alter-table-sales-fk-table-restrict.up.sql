@@ -0,0 +1,6 @@
+-- Dine-in sales must keep their table (chk_sales_dine_in_table), so tables with sales history
+-- can't be deleted
+ALTER TABLE sales
+    DROP CONSTRAINT fk_table,
+    ADD CONSTRAINT fk_table
+        FOREIGN KEY (table_id) REFERENCES tables(id) ON DELETE RESTRICT;
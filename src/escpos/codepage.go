@@ -0,0 +1,50 @@
+package escpos
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// CodePage is a character table of the printer, selected with ESC t. Text is encoded to it
+// before printing and characters it does not have are printed as "?".
+type CodePage struct {
+	Name    string
+	number  byte
+	charmap *charmap.Charmap
+}
+
+var codePages = map[string]CodePage{
+	"cp437":   {Name: "cp437", number: 0, charmap: charmap.CodePage437},
+	"cp850":   {Name: "cp850", number: 2, charmap: charmap.CodePage850},
+	"cp858":   {Name: "cp858", number: 19, charmap: charmap.CodePage858},
+	"cp866":   {Name: "cp866", number: 17, charmap: charmap.CodePage866},
+	"wpc1252": {Name: "wpc1252", number: 16, charmap: charmap.Windows1252},
+}
+
+// DefaultCodePage is understood by every ESC/POS printer.
+var DefaultCodePage = codePages["cp437"]
+
+// LookupCodePage finds a supported code page by name, e.g. "cp858" or "wpc1252".
+func LookupCodePage(name string) (CodePage, bool) {
+	codePage, ok := codePages[strings.ToLower(name)]
+	return codePage, ok
+}
+
+func (c CodePage) encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		if r < 0x80 {
+			encoded = append(encoded, byte(r))
+			continue
+		}
+
+		b, ok := c.charmap.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+
+	return encoded
+}
@@ -0,0 +1,78 @@
+package escpos
+
+import (
+	"image"
+)
+
+const (
+	esc byte = 0x1b
+	gs  byte = 0x1d
+)
+
+// QR code module size in dots, 6 keeps a URL readable on both paper widths
+const qrModuleSize = 6
+
+func flag(on bool) byte {
+	if on {
+		return 1
+	}
+	return 0
+}
+
+// size is the GS ! character size, double width and height or normal.
+func size(double bool) byte {
+	if double {
+		return 0x11
+	}
+	return 0
+}
+
+// raster converts an image to a GS v 0 bitmap, scaled down to maxDots wide with nearest
+// neighbour sampling.
+func raster(img image.Image, maxDots int) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	if width > maxDots {
+		height = height * maxDots / width
+		width = maxDots
+	}
+
+	rowBytes := (width + 7) / 8
+	data := make([]byte, 0, 8+rowBytes*height)
+	data = append(data, gs, 'v', '0', 0,
+		byte(rowBytes), byte(rowBytes>>8), byte(height), byte(height>>8))
+
+	for y := range height {
+		row := make([]byte, rowBytes)
+		sourceY := bounds.Min.Y + y*bounds.Dy()/height
+		for x := range width {
+			sourceX := bounds.Min.X + x*bounds.Dx()/width
+			if isBlack(img.At(sourceX, sourceY)) {
+				row[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		data = append(data, row...)
+	}
+
+	return append(data, '\n')
+}
+
+// qrCode stores the data in the printer's QR symbol memory with GS ( k and prints it.
+func qrCode(data string) []byte {
+	length := len(data) + 3
+
+	commands := []byte{
+		gs, '(', 'k', 4, 0, '1', 'A', '2', 0, // model 2
+		gs, '(', 'k', 3, 0, '1', 'C', qrModuleSize,
+		gs, '(', 'k', 3, 0, '1', 'E', '1', // error correction level M
+		gs, '(', 'k', byte(length), byte(length >> 8), '1', 'P', '0',
+	}
+	commands = append(commands, data...)
+	commands = append(commands, gs, '(', 'k', 3, 0, '1', 'Q', '0', '\n')
+
+	return commands
+}
@@ -0,0 +1,239 @@
+package escpos
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"unicode/utf8"
+)
+
+type Align byte
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Style of a line. Double text is twice as wide and high, so half as many characters fit.
+type Style struct {
+	Align  Align
+	Bold   bool
+	Double bool
+}
+
+// Paper layouts of the usual thermal printers, at 203 dpi with font A
+var (
+	Paper58 = Paper{Width: 58, Columns: 32, Dots: 384}
+	Paper80 = Paper{Width: 80, Columns: 48, Dots: 576}
+)
+
+type Paper struct {
+	Width   int // in millimetres
+	Columns int // characters per line
+	Dots    int // printable dots per line
+}
+
+// PaperFor returns the layout of a paper width in millimetres, 80mm when it is unknown.
+func PaperFor(width int) Paper {
+	if width > 0 && width <= Paper58.Width {
+		return Paper58
+	}
+	return Paper80
+}
+
+type blockKind int
+
+const (
+	blockText blockKind = iota
+	blockImage
+	blockQRCode
+	blockFeed
+)
+
+type block struct {
+	kind  blockKind
+	text  string
+	style Style
+	image image.Image
+	lines int
+}
+
+// Document is a layout for a paper width. It renders to ESC/POS commands for the printer or to
+// plain text with the same line breaks for previews.
+type Document struct {
+	Paper    Paper
+	CodePage CodePage
+	blocks   []block
+}
+
+func NewDocument(paper Paper) *Document {
+	return &Document{Paper: paper, CodePage: DefaultCodePage}
+}
+
+// Line adds text, wrapped at word boundaries to the width of the paper.
+func (d *Document) Line(text string, style Style) {
+	for _, line := range wrap(text, d.columns(style)) {
+		d.blocks = append(d.blocks, block{kind: blockText, text: line, style: style})
+	}
+}
+
+// Row adds a line with left aligned and right aligned text, e.g. a label and an amount. When both
+// do not fit, the left text gets its own lines.
+func (d *Document) Row(left, right string, style Style) {
+	columns := d.columns(style)
+	style.Align = AlignLeft
+
+	lines := wrap(left, columns)
+	last := lines[len(lines)-1]
+	gap := columns - utf8.RuneCountInString(last) - utf8.RuneCountInString(right)
+	if gap < 1 {
+		lines = append(lines, "")
+		gap = columns - utf8.RuneCountInString(right)
+	}
+	lines[len(lines)-1] += strings.Repeat(" ", max(gap, 1)) + right
+
+	for _, line := range lines {
+		d.blocks = append(d.blocks, block{kind: blockText, text: line, style: style})
+	}
+}
+
+// Separator adds a dashed line across the paper.
+func (d *Document) Separator() {
+	d.blocks = append(d.blocks, block{kind: blockText, text: strings.Repeat("-", d.Paper.Columns)})
+}
+
+// Image adds a centered black and white bitmap, scaled down to the paper when it is wider.
+func (d *Document) Image(img image.Image) {
+	d.blocks = append(d.blocks, block{kind: blockImage, image: img})
+}
+
+// QRCode adds a centered QR code that the printer generates from the data.
+func (d *Document) QRCode(data string) {
+	d.blocks = append(d.blocks, block{kind: blockQRCode, text: data})
+}
+
+func (d *Document) Feed(lines int) {
+	d.blocks = append(d.blocks, block{kind: blockFeed, lines: lines})
+}
+
+// Bytes renders the document to ESC/POS commands, ending with a paper cut.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+
+	buf.Write([]byte{esc, '@', esc, 't', d.CodePage.number})
+
+	for _, b := range d.blocks {
+		switch b.kind {
+		case blockText:
+			buf.Write([]byte{esc, 'a', byte(b.style.Align), esc, 'E', flag(b.style.Bold), gs, '!', size(b.style.Double)})
+			buf.Write(d.CodePage.encode(b.text))
+			buf.WriteByte('\n')
+		case blockImage:
+			buf.Write([]byte{esc, 'a', byte(AlignCenter)})
+			buf.Write(raster(b.image, d.Paper.Dots))
+		case blockQRCode:
+			buf.Write([]byte{esc, 'a', byte(AlignCenter)})
+			buf.Write(qrCode(b.text))
+		case blockFeed:
+			buf.Write([]byte{esc, 'd', byte(b.lines)})
+		}
+	}
+
+	buf.Write([]byte{esc, 'a', byte(AlignLeft), esc, 'E', 0, gs, '!', 0})
+	buf.Write([]byte{gs, 'V', 66, 3})
+
+	return buf.Bytes()
+}
+
+// String renders the document to plain text. Images and QR codes are shown as placeholders.
+func (d *Document) String() string {
+	var sb strings.Builder
+
+	for _, b := range d.blocks {
+		switch b.kind {
+		case blockText:
+			sb.WriteString(d.align(b.text, b.style))
+		case blockImage:
+			sb.WriteString(d.align("[logo]", Style{Align: AlignCenter}))
+		case blockQRCode:
+			sb.WriteString(d.align("[QR: "+b.text+"]", Style{Align: AlignCenter}))
+		case blockFeed:
+			sb.WriteString(strings.Repeat("\n", b.lines))
+			continue
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+func (d *Document) columns(style Style) int {
+	if style.Double {
+		return d.Paper.Columns / 2
+	}
+	return d.Paper.Columns
+}
+
+// align pads text for the preview. Double text is centered on the whole paper since the preview
+// prints it at normal size.
+func (d *Document) align(text string, style Style) string {
+	gap := d.Paper.Columns - utf8.RuneCountInString(text)
+	if gap <= 0 || style.Align == AlignLeft {
+		return text
+	}
+	if style.Align == AlignCenter {
+		gap /= 2
+	}
+	return strings.Repeat(" ", gap) + text
+}
+
+// wrap breaks text into lines of at most width characters, splitting words only when they are
+// longer than a line. Leading spaces indent every line.
+func wrap(text string, width int) []string {
+	indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	if len(indent) >= width {
+		indent = ""
+	}
+	width -= len(indent)
+
+	var lines []string
+	line := ""
+
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+
+	lines = append(lines, line)
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+
+	return lines
+}
+
+// isBlack decides whether a pixel is printed, transparent pixels are left blank.
+func isBlack(c color.Color) bool {
+	gray, _ := color.Gray16Model.Convert(c).(color.Gray16)
+	_, _, _, alpha := c.RGBA()
+	return alpha > 0x7fff && gray.Y < 0x7fff
+}
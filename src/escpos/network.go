@@ -0,0 +1,33 @@
+package escpos
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Send writes print data to a network printer over raw TCP, the printer prints as it reads.
+// The timeout covers connecting and writing.
+func Send(ctx context.Context, address string, data []byte, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	if _, err = conn.Write(data); err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
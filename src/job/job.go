@@ -0,0 +1,64 @@
+package job
+
+import (
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Start registers the background jobs, they stop when ctx is cancelled.
+func Start(ctx context.Context, db *gorm.DB) {
+	validate := validation.Validator()
+
+	saleService := service.NewSaleService(db, validate)
+	idempotencyService := service.NewIdempotencyService(db, validate)
+	printJobService := service.NewPrintJobService(db, validate)
+	reservationService := service.NewReservationService(db, validate, service.NewEmailService())
+
+	go Every(ctx, "expire held sales", time.Minute, func(ctx context.Context) error {
+		expired, err := saleService.ExpireHeldSales(ctx)
+		if err == nil && expired > 0 {
+			utils.Log.Infof("Voided %d expired held sales", expired)
+		}
+		return err
+	})
+
+	go Every(ctx, "delete expired idempotency keys", time.Hour, func(ctx context.Context) error {
+		_, err := idempotencyService.DeleteExpired(ctx)
+		return err
+	})
+
+	go Every(ctx, "deliver print jobs", 2*time.Second, func(ctx context.Context) error {
+		_, err := printJobService.DeliverPrintJobs(ctx)
+		return err
+	})
+
+	go Every(ctx, "send reservation reminders", time.Minute, func(ctx context.Context) error {
+		sent, err := reservationService.SendReservationReminders(ctx)
+		if sent > 0 {
+			utils.Log.Infof("Sent %d reservation reminders", sent)
+		}
+		return err
+	})
+}
+
+// Every runs task on a fixed interval until ctx is cancelled.
+func Every(ctx context.Context, name string, interval time.Duration, task func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := task(ctx); err != nil {
+				utils.Log.Errorf("Job %s failed: %+v", name, err)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"app/src/config"
 	"app/src/database"
+	"app/src/job"
 	"app/src/middleware"
 	"app/src/router"
 	"app/src/utils"
@@ -37,6 +38,7 @@ func main() {
 	db := setupDatabase()
 	defer closeDatabase(db)
 	setupRoutes(app, db)
+	job.Start(ctx, db)
 
 	address := fmt.Sprintf("%s:%d", config.AppHost, config.AppPort)
 
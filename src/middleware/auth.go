@@ -6,6 +6,7 @@ import (
 	"app/src/utils"
 	"strings"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -41,6 +42,18 @@ func Auth(userService service.UserService, requiredRights ...string) fiber.Handl
 	}
 }
 
+// WebSocketToken lets websocket clients send their access token as the access_token query
+// parameter, browsers can't set the Authorization header when they open a websocket. It goes
+// before Auth, other requests still need the header.
+func WebSocketToken(c *fiber.Ctx) error {
+	token := c.Query("access_token")
+	if token != "" && c.Get("Authorization") == "" && websocket.IsWebSocketUpgrade(c) {
+		c.Request().Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.Next()
+}
+
 func hasAllRights(userRights, requiredRights []string) bool {
 	rightSet := make(map[string]struct{}, len(userRights))
 	for _, right := range userRights {
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/service"
+	"app/src/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to retry. The first
+// response is stored and replayed to retries of the same request, reusing the key for another
+// request is a conflict. Responses to server errors are not kept so the request can be retried.
+func Idempotency(idempotencyService service.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+		if c.Method() != fiber.MethodPost || key == "" {
+			return c.Next()
+		}
+
+		record, reserved, err := idempotencyService.Reserve(c, idempotencyScope(c), key, requestHash(c))
+		if err != nil {
+			return err
+		}
+
+		if !reserved {
+			return replay(c, record)
+		}
+
+		return runIdempotent(c, idempotencyService, record)
+	}
+}
+
+func runIdempotent(c *fiber.Ctx, idempotencyService service.IdempotencyService, record *model.IdempotencyKey) error {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = idempotencyService.Release(c, record)
+			panic(r)
+		}
+	}()
+
+	// Render errors here so the stored response is the one the client receives
+	if err := c.Next(); err != nil {
+		if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+			_ = idempotencyService.Release(c, record)
+			return handlerErr
+		}
+	}
+
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		return idempotencyService.Release(c, record)
+	}
+
+	contentType := string(c.Response().Header.ContentType())
+	record.StatusCode = &status
+	record.ContentType = &contentType
+	record.ResponseBody = append([]byte(nil), c.Response().Body()...)
+
+	// The response already went out fine, a key that cannot be completed is only freed for retries
+	if err := idempotencyService.Complete(c, record); err != nil {
+		_ = idempotencyService.Release(c, record)
+	}
+
+	return nil
+}
+
+func replay(c *fiber.Ctx, record *model.IdempotencyKey) error {
+	if record.RequestHash != requestHash(c) {
+		return fiber.NewError(fiber.StatusConflict, "Idempotency key was already used for a different request")
+	}
+
+	if record.StatusCode == nil {
+		return fiber.NewError(fiber.StatusConflict, "A request with this idempotency key is still being processed")
+	}
+
+	if record.ContentType != nil {
+		c.Set(fiber.HeaderContentType, *record.ContentType)
+	}
+	c.Set("Idempotent-Replayed", "true")
+
+	return c.Status(*record.StatusCode).Send(record.ResponseBody)
+}
+
+// idempotencyScope keeps the keys of every user apart, and those of anonymous requests by the
+// table of a self-order link or else by address. The tokens are only read here, Auth and the
+// self-order service still check the request.
+func idempotencyScope(c *fiber.Ctx) string {
+	token := strings.TrimSpace(strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "))
+	if token != "" {
+		if userID, err := utils.VerifyToken(token, config.JWTSecret, config.TokenTypeAccess); err == nil {
+			return userID
+		}
+	}
+
+	// Runs before routing, so the ordering token is read from the path
+	if path, ok := strings.CutPrefix(c.Path(), "/v1/self-order/"); ok {
+		tableToken, _, _ := strings.Cut(path, "/")
+		if tableID := utils.TableTokenKey(config.SelfOrderSecret, tableToken); tableID != "" {
+			return "table:" + tableID
+		}
+	}
+
+	return "ip:" + c.IP()
+}
+
+func requestHash(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	hash.Write(c.Body())
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"app/src/config"
 	"app/src/response"
+	"app/src/utils"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -23,3 +25,26 @@ func LimiterConfig() fiber.Handler {
 		SkipSuccessfulRequests: true,
 	})
 }
+
+// SelfOrderLimiter limits the guests of a table, who share the table of the ordering token in the path.
+// Tokens that aren't signed by us are limited by address, so made up tokens can't use up a table's limit.
+func SelfOrderLimiter(limit int, expiration time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        limit,
+		Expiration: expiration,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if tableID := utils.TableTokenKey(config.SelfOrderSecret, c.Params("token")); tableID != "" {
+				return "table:" + tableID
+			}
+			return "ip:" + c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).
+				JSON(response.Common{
+					Code:    fiber.StatusTooManyRequests,
+					Status:  "error",
+					Message: "Too many requests from this table, please ask the staff for help",
+				})
+		},
+	})
+}
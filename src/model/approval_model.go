@@ -0,0 +1,44 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Approval is a manager's authorization for something a cashier may not do alone. It is given
+// with the manager's PIN at the terminal or pushed from the manager's device, and used once.
+type Approval struct {
+	ID                uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	OutletID          uuid.UUID    `gorm:"not null" json:"outlet_id"`
+	SaleID            *uuid.UUID   `json:"sale_id"`
+	Action            string       `gorm:"not null" json:"action"`
+	Status            string       `gorm:"not null" json:"status"`
+	Method            string       `gorm:"not null" json:"method"`
+	Amount            money.Amount `gorm:"type:bigint;default:0;not null" json:"amount" swaggertype:"number"`
+	Reason            string       `gorm:"type:text;not null" json:"reason"`
+	RequestedByID     *uuid.UUID   `json:"requested_by_id"`
+	RequestedByUserID *uuid.UUID   `json:"requested_by_user_id"`
+	ApprovedByStaffID *uuid.UUID   `json:"approved_by_staff_id"`
+	ApprovedByUserID  *uuid.UUID   `json:"approved_by_user_id"`
+	DecidedAt         *time.Time   `json:"decided_at"`
+	UsedAt            *time.Time   `json:"used_at"`
+	ExpiresAt         time.Time    `gorm:"not null" json:"expires_at"`
+	CreatedAt         time.Time    `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt         time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet          *Outlet      `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Sale            *Sale        `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	RequestedBy     *OutletStaff `gorm:"foreignKey:requested_by_id;references:id" json:"-"`
+	RequestedByUser *User        `gorm:"foreignKey:requested_by_user_id;references:id" json:"-"`
+	ApprovedByStaff *OutletStaff `gorm:"foreignKey:approved_by_staff_id;references:id" json:"-"`
+	ApprovedByUser  *User        `gorm:"foreignKey:approved_by_user_id;references:id" json:"-"`
+}
+
+func (approval *Approval) BeforeCreate(_ *gorm.DB) error {
+	approval.ID = uuid.New()
+	return nil
+}
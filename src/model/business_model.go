@@ -24,6 +24,14 @@ type Business struct {
 	Outlets           []Outlet          `gorm:"foreignKey:business_id;references:id" json:"-"`
 	ProductCategories []ProductCategory `gorm:"foreignKey:business_id;references:id" json:"-"`
 	Products          []Product         `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Taxes             []Tax             `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Promotions        []Promotion       `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Coupons           []Coupon          `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Customers         []Customer        `gorm:"foreignKey:business_id;references:id" json:"-"`
+}
+
+func (Business) TableName() string {
+	return "business"
 }
 
 func (business *Business) BeforeCreate(_ *gorm.DB) error {
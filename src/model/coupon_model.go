@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,22 +9,28 @@ import (
 )
 
 type Coupon struct {
-	ID            uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	OutletID      uuid.UUID `gorm:"not null" json:"outlet_id"`
-	Code          string    `gorm:"uniqueIndex;not null" json:"code"`
-	Description   *string   `gorm:"type:text" json:"description"`
-	DiscountType  string    `gorm:"not null" json:"discount_type"`
-	DiscountValue float64   `gorm:"type:numeric(10,2);not null" json:"discount_value"`
-	MaxUses       int       `gorm:"default:1;not null" json:"max_uses"`
-	UsedCount     int       `gorm:"default:0;not null" json:"used_count"`
-	StartDate     time.Time `gorm:"not null" json:"start_date"`
-	EndDate       time.Time `gorm:"not null" json:"end_date"`
-	IsActive      bool      `gorm:"default:true;not null" json:"is_active"`
-	CreatedAt     time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt     time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID                 uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	BusinessID         uuid.UUID    `gorm:"not null" json:"business_id"`
+	OutletIDs          []uuid.UUID  `gorm:"type:jsonb;serializer:json" json:"outlet_ids"` // every outlet when empty
+	Code               string       `gorm:"not null" json:"code"`                         // unique per business, case insensitive
+	Description        *string      `gorm:"type:text" json:"description"`
+	DiscountType       string       `gorm:"not null" json:"discount_type"`
+	DiscountValue      money.Amount `gorm:"type:bigint;not null" json:"discount_value" swaggertype:"number"`
+	MaxUses            int          `gorm:"not null" json:"max_uses"` // 0 is unlimited
+	UsedCount          int          `gorm:"default:0;not null" json:"used_count"`
+	MaxUsesPerCustomer int          `gorm:"default:0;not null" json:"max_uses_per_customer"`
+	FirstOrderOnly     bool         `gorm:"default:false;not null" json:"first_order_only"`
+	CustomerIDs        []uuid.UUID  `gorm:"type:jsonb;serializer:json" json:"customer_ids"`
+	CustomerTiers      []string     `gorm:"type:jsonb;serializer:json" json:"customer_tiers"`
+	Campaign           *string      `json:"campaign"`
+	StartDate          time.Time    `gorm:"not null" json:"start_date"`
+	EndDate            time.Time    `gorm:"not null" json:"end_date"`
+	IsActive           bool         `gorm:"default:true;not null" json:"is_active"`
+	CreatedAt          time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt          time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet      *Outlet      `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Business    *Business    `gorm:"foreignKey:business_id;references:id" json:"-"`
 	SaleCoupons []SaleCoupon `gorm:"foreignKey:coupon_id;references:id" json:"-"`
 }
 
@@ -9,20 +9,26 @@ import (
 
 type Customer struct {
 	ID            uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	BusinessID    uuid.UUID  `gorm:"not null" json:"business_id"`
+	OutletID      *uuid.UUID `json:"outlet_id"` // the outlet the customer was added at
 	UserID        *uuid.UUID `json:"user_id"`
 	Name          string     `gorm:"not null" json:"name"`
-	Email         string     `gorm:"uniqueIndex;not null" json:"email"`
+	Email         *string    `json:"email"` // unique per business, case insensitive
 	Phone         *string    `json:"phone"`
 	Address       *string    `gorm:"type:text" json:"address"`
 	LoyaltyPoints int        `gorm:"default:0;not null" json:"loyalty_points"`
-	OutletID      uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	Tier          *string    `json:"tier"`
+	NoShowCount   int        `gorm:"default:0;not null" json:"no_show_count"`
+	LastNoShowAt  *time.Time `json:"last_no_show_at"`
 	CreatedAt     time.Time  `gorm:"autoCreateTime:milli" json:"-"`
 	UpdatedAt     time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	User   *User   `gorm:"foreignKey:user_id;references:id" json:"-"`
-	Sales  []Sale  `gorm:"foreignKey:customer_id;references:id" json:"-"`
+	Business     *Business     `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Outlet       *Outlet       `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	User         *User         `gorm:"foreignKey:user_id;references:id" json:"-"`
+	Sales        []Sale        `gorm:"foreignKey:customer_id;references:id" json:"-"`
+	Reservations []Reservation `gorm:"foreignKey:customer_id;references:id" json:"-"`
 }
 
 func (customer *Customer) BeforeCreate(_ *gorm.DB) error {
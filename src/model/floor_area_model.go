@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FloorArea is a room or zone of an outlet, its tables are laid out on a canvas of width by height.
+type FloorArea struct {
+	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
+	OutletID  uuid.UUID `gorm:"not null" json:"outlet_id"`
+	Name      string    `gorm:"not null" json:"name"`
+	SortOrder int       `gorm:"not null" json:"sort_order"`
+	Width     int       `gorm:"not null" json:"width"`
+	Height    int       `gorm:"not null" json:"height"`
+	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Tables []Table `gorm:"foreignKey:floor_area_id;references:id" json:"tables,omitempty"`
+}
+
+func (floorArea *FloorArea) BeforeCreate(_ *gorm.DB) error {
+	floorArea.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey remembers the response to a POST request so a retry with the same
+// Idempotency-Key header gets it back instead of running the request again.
+type IdempotencyKey struct {
+	ID           uuid.UUID `gorm:"primaryKey;not null"`
+	Scope        string    `gorm:"not null"` // user id, or the table or address of anonymous requests
+	Key          string    `gorm:"not null"`
+	RequestHash  string    `gorm:"not null"`
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	ExpiresAt    time.Time `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime:milli"`
+	UpdatedAt    time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (idempotencyKey *IdempotencyKey) BeforeCreate(_ *gorm.DB) error {
+	idempotencyKey.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KitchenItem is a sale item shown on a kitchen display. What the cook sees is copied when the
+// item is fired, an item routed to two displays has one row on each.
+type KitchenItem struct {
+	ID            uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID      uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	SaleID        uuid.UUID  `gorm:"not null" json:"sale_id"`
+	SaleItemID    uuid.UUID  `gorm:"not null" json:"sale_item_id"`
+	PrinterID     uuid.UUID  `gorm:"not null" json:"station_id"`
+	InvoiceNumber string     `gorm:"not null" json:"invoice_number"`
+	OrderType     string     `gorm:"default:dine_in;not null" json:"order_type"`
+	Table         *string    `gorm:"column:table_name" json:"table_name"`
+	ProductName   string     `gorm:"not null" json:"product_name"`
+	Quantity      int        `gorm:"not null" json:"quantity"`
+	SeatNumber    *int       `json:"seat_number"`
+	Note          *string    `gorm:"type:text" json:"note"`
+	Modifiers     []string   `gorm:"type:jsonb;serializer:json" json:"modifiers"`
+	Status        string     `gorm:"not null" json:"status"`
+	FiredAt       time.Time  `gorm:"not null" json:"fired_at"`
+	StartedAt     *time.Time `json:"started_at"`
+	ReadyAt       *time.Time `json:"ready_at"`
+	ServedAt      *time.Time `json:"served_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt     time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet   *Outlet   `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Sale     *Sale     `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	SaleItem *SaleItem `gorm:"foreignKey:sale_item_id;references:id" json:"-"`
+	Printer  *Printer  `gorm:"foreignKey:printer_id;references:id" json:"-"`
+}
+
+func (kitchenItem *KitchenItem) BeforeCreate(_ *gorm.DB) error {
+	kitchenItem.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KitchenRoute sends the items of a product category, or of a single product, to a printer.
+type KitchenRoute struct {
+	ID                uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID          uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	PrinterID         uuid.UUID  `gorm:"not null" json:"printer_id"`
+	ProductCategoryID *uuid.UUID `json:"product_category_id"`
+	ProductID         *uuid.UUID `json:"product_id"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt         time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet          *Outlet          `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Printer         *Printer         `gorm:"foreignKey:printer_id;references:id" json:"-"`
+	ProductCategory *ProductCategory `gorm:"foreignKey:product_category_id;references:id" json:"-"`
+	Product         *Product         `gorm:"foreignKey:product_id;references:id" json:"-"`
+}
+
+func (kitchenRoute *KitchenRoute) BeforeCreate(_ *gorm.DB) error {
+	kitchenRoute.ID = uuid.New()
+	return nil
+}
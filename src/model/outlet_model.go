@@ -25,8 +25,12 @@ type Outlet struct {
 	Tables         []Table         `gorm:"foreignKey:outlet_id;references:id" json:"-"`
 	Sales          []Sale          `gorm:"foreignKey:outlet_id;references:id" json:"-"`
 	Settings       []Setting       `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	Coupons        []Coupon        `gorm:"foreignKey:outlet_id;references:id" json:"-"`
 	Printers       []Printer       `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Taxes          []Tax           `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	StaffShifts    []StaffShift    `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Approvals      []Approval      `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	KitchenRoutes  []KitchenRoute  `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	FloorAreas     []FloorArea     `gorm:"foreignKey:outlet_id;references:id" json:"-"`
 }
 
 func (outlet *Outlet) BeforeCreate(_ *gorm.DB) error {
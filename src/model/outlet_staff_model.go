@@ -8,18 +8,26 @@ import (
 )
 
 type OutletStaff struct {
-	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	OutletID  uuid.UUID `gorm:"not null" json:"outlet_id"`
-	Name      string    `gorm:"not null" json:"name"`
-	Username  *string   `gorm:"uniqueIndex" json:"username"`
-	Password  string    `gorm:"not null" json:"-"`
-	Role      string    `gorm:"not null" json:"role"`
-	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID          uuid.UUID `gorm:"primaryKey;not null" json:"id"`
+	OutletID    uuid.UUID `gorm:"not null" json:"outlet_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Username    *string   `gorm:"uniqueIndex" json:"username"`
+	Password    string    `gorm:"not null" json:"-"`
+	Role        string    `gorm:"not null" json:"role"`
+	Pin         *string   `json:"-"`
+	SyncVersion int64     `gorm:"<-:false" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt   time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	Sales  []Sale  `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+	Outlet       *Outlet       `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Sales        []Sale        `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+	SalePayments []SalePayment `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+	StaffShifts  []StaffShift  `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+}
+
+func (OutletStaff) TableName() string {
+	return "outlet_staff"
 }
 
 func (outletStaff *OutletStaff) BeforeCreate(_ *gorm.DB) error {
@@ -8,16 +8,18 @@ import (
 )
 
 type PaymentMethod struct {
-	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	OutletID  uuid.UUID `gorm:"not null" json:"outlet_id"`
-	Name      string    `gorm:"not null" json:"name"`
-	Type      string    `gorm:"not null" json:"type"`
-	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID          uuid.UUID `gorm:"primaryKey;not null" json:"id"`
+	OutletID    uuid.UUID `gorm:"not null" json:"outlet_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Type        string    `gorm:"not null" json:"type"`
+	SyncVersion int64     `gorm:"<-:false" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt   time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	Sales  []Sale  `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
+	Outlet       *Outlet       `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Sales        []Sale        `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
+	SalePayments []SalePayment `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
 }
 
 func (paymentMethod *PaymentMethod) BeforeCreate(_ *gorm.DB) error {
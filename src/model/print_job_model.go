@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PrintJob struct {
+	ID            uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	PrinterID     uuid.UUID  `gorm:"not null" json:"printer_id"`
+	SaleID        *uuid.UUID `json:"sale_id"`
+	Kind          string     `gorm:"not null" json:"kind"`
+	Data          []byte     `gorm:"not null" json:"-"`
+	Status        string     `gorm:"not null" json:"status"`
+	Attempts      int        `gorm:"default:0;not null" json:"attempts"`
+	LastError     *string    `gorm:"type:text" json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"not null" json:"next_attempt_at"`
+	PrintedAt     *time.Time `json:"printed_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Printer *Printer `gorm:"foreignKey:printer_id;references:id" json:"-"`
+	Sale    *Sale    `gorm:"foreignKey:sale_id;references:id" json:"-"`
+}
+
+func (printJob *PrintJob) BeforeCreate(_ *gorm.DB) error {
+	printJob.ID = uuid.New()
+	return nil
+}
@@ -20,7 +20,10 @@ type Printer struct {
 	UpdatedAt      time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Outlet        *Outlet        `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	PrintJobs     []PrintJob     `gorm:"foreignKey:printer_id;references:id" json:"-"`
+	KitchenRoutes []KitchenRoute `gorm:"foreignKey:printer_id;references:id" json:"-"`
+	KitchenItems  []KitchenItem  `gorm:"foreignKey:printer_id;references:id" json:"-"`
 }
 
 func (printer *Printer) BeforeCreate(_ *gorm.DB) error {
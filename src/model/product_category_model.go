@@ -12,12 +12,15 @@ type ProductCategory struct {
 	Name        string    `gorm:"not null" json:"name"`
 	Description *string   `json:"description"`
 	BusinessID  uuid.UUID `gorm:"not null" json:"business_id"`
+	SyncVersion int64     `gorm:"<-:false" json:"-"`
 	CreatedAt   time.Time `gorm:"autoCreateTime:milli" json:"-"`
 	UpdatedAt   time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Business *Business `gorm:"foreignKey:business_id;references:id" json:"-"`
-	Products []Product `gorm:"foreignKey:category_id;references:id" json:"-"`
+	Business      *Business      `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Products      []Product      `gorm:"foreignKey:category_id;references:id" json:"-"`
+	TaxExemptions []TaxExemption `gorm:"foreignKey:product_category_id;references:id" json:"-"`
+	KitchenRoutes []KitchenRoute `gorm:"foreignKey:product_category_id;references:id" json:"-"`
 }
 
 func (productCategory *ProductCategory) BeforeCreate(_ *gorm.DB) error {
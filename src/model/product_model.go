@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,20 +9,24 @@ import (
 )
 
 type Product struct {
-	ID          uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	Image       *string   `json:"image"`
-	Name        string    `gorm:"not null" json:"name"`
-	Description *string   `json:"description"`
-	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"`
-	CategoryID  uuid.UUID `gorm:"not null" json:"category_id"`
-	BusinessID  uuid.UUID `gorm:"not null" json:"business_id"`
-	CreatedAt   time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt   time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID          uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	Image       *string      `json:"image"`
+	Name        string       `gorm:"not null" json:"name"`
+	Description *string      `json:"description"`
+	Price       money.Amount `gorm:"type:bigint;not null" json:"price" swaggertype:"number"`
+	// Prices of the order types not sold at Price, e.g. {"delivery": 27000}
+	OrderPrices map[string]money.Amount `gorm:"type:jsonb;serializer:json" json:"order_prices"`
+	CategoryID  uuid.UUID               `gorm:"not null" json:"category_id"`
+	BusinessID  uuid.UUID               `gorm:"not null" json:"business_id"`
+	SyncVersion int64                   `gorm:"<-:false" json:"-"`
+	CreatedAt   time.Time               `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt   time.Time               `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Business  *Business        `gorm:"foreignKey:business_id;references:id" json:"-"`
-	Category  *ProductCategory `gorm:"foreignKey:category_id;references:id" json:"-"`
-	SaleItems []SaleItem       `gorm:"foreignKey:product_id;references:id" json:"-"`
+	Business      *Business        `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Category      *ProductCategory `gorm:"foreignKey:category_id;references:id" json:"-"`
+	SaleItems     []SaleItem       `gorm:"foreignKey:product_id;references:id" json:"-"`
+	KitchenRoutes []KitchenRoute   `gorm:"foreignKey:product_id;references:id" json:"-"`
 }
 
 func (product *Product) BeforeCreate(_ *gorm.DB) error {
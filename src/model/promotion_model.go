@@ -0,0 +1,45 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Promotion struct {
+	ID            uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	BusinessID    uuid.UUID    `gorm:"not null" json:"business_id"`
+	OutletID      *uuid.UUID   `json:"outlet_id"` // every outlet of the business when empty
+	Name          string       `gorm:"not null" json:"name"`
+	Description   *string      `json:"description"`
+	Priority      int          `gorm:"default:0;not null" json:"priority"`
+	Stackable     bool         `gorm:"default:true;not null" json:"stackable"`
+	IsActive      bool         `gorm:"default:true;not null" json:"is_active"`
+	StartDate     *time.Time   `json:"start_date"`
+	EndDate       *time.Time   `json:"end_date"`
+	DaysOfWeek    []int        `gorm:"type:jsonb;serializer:json" json:"days_of_week"`
+	StartTime     *string      `json:"start_time"`
+	EndTime       *string      `json:"end_time"`
+	MinSpend      money.Amount `gorm:"type:bigint;default:0;not null" json:"min_spend" swaggertype:"number"`
+	ProductIDs    []uuid.UUID  `gorm:"type:jsonb;serializer:json" json:"product_ids"`
+	CategoryIDs   []uuid.UUID  `gorm:"type:jsonb;serializer:json" json:"category_ids"`
+	CustomerTiers []string     `gorm:"type:jsonb;serializer:json" json:"customer_tiers"`
+	Action        string       `gorm:"not null" json:"action"`
+	Value         money.Amount `gorm:"type:bigint;default:0;not null" json:"value" swaggertype:"number"`
+	BuyQuantity   int          `gorm:"default:0;not null" json:"buy_quantity"`
+	GetQuantity   int          `gorm:"default:0;not null" json:"get_quantity"`
+	CreatedAt     time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt     time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Business       *Business       `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Outlet         *Outlet         `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	SalePromotions []SalePromotion `gorm:"foreignKey:promotion_id;references:id" json:"-"`
+}
+
+func (promotion *Promotion) BeforeCreate(_ *gorm.DB) error {
+	promotion.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundItem struct {
+	ID         uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	RefundID   uuid.UUID    `gorm:"not null" json:"refund_id"`
+	SaleItemID uuid.UUID    `gorm:"not null" json:"sale_item_id"`
+	ProductID  uuid.UUID    `gorm:"not null" json:"product_id"`
+	Quantity   int          `gorm:"not null" json:"quantity"`
+	Total      money.Amount `gorm:"type:bigint;not null" json:"total" swaggertype:"number"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt  time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Refund   *Refund   `gorm:"foreignKey:refund_id;references:id" json:"-"`
+	SaleItem *SaleItem `gorm:"foreignKey:sale_item_id;references:id" json:"-"`
+	Product  *Product  `gorm:"foreignKey:product_id;references:id" json:"-"`
+}
+
+func (refundItem *RefundItem) BeforeCreate(_ *gorm.DB) error {
+	refundItem.ID = uuid.New()
+	return nil
+}
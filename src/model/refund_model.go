@@ -0,0 +1,36 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Refund struct {
+	ID              uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleID          uuid.UUID    `gorm:"not null" json:"sale_id"`
+	OutletID        uuid.UUID    `gorm:"not null" json:"outlet_id"`
+	OutletStaffID   *uuid.UUID   `json:"outlet_staff_id"`
+	PaymentMethodID uuid.UUID    `gorm:"not null" json:"payment_method_id"`
+	RefundNumber    string       `gorm:"uniqueIndex;not null" json:"refund_number"`
+	ReasonCode      string       `gorm:"not null" json:"reason_code"`
+	Note            *string      `gorm:"type:text" json:"note"`
+	Restock         bool         `gorm:"default:false;not null" json:"restock"`
+	Total           money.Amount `gorm:"type:bigint;not null" json:"total" swaggertype:"number"`
+	CreatedAt       time.Time    `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt       time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Sale          *Sale          `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	Outlet        *Outlet        `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	OutletStaff   *OutletStaff   `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+	PaymentMethod *PaymentMethod `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
+	RefundItems   []RefundItem   `gorm:"foreignKey:refund_id;references:id" json:"items"`
+}
+
+func (refund *Refund) BeforeCreate(_ *gorm.DB) error {
+	refund.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reservation books a table of an outlet for a party from ReservedAt for DurationMinutes.
+type Reservation struct {
+	ID                 uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID           uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	TableID            *uuid.UUID `json:"table_id"`
+	CustomerID         *uuid.UUID `json:"customer_id"`
+	Name               string     `gorm:"not null" json:"name"`
+	Phone              *string    `json:"phone"`
+	Email              *string    `json:"email"`
+	PartySize          int        `gorm:"not null" json:"party_size"`
+	ReservedAt         time.Time  `gorm:"not null" json:"reserved_at"`
+	DurationMinutes    int        `gorm:"not null" json:"duration_minutes"`
+	Status             string     `gorm:"not null;default:booked" json:"status"`
+	Note               *string    `gorm:"type:text" json:"note"`
+	ConfirmationSentAt *time.Time `json:"confirmation_sent_at"`
+	ReminderSentAt     *time.Time `json:"reminder_sent_at"`
+	SeatedAt           *time.Time `json:"seated_at"`
+	CancelledAt        *time.Time `json:"cancelled_at"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt          time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet   *Outlet   `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Table    *Table    `gorm:"foreignKey:table_id;references:id" json:"-"`
+	Customer *Customer `gorm:"foreignKey:customer_id;references:id" json:"-"`
+}
+
+func (reservation *Reservation) BeforeCreate(_ *gorm.DB) error {
+	reservation.ID = uuid.New()
+	return nil
+}
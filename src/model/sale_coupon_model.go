@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,15 +9,20 @@ import (
 )
 
 type SaleCoupon struct {
-	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	SaleID    uuid.UUID `gorm:"not null" json:"sale_id"`
-	CouponID  uuid.UUID `gorm:"not null" json:"coupon_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID        uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleID    uuid.UUID    `gorm:"not null" json:"sale_id"`
+	CouponID  uuid.UUID    `gorm:"not null" json:"coupon_id"`
+	Discount  money.Amount `gorm:"type:bigint;default:0;not null" json:"discount" swaggertype:"number"`
+	CreatedAt time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
 	Sale   *Sale   `gorm:"foreignKey:sale_id;references:id" json:"-"`
-	Coupon *Coupon `gorm:"foreignKey:coupon_id;references:id" json:"-"`
+	Coupon *Coupon `gorm:"foreignKey:coupon_id;references:id" json:"coupon,omitempty"`
+}
+
+func (SaleCoupon) TableName() string {
+	return "sales_coupons"
 }
 
 func (saleCoupon *SaleCoupon) BeforeCreate(_ *gorm.DB) error {
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,19 +9,35 @@ import (
 )
 
 type SaleItem struct {
-	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	SaleID    uuid.UUID `gorm:"not null" json:"sale_id"`
-	ProductID uuid.UUID `gorm:"not null" json:"product_id"`
-	Quantity  int       `gorm:"not null" json:"quantity"`
-	Price     float64   `gorm:"type:numeric(10,2);not null" json:"price"`
-	Discount  float64   `gorm:"type:numeric(10,2);default:0;not null" json:"discount"`
-	Total     float64   `gorm:"type:numeric(10,2);not null" json:"total"`
-	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID            uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleID        uuid.UUID    `gorm:"not null" json:"sale_id"`
+	ProductID     uuid.UUID    `gorm:"not null" json:"product_id"`
+	Quantity      int          `gorm:"not null" json:"quantity"`
+	Price         money.Amount `gorm:"type:bigint;not null" json:"price" swaggertype:"number"`
+	Discount      money.Amount `gorm:"type:bigint;default:0;not null" json:"discount" swaggertype:"number"`
+	Total         money.Amount `gorm:"type:bigint;not null" json:"total" swaggertype:"number"`
+	ServiceCharge money.Amount `gorm:"type:bigint;default:0;not null" json:"service_charge" swaggertype:"number"`
+	Tax           money.Amount `gorm:"type:bigint;default:0;not null" json:"tax" swaggertype:"number"`
+	TaxIncluded   money.Amount `gorm:"type:bigint;default:0;not null" json:"tax_included" swaggertype:"number"`
+	SeatNumber    *int         `json:"seat_number"`
+	Portion       float64      `gorm:"type:numeric(7,4);default:1;not null" json:"portion"`
+	Note          *string      `gorm:"type:text" json:"note"`
+	Modifiers     []string     `gorm:"type:jsonb;serializer:json" json:"modifiers"`
+	FiredAt       *time.Time   `json:"fired_at"`
+	MergedFromID  *uuid.UUID   `gorm:"column:merged_from_sale_id" json:"merged_from_sale_id"`
+	CreatedAt     time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt     time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Sale    *Sale    `gorm:"foreignKey:sale_id;references:id" json:"-"`
-	Product *Product `gorm:"foreignKey:product_id;references:id" json:"-"`
+	Sale         *Sale         `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	Product      *Product      `gorm:"foreignKey:product_id;references:id" json:"-"`
+	RefundItems  []RefundItem  `gorm:"foreignKey:sale_item_id;references:id" json:"-"`
+	Taxes        []SaleItemTax `gorm:"foreignKey:sale_item_id;references:id" json:"taxes,omitempty"`
+	KitchenItems []KitchenItem `gorm:"foreignKey:sale_item_id;references:id" json:"-"`
+}
+
+func (SaleItem) TableName() string {
+	return "sales_items"
 }
 
 func (saleItem *SaleItem) BeforeCreate(_ *gorm.DB) error {
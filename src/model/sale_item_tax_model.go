@@ -0,0 +1,33 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SaleItemTax struct {
+	ID         uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleItemID uuid.UUID    `gorm:"not null" json:"sale_item_id"`
+	TaxID      *uuid.UUID   `json:"tax_id"`
+	Name       string       `gorm:"not null" json:"name"`
+	Type       string       `gorm:"not null" json:"type"`
+	Rate       float64      `gorm:"type:numeric(6,3);not null" json:"rate"`
+	Inclusive  bool         `gorm:"default:false;not null" json:"inclusive"`
+	Base       money.Amount `gorm:"type:bigint;not null" json:"base" swaggertype:"number"`
+	Amount     money.Amount `gorm:"type:bigint;not null" json:"amount" swaggertype:"number"`
+	Included   money.Amount `gorm:"type:bigint;default:0;not null" json:"included" swaggertype:"number"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt  time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	SaleItem *SaleItem `gorm:"foreignKey:sale_item_id;references:id" json:"-"`
+	Tax      *Tax      `gorm:"foreignKey:tax_id;references:id" json:"-"`
+}
+
+func (saleItemTax *SaleItemTax) BeforeCreate(_ *gorm.DB) error {
+	saleItemTax.ID = uuid.New()
+	return nil
+}
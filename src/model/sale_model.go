@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,34 +9,55 @@ import (
 )
 
 type Sale struct {
-	ID              uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
-	OutletID        uuid.UUID  `gorm:"not null" json:"outlet_id"`
-	OutletStaffID   uuid.UUID  `gorm:"not null" json:"outlet_staff_id"`
-	CustomerID      *uuid.UUID `json:"customer_id"`
-	PaymentMethodID *uuid.UUID `json:"payment_method_id"`
-	TableID         uuid.UUID  `gorm:"not null" json:"table_id"`
-	InvoiceNumber   string     `gorm:"uniqueIndex;not null" json:"invoice_number"`
-	Total           float64    `gorm:"type:numeric(10,2);not null" json:"total"`
-	Discount        float64    `gorm:"type:numeric(10,2);default:0;not null" json:"discount"`
-	Tax             float64    `gorm:"type:numeric(10,2);default:0;not null" json:"tax"`
-	GrandTotal      float64    `gorm:"type:numeric(10,2);not null" json:"grand_total"`
-	Status          string     `gorm:"not null" json:"status"`
-	SaleDate        time.Time  `gorm:"default:CURRENT_TIMESTAMP;not null" json:"sale_date"`
-	Note            *string    `gorm:"type:text" json:"note"`
-	CreatedAt       time.Time  `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt       time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID              uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	OutletID        uuid.UUID    `gorm:"not null" json:"outlet_id"`
+	OutletStaffID   uuid.UUID    `gorm:"not null" json:"outlet_staff_id"`
+	CustomerID      *uuid.UUID   `json:"customer_id"`
+	PaymentMethodID *uuid.UUID   `json:"payment_method_id"`
+	OrderType       string       `gorm:"default:dine_in;not null" json:"order_type"`
+	TableID         *uuid.UUID   `json:"table_id"`
+	InvoiceNumber   string       `gorm:"uniqueIndex;not null" json:"invoice_number"`
+	Total           money.Amount `gorm:"type:bigint;not null" json:"total" swaggertype:"number"`
+	Discount        money.Amount `gorm:"type:bigint;default:0;not null" json:"discount" swaggertype:"number"`
+	ServiceCharge   money.Amount `gorm:"type:bigint;default:0;not null" json:"service_charge" swaggertype:"number"`
+	Tax             money.Amount `gorm:"type:bigint;default:0;not null" json:"tax" swaggertype:"number"`
+	TaxIncluded     money.Amount `gorm:"type:bigint;default:0;not null" json:"tax_included" swaggertype:"number"`
+	CashRounding    money.Amount `gorm:"type:bigint;default:0;not null" json:"cash_rounding" swaggertype:"number"`
+	GrandTotal      money.Amount `gorm:"type:bigint;not null" json:"grand_total" swaggertype:"number"`
+	Status          string       `gorm:"not null" json:"status"`
+	SaleDate        time.Time    `gorm:"default:CURRENT_TIMESTAMP;not null" json:"sale_date"`
+	Note            *string      `gorm:"type:text" json:"note"`
+	SplitFromID     *uuid.UUID   `json:"split_from_id"`
+	HeldAt          *time.Time   `json:"held_at"`
+	HoldExpiresAt   *time.Time   `json:"hold_expires_at"`
+	VoidReason      *string      `gorm:"type:text" json:"void_reason"`
+	VoidedAt        *time.Time   `json:"voided_at"`
+	DeliveryAddress *string      `gorm:"type:text" json:"delivery_address"`
+	DeliveryContact *string      `json:"delivery_contact"`
+	DeliveryPhone   *string      `json:"delivery_phone"`
+	DeliveryFee     money.Amount `gorm:"type:bigint;default:0;not null" json:"delivery_fee" swaggertype:"number"`
+	PickupAt        *time.Time   `json:"pickup_at"`
+	CreatedAt       time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt       time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet        *Outlet        `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	OutletStaff   *OutletStaff   `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
-	Customer      *Customer      `gorm:"foreignKey:customer_id;references:id" json:"-"`
-	PaymentMethod *PaymentMethod `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
-	Table         *Table         `gorm:"foreignKey:table_id;references:id" json:"-"`
-	SaleItems     []SaleItem     `gorm:"foreignKey:sale_id;references:id" json:"-"`
-	SaleCoupons   []SaleCoupon   `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	Outlet         *Outlet         `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	OutletStaff    *OutletStaff    `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+	Customer       *Customer       `gorm:"foreignKey:customer_id;references:id" json:"-"`
+	PaymentMethod  *PaymentMethod  `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
+	Table          *Table          `gorm:"foreignKey:table_id;references:id" json:"-"`
+	SaleItems      []SaleItem      `gorm:"foreignKey:sale_id;references:id" json:"items,omitempty"`
+	SaleCoupons    []SaleCoupon    `gorm:"foreignKey:sale_id;references:id" json:"coupons,omitempty"`
+	SalePromotions []SalePromotion `gorm:"foreignKey:sale_id;references:id" json:"promotions,omitempty"`
+	Refunds        []Refund        `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	SalePayments   []SalePayment   `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	Approvals      []Approval      `gorm:"foreignKey:sale_id;references:id" json:"-"`
 }
 
+// BeforeCreate keeps an id generated by the terminal, sales made offline are uploaded with it.
 func (sale *Sale) BeforeCreate(_ *gorm.DB) error {
-	sale.ID = uuid.New()
+	if sale.ID == uuid.Nil {
+		sale.ID = uuid.New()
+	}
 	return nil
 }
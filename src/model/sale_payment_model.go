@@ -0,0 +1,35 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SalePayment struct {
+	ID              uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleID          uuid.UUID    `gorm:"not null" json:"sale_id"`
+	PaymentMethodID uuid.UUID    `gorm:"not null" json:"payment_method_id"`
+	Amount          money.Amount `gorm:"type:bigint;not null" json:"amount" swaggertype:"number"`
+	Tendered        money.Amount `gorm:"type:bigint;not null" json:"tendered" swaggertype:"number"`
+	Change          money.Amount `gorm:"type:bigint;default:0;not null" json:"change" swaggertype:"number"`
+	Tip             money.Amount `gorm:"type:bigint;default:0;not null" json:"tip" swaggertype:"number"`
+	OutletStaffID   *uuid.UUID   `json:"outlet_staff_id"`
+	Status          string       `gorm:"not null" json:"status"`
+	Reference       *string      `json:"reference"`
+	PaidAt          *time.Time   `json:"paid_at"`
+	CreatedAt       time.Time    `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt       time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Sale          *Sale          `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	PaymentMethod *PaymentMethod `gorm:"foreignKey:payment_method_id;references:id" json:"-"`
+	OutletStaff   *OutletStaff   `gorm:"foreignKey:outlet_staff_id;references:id" json:"-"`
+}
+
+func (salePayment *SalePayment) BeforeCreate(_ *gorm.DB) error {
+	salePayment.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SalePromotion struct {
+	ID          uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	SaleID      uuid.UUID    `gorm:"not null" json:"sale_id"`
+	PromotionID *uuid.UUID   `json:"promotion_id"`
+	Name        string       `gorm:"not null" json:"name"`
+	Discount    money.Amount `gorm:"type:bigint;default:0;not null" json:"discount" swaggertype:"number"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt   time.Time    `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Sale      *Sale      `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	Promotion *Promotion `gorm:"foreignKey:promotion_id;references:id" json:"-"`
+}
+
+func (SalePromotion) TableName() string {
+	return "sales_promotions"
+}
+
+func (salePromotion *SalePromotion) BeforeCreate(_ *gorm.DB) error {
+	salePromotion.ID = uuid.New()
+	return nil
+}
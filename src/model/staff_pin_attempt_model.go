@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StaffPinAttempt struct {
+	OutletStaffID uuid.UUID  `gorm:"primaryKey;not null" json:"outlet_staff_id"`
+	FailedCount   int        `gorm:"not null" json:"failed_count"`
+	LockedUntil   *time.Time `json:"locked_until"`
+	UpdatedAt     time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StaffShift struct {
+	ID            uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID      uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	OutletStaffID uuid.UUID  `gorm:"not null" json:"outlet_staff_id"`
+	ClockIn       time.Time  `gorm:"not null" json:"clock_in"`
+	ClockOut      *time.Time `json:"clock_out"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt     time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet      *Outlet      `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	OutletStaff *OutletStaff `gorm:"foreignKey:outlet_staff_id;references:id" json:"outlet_staff,omitempty"`
+}
+
+func (staffShift *StaffShift) BeforeCreate(_ *gorm.DB) error {
+	staffShift.ID = uuid.New()
+	return nil
+}
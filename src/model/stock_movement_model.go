@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StockMovement struct {
+	ID          uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID    uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	ProductID   uuid.UUID  `gorm:"not null" json:"product_id"`
+	Quantity    int        `gorm:"not null" json:"quantity"`
+	Type        string     `gorm:"not null" json:"type"`
+	ReferenceID *uuid.UUID `json:"reference_id"`
+	Note        *string    `gorm:"type:text" json:"note"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet  *Outlet  `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Product *Product `gorm:"foreignKey:product_id;references:id" json:"-"`
+}
+
+func (stockMovement *StockMovement) BeforeCreate(_ *gorm.DB) error {
+	stockMovement.ID = uuid.New()
+	return nil
+}
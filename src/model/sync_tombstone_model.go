@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SyncTombstone records a deleted row for the terminals. Rows are written by a database trigger.
+type SyncTombstone struct {
+	ID          uuid.UUID  `gorm:"primaryKey;not null" json:"-"`
+	Table       string     `gorm:"column:table_name;not null" json:"table"`
+	RecordID    uuid.UUID  `gorm:"not null" json:"id"`
+	BusinessID  *uuid.UUID `json:"-"`
+	OutletID    *uuid.UUID `json:"-"`
+	SyncVersion int64      `gorm:"not null" json:"-"`
+	DeletedAt   time.Time  `gorm:"not null" json:"deleted_at"`
+}
@@ -8,18 +8,31 @@ import (
 )
 
 type Table struct {
-	ID        uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	OutletID  uuid.UUID `gorm:"not null" json:"outlet_id"`
-	Name      string    `gorm:"not null" json:"name"`
-	Location  *string   `json:"location"`
-	Status    *string   `json:"status"`
-	Capacity  int       `gorm:"not null" json:"capacity"`
-	CreatedAt time.Time `gorm:"autoCreateTime:milli" json:"-"`
-	UpdatedAt time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+	ID              uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID        uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	FloorAreaID     *uuid.UUID `json:"floor_area_id"`
+	MergedIntoID    *uuid.UUID `json:"merged_into_id"`
+	Name            string     `gorm:"not null" json:"name"`
+	Location        *string    `json:"location"`
+	Status          string     `gorm:"not null;default:available" json:"status"`
+	Capacity        int        `gorm:"not null" json:"capacity"`
+	Shape           string     `gorm:"not null;default:square" json:"shape"`
+	PosX            int        `gorm:"not null" json:"pos_x"`
+	PosY            int        `gorm:"not null" json:"pos_y"`
+	Width           int        `gorm:"not null" json:"width"`
+	Height          int        `gorm:"not null" json:"height"`
+	Rotation        int        `gorm:"not null" json:"rotation"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+	OrderingVersion int        `gorm:"not null;default:1" json:"-"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt       time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
 
 	// Relationships
-	Outlet *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
-	Sales  []Sale  `gorm:"foreignKey:table_id;references:id" json:"-"`
+	Outlet     *Outlet      `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	FloorArea  *FloorArea   `gorm:"foreignKey:floor_area_id;references:id" json:"-"`
+	MergedInto *Table       `gorm:"foreignKey:merged_into_id;references:id" json:"-"`
+	Sales      []Sale       `gorm:"foreignKey:table_id;references:id" json:"-"`
+	Orders     []TableOrder `gorm:"foreignKey:table_id;references:id" json:"-"`
 }
 
 func (table *Table) BeforeCreate(_ *gorm.DB) error {
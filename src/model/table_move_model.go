@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TableMove records a sale moved to another table, or tables merged and unmerged.
+type TableMove struct {
+	ID           uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID     uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	Action       string     `gorm:"not null" json:"action"`
+	SaleID       *uuid.UUID `json:"sale_id"`
+	MergedSaleID *uuid.UUID `json:"merged_sale_id"`
+	FromTableID  *uuid.UUID `json:"from_table_id"`
+	ToTableID    *uuid.UUID `json:"to_table_id"`
+	UserID       *uuid.UUID `json:"user_id"`
+	Reason       *string    `json:"reason"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli" json:"created_at"`
+
+	// Relationships
+	Outlet     *Outlet `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Sale       *Sale   `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	MergedSale *Sale   `gorm:"foreignKey:merged_sale_id;references:id" json:"-"`
+	FromTable  *Table  `gorm:"foreignKey:from_table_id;references:id" json:"-"`
+	ToTable    *Table  `gorm:"foreignKey:to_table_id;references:id" json:"-"`
+	User       *User   `gorm:"foreignKey:user_id;references:id" json:"-"`
+}
+
+func (tableMove *TableMove) BeforeCreate(_ *gorm.DB) error {
+	tableMove.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TableOrder is an order guests placed from the QR code on their table, waiting for staff to confirm
+// it onto the table's open sale.
+type TableOrder struct {
+	ID           uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID     uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	TableID      uuid.UUID  `gorm:"not null" json:"table_id"`
+	SaleID       *uuid.UUID `json:"sale_id"`
+	Status       string     `gorm:"not null;default:pending" json:"status"`
+	Name         *string    `json:"name"`
+	Note         *string    `json:"note"`
+	RejectReason *string    `json:"reject_reason"`
+	UserID       *uuid.UUID `json:"user_id"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	RejectedAt   *time.Time `json:"rejected_at"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet *Outlet          `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Table  *Table           `gorm:"foreignKey:table_id;references:id" json:"-"`
+	Sale   *Sale            `gorm:"foreignKey:sale_id;references:id" json:"-"`
+	User   *User            `gorm:"foreignKey:user_id;references:id" json:"-"`
+	Items  []TableOrderItem `gorm:"foreignKey:table_order_id;references:id" json:"items,omitempty"`
+}
+
+func (tableOrder *TableOrder) BeforeCreate(_ *gorm.DB) error {
+	tableOrder.ID = uuid.New()
+	return nil
+}
+
+type TableOrderItem struct {
+	ID           uuid.UUID    `gorm:"primaryKey;not null" json:"id"`
+	TableOrderID uuid.UUID    `gorm:"not null" json:"table_order_id"`
+	ProductID    uuid.UUID    `gorm:"not null" json:"product_id"`
+	Quantity     int          `gorm:"not null" json:"quantity"`
+	Price        money.Amount `gorm:"type:bigint;not null" json:"price" swaggertype:"number"`
+	Note         *string      `json:"note"`
+	Modifiers    []string     `gorm:"type:jsonb;serializer:json" json:"modifiers"`
+	CreatedAt    time.Time    `gorm:"autoCreateTime:milli" json:"-"`
+
+	// Relationships
+	TableOrder *TableOrder `gorm:"foreignKey:table_order_id;references:id" json:"-"`
+	Product    *Product    `gorm:"foreignKey:product_id;references:id" json:"-"`
+}
+
+func (tableOrderItem *TableOrderItem) BeforeCreate(_ *gorm.DB) error {
+	tableOrderItem.ID = uuid.New()
+	return nil
+}
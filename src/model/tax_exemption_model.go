@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaxExemption struct {
+	ID                uuid.UUID `gorm:"primaryKey;not null" json:"id"`
+	TaxID             uuid.UUID `gorm:"not null" json:"tax_id"`
+	ProductCategoryID uuid.UUID `gorm:"not null" json:"product_category_id"`
+	CreatedAt         time.Time `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt         time.Time `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Tax             *Tax             `gorm:"foreignKey:tax_id;references:id" json:"-"`
+	ProductCategory *ProductCategory `gorm:"foreignKey:product_category_id;references:id" json:"-"`
+}
+
+func (taxExemption *TaxExemption) BeforeCreate(_ *gorm.DB) error {
+	taxExemption.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Tax struct {
+	ID          uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	BusinessID  uuid.UUID  `gorm:"not null" json:"business_id"`
+	OutletID    *uuid.UUID `json:"outlet_id"`
+	Name        string     `gorm:"not null" json:"name"`
+	Type        string     `gorm:"default:tax;not null" json:"type"`
+	Rate        float64    `gorm:"type:numeric(6,3);not null" json:"rate"`
+	Inclusive   bool       `gorm:"default:false;not null" json:"inclusive"`
+	Taxable     bool       `gorm:"default:false;not null" json:"taxable"`
+	IsActive    bool       `gorm:"default:true;not null" json:"is_active"`
+	OrderTypes  []string   `gorm:"type:jsonb;serializer:json" json:"order_types"` // every order type when empty
+	SyncVersion int64      `gorm:"<-:false" json:"-"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime:milli" json:"-"`
+	UpdatedAt   time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Business      *Business      `gorm:"foreignKey:business_id;references:id" json:"-"`
+	Outlet        *Outlet        `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	TaxExemptions []TaxExemption `gorm:"foreignKey:tax_id;references:id" json:"exemptions"`
+}
+
+func (tax *Tax) BeforeCreate(_ *gorm.DB) error {
+	tax.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistEntry is a walk-in party waiting for a table.
+type WaitlistEntry struct {
+	ID                uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	OutletID          uuid.UUID  `gorm:"not null" json:"outlet_id"`
+	CustomerID        *uuid.UUID `json:"customer_id"`
+	TableID           *uuid.UUID `json:"table_id"`
+	Name              string     `gorm:"not null" json:"name"`
+	Phone             *string    `json:"phone"`
+	PartySize         int        `gorm:"not null" json:"party_size"`
+	Status            string     `gorm:"not null;default:waiting" json:"status"`
+	QuotedWaitMinutes int        `gorm:"not null" json:"quoted_wait_minutes"`
+	Note              *string    `gorm:"type:text" json:"note"`
+	SeatedAt          *time.Time `json:"seated_at"`
+	LeftAt            *time.Time `json:"left_at"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime:milli" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"autoCreateTime:milli;autoUpdateTime:milli" json:"-"`
+
+	// Relationships
+	Outlet   *Outlet   `gorm:"foreignKey:outlet_id;references:id" json:"-"`
+	Customer *Customer `gorm:"foreignKey:customer_id;references:id" json:"-"`
+	Table    *Table    `gorm:"foreignKey:table_id;references:id" json:"-"`
+}
+
+func (waitlistEntry *WaitlistEntry) BeforeCreate(_ *gorm.DB) error {
+	waitlistEntry.ID = uuid.New()
+	return nil
+}
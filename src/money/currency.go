@@ -0,0 +1,169 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"   // half away from zero
+	RoundHalfEven RoundingMode = "half_even" // banker's rounding
+	RoundDown     RoundingMode = "down"      // towards zero
+	RoundUp       RoundingMode = "up"        // away from zero
+)
+
+// Decimals used in practice, currencies that are not listed use two.
+var currencyDecimals = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+}
+
+// Currency decides how computed amounts such as taxes, prorations and allocations are rounded.
+type Currency struct {
+	Code     string
+	Decimals int
+	Rounding RoundingMode
+}
+
+func NewCurrency(code string, rounding RoundingMode) Currency {
+	code = strings.ToUpper(code)
+
+	decimals, ok := currencyDecimals[code]
+	if !ok {
+		decimals = 2
+	}
+
+	switch rounding {
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+	default:
+		rounding = RoundHalfUp
+	}
+
+	return Currency{Code: code, Decimals: decimals, Rounding: rounding}
+}
+
+// step is the smallest amount of the currency in hundredths, 100 for a currency without decimals.
+func (c Currency) step() int64 {
+	return int64(math.Pow10(2 - c.Decimals))
+}
+
+// Round rounds an amount to the smallest unit of the currency.
+func (c Currency) Round(amount Amount) Amount {
+	return c.Ratio(amount, 1, 1)
+}
+
+// Format writes the amount rounded to the currency with thousands separators, for receipts and
+// other printed documents, e.g. "12,500" for IDR or "-1,234.50" for USD.
+func (c Currency) Format(amount Amount) string {
+	value := int64(c.Round(amount))
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	whole := strconv.FormatInt(value/scale, 10)
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+
+	if c.Decimals == 0 {
+		return sign + whole
+	}
+	return sign + whole + "." + fmt.Sprintf("%02d", value%scale)[:c.Decimals]
+}
+
+// Ratio returns amount * numerator / denominator rounded to the currency.
+func (c Currency) Ratio(amount Amount, numerator, denominator int64) Amount {
+	step := c.step()
+
+	product := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(numerator))
+	return Amount(divide(product, big.NewInt(denominator*step), c.Rounding) * step)
+}
+
+// Percent returns rate percent of amount rounded to the currency. Rates are exact to three decimals.
+func (c Currency) Percent(amount Amount, rate float64) Amount {
+	return c.Ratio(amount, int64(math.Round(rate*1000)), 100000)
+}
+
+// Allocate splits total proportionally to weights. Every share is rounded to the currency and
+// the last share with a weight absorbs the remainder, so the shares always add up to total.
+// When every weight is zero the total is split equally.
+func (c Currency) Allocate(total Amount, weights []Amount) []Amount {
+	shares := make([]Amount, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	var sum Amount
+	last := len(weights) - 1
+	for i, weight := range weights {
+		sum += weight
+		if weight != 0 {
+			last = i
+		}
+	}
+
+	var allocated Amount
+	for i, weight := range weights {
+		switch {
+		case i == last:
+			shares[i] = total - allocated
+			return shares
+		case sum == 0:
+			shares[i] = c.Ratio(total, 1, int64(len(weights)))
+		default:
+			shares[i] = c.Ratio(total, int64(weight), int64(sum))
+		}
+		allocated += shares[i]
+	}
+
+	return shares
+}
+
+// divide returns numerator / denominator rounded with mode.
+func divide(numerator, denominator *big.Int, mode RoundingMode) int64 {
+	if denominator.Sign() < 0 {
+		numerator = new(big.Int).Neg(numerator)
+		denominator = new(big.Int).Neg(denominator)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient.Int64()
+	}
+
+	// Compare twice the remainder with the denominator to find which side of the half we are on
+	half := new(big.Int).Abs(remainder)
+	half.Mul(half, big.NewInt(2))
+	comparison := half.Cmp(denominator)
+
+	awayFromZero := false
+	switch mode {
+	case RoundUp:
+		awayFromZero = true
+	case RoundDown:
+		awayFromZero = false
+	case RoundHalfEven:
+		awayFromZero = comparison > 0 || (comparison == 0 && quotient.Bit(0) == 1)
+	default:
+		awayFromZero = comparison >= 0
+	}
+
+	if awayFromZero {
+		if remainder.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
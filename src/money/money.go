@@ -0,0 +1,157 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount is a monetary amount in hundredths of the currency unit, whatever the currency.
+// It is stored as BIGINT and sent over JSON in currency units, so 1250 is written as 12.50.
+type Amount int64
+
+const scale = 100
+
+// FromUnits converts a whole number of currency units, e.g. FromUnits(15000) is 15,000.00.
+func FromUnits(units int64) Amount {
+	return Amount(units * scale)
+}
+
+// FromFloat converts a float in currency units, rounding half away from zero. Only meant for
+// values that are not money yet, such as configuration.
+func FromFloat(value float64) Amount {
+	return Amount(math.Round(value * scale))
+}
+
+// Parse reads a decimal amount in currency units such as "12.5" or "-3.25" without going
+// through float64. More than two decimals is an error.
+func Parse(text string) (Amount, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	negative := strings.HasPrefix(text, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("amount %s has more than 2 decimals", text)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseUint(whole+(fraction + "00")[:2], 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %s", text)
+	}
+
+	if negative {
+		return -Amount(units), nil
+	}
+	return Amount(units), nil
+}
+
+// Float64 returns the amount in currency units, for display and reporting only.
+func (a Amount) Float64() float64 {
+	return float64(a) / scale
+}
+
+// String formats the amount in currency units with two decimals, e.g. "-12.50".
+func (a Amount) String() string {
+	sign := ""
+	value := int64(a)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/scale, value%scale)
+}
+
+// Mul multiplies the amount by a quantity.
+func (a Amount) Mul(quantity int) Amount {
+	return a * Amount(quantity)
+}
+
+// RoundTo rounds the amount to a multiple of increment, e.g. to the nearest 500 for cash.
+func (a Amount) RoundTo(increment Amount, mode RoundingMode) Amount {
+	if increment <= 0 {
+		return a
+	}
+
+	return Amount(divide(big.NewInt(int64(a)), big.NewInt(int64(increment)), mode)) * increment
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" {
+		return nil
+	}
+
+	// Exponents are not valid in Parse, but JSON encoders may use them for large numbers
+	if strings.ContainsAny(text, "eE") {
+		rat, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return fmt.Errorf("invalid amount %s", text)
+		}
+		text = rat.FloatString(2)
+	}
+
+	amount, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+// Scan reads a BIGINT column, or the NUMERIC returned by aggregates such as SUM.
+func (a *Amount) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = 0
+	case int64:
+		*a = Amount(v)
+	case float64:
+		*a = Amount(math.Round(v))
+	case []byte:
+		return a.scanText(string(v))
+	case string:
+		return a.scanText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Amount", value)
+	}
+
+	return nil
+}
+
+func (a *Amount) scanText(text string) error {
+	if units, err := strconv.ParseInt(text, 10, 64); err == nil {
+		*a = Amount(units)
+		return nil
+	}
+
+	rat, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return fmt.Errorf("cannot scan %q into money.Amount", text)
+	}
+
+	*a = Amount(divide(rat.Num(), rat.Denom(), RoundHalfUp))
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return int64(a), nil
+}
@@ -0,0 +1,74 @@
+package receipt
+
+import (
+	"app/src/escpos"
+	"app/src/model"
+	"strconv"
+	"time"
+)
+
+// Kinds of kitchen tickets
+const (
+	TicketOrder   = "order"
+	TicketReprint = "reprint"
+	TicketVoid    = "void"
+)
+
+// KitchenTicket lays out the items a station has to prepare, or stop preparing for a void
+// ticket. Prices are left out, items are printed large with their seat, modifiers and note.
+// The sale needs its staff and table loaded and the items their product.
+func KitchenTicket(
+	sale *model.Sale, station string, items []model.SaleItem, kind string, opts Options,
+) *escpos.Document {
+	doc := escpos.NewDocument(opts.Paper)
+	doc.CodePage = opts.CodePage
+	l := labelsFor(opts.Language)
+	large := escpos.Style{Bold: true, Double: true}
+
+	switch kind {
+	case TicketVoid:
+		doc.Line("*** "+l.Void+" ***", title)
+	case TicketReprint:
+		doc.Line(l.Reprint, title)
+	}
+
+	doc.Line(station, escpos.Style{Align: escpos.AlignCenter, Bold: true})
+	doc.Separator()
+	if sale.Table != nil {
+		doc.Line(l.Table+" "+sale.Table.Name, large)
+	}
+	if name := l.orderType(sale.OrderType); name != "" {
+		doc.Line(name, large)
+	}
+	if sale.PickupAt != nil {
+		doc.Row(l.Pickup, sale.PickupAt.In(time.Local).Format("15:04"), bold)
+	}
+	doc.Row(l.Invoice, sale.InvoiceNumber, plain)
+	if sale.OutletStaff != nil {
+		doc.Row(l.Staff, sale.OutletStaff.Name, plain)
+	}
+	doc.Row(l.Date, time.Now().In(time.Local).Format("2006-01-02 15:04"), plain)
+	doc.Separator()
+
+	for _, item := range items {
+		name := item.ProductID.String()
+		if item.Product != nil {
+			name = item.Product.Name
+		}
+
+		doc.Line(strconv.Itoa(item.Quantity)+"x "+name, large)
+		if item.SeatNumber != nil {
+			doc.Line("  "+l.Seat+" "+strconv.Itoa(*item.SeatNumber), plain)
+		}
+		for _, modifier := range item.Modifiers {
+			doc.Line("  + "+modifier, bold)
+		}
+		if item.Note != nil && *item.Note != "" {
+			doc.Line("  "+l.Note+": "+*item.Note, bold)
+		}
+	}
+
+	doc.Feed(4)
+
+	return doc
+}
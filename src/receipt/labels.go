@@ -0,0 +1,113 @@
+package receipt
+
+import "app/src/config"
+
+// Languages receipts can be printed in
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+type labels struct {
+	Invoice     string
+	Date        string
+	Cashier     string
+	Staff       string
+	Table       string
+	Takeaway    string
+	Delivery    string
+	Pickup      string
+	DeliverTo   string
+	DeliveryFee string
+	Seat        string
+	Note        string
+	Subtotal    string
+	Discount    string
+	Included    string
+	Rounding    string
+	Total       string
+	Tip         string
+	Change      string
+	Unpaid      string
+	Void        string
+	Reprint     string
+	ThankYou    string
+}
+
+var translations = map[string]labels{
+	LanguageEnglish: {
+		Invoice:     "Invoice",
+		Date:        "Date",
+		Cashier:     "Cashier",
+		Staff:       "Staff",
+		Table:       "Table",
+		Takeaway:    "TAKEAWAY",
+		Delivery:    "DELIVERY",
+		Pickup:      "Pickup",
+		DeliverTo:   "Deliver to",
+		DeliveryFee: "Delivery fee",
+		Seat:        "Seat",
+		Note:        "Note",
+		Subtotal:    "Subtotal",
+		Discount:    "Discount",
+		Included:    "incl.",
+		Rounding:    "Rounding",
+		Total:       "TOTAL",
+		Tip:         "Tip",
+		Change:      "Change",
+		Unpaid:      "NOT PAID",
+		Void:        "VOID",
+		Reprint:     "REPRINT",
+		ThankYou:    "Thank you for your visit",
+	},
+	LanguageIndonesian: {
+		Invoice:     "No. Nota",
+		Date:        "Tanggal",
+		Cashier:     "Kasir",
+		Staff:       "Staf",
+		Table:       "Meja",
+		Takeaway:    "BAWA PULANG",
+		Delivery:    "PESAN ANTAR",
+		Pickup:      "Diambil",
+		DeliverTo:   "Kirim ke",
+		DeliveryFee: "Ongkos kirim",
+		Seat:        "Kursi",
+		Note:        "Catatan",
+		Subtotal:    "Subtotal",
+		Discount:    "Diskon",
+		Included:    "termasuk",
+		Rounding:    "Pembulatan",
+		Total:       "TOTAL",
+		Tip:         "Tip",
+		Change:      "Kembali",
+		Unpaid:      "BELUM DIBAYAR",
+		Void:        "BATAL",
+		Reprint:     "CETAK ULANG",
+		ThankYou:    "Terima kasih atas kunjungan Anda",
+	},
+}
+
+// IsLanguage reports whether receipts can be printed in the language.
+func IsLanguage(language string) bool {
+	_, ok := translations[language]
+	return ok
+}
+
+// orderType names takeaway and delivery orders, dine-in is not printed.
+func (l labels) orderType(orderType string) string {
+	switch orderType {
+	case config.OrderTypeTakeaway:
+		return l.Takeaway
+	case config.OrderTypeDelivery:
+		return l.Delivery
+	default:
+		return ""
+	}
+}
+
+func labelsFor(language string) labels {
+	if l, ok := translations[language]; ok {
+		return l
+	}
+	return translations[LanguageEnglish]
+}
@@ -0,0 +1,53 @@
+package receipt
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // decoders for image.Decode
+	_ "image/jpeg" // decoders for image.Decode
+	_ "image/png"  // decoders for image.Decode
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const maxLogoBytes = 2 << 20
+
+var (
+	logoClient = &http.Client{Timeout: 5 * time.Second}
+	logos      sync.Map // url to image.Image
+)
+
+// LoadLogo downloads and decodes a PNG, JPEG or GIF logo. Logos are kept in memory once loaded,
+// a business uploads a new one under a new URL.
+func LoadLogo(ctx context.Context, url string) (image.Image, error) {
+	if logo, ok := logos.Load(url); ok {
+		img, _ := logo.(image.Image)
+		return img, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := logoClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("logo %s returned status %d", url, resp.StatusCode)
+	}
+
+	img, _, err := image.Decode(io.LimitReader(resp.Body, maxLogoBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	logos.Store(url, img)
+	return img, nil
+}
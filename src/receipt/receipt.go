@@ -0,0 +1,199 @@
+package receipt
+
+import (
+	"app/src/config"
+	"app/src/escpos"
+	"app/src/model"
+	"app/src/money"
+	"fmt"
+	"image"
+	"strconv"
+	"time"
+)
+
+type Options struct {
+	Paper    escpos.Paper
+	CodePage escpos.CodePage
+	Language string
+	Currency money.Currency
+	Logo     image.Image // business logo, left out when nil
+	QRCode   string      // printed under the totals when set
+	Footer   string
+}
+
+var (
+	plain  = escpos.Style{}
+	center = escpos.Style{Align: escpos.AlignCenter}
+	bold   = escpos.Style{Bold: true}
+	title  = escpos.Style{Align: escpos.AlignCenter, Bold: true, Double: true}
+)
+
+// Render lays out the receipt of a sale. The sale needs its outlet with the business, staff,
+// table, items with their product and taxes, and payments with their method loaded.
+func Render(sale *model.Sale, opts Options) *escpos.Document {
+	doc := escpos.NewDocument(opts.Paper)
+	doc.CodePage = opts.CodePage
+	l := labelsFor(opts.Language)
+
+	header(doc, sale, opts, l)
+	doc.Separator()
+	items(doc, sale, opts.Currency, l)
+	doc.Separator()
+	totals(doc, sale, opts.Currency, l)
+	payments(doc, sale, opts.Currency, l)
+
+	switch sale.Status {
+	case config.SaleStatusUnpaid, config.SaleStatusHold:
+		doc.Feed(1)
+		doc.Line(l.Unpaid, title)
+	case config.SaleStatusVoid:
+		doc.Feed(1)
+		doc.Line(l.Void, title)
+	}
+
+	doc.Feed(1)
+	if opts.Footer != "" {
+		doc.Line(opts.Footer, center)
+	}
+	doc.Line(l.ThankYou, center)
+	if opts.QRCode != "" {
+		doc.QRCode(opts.QRCode)
+	}
+	doc.Feed(3)
+
+	return doc
+}
+
+func header(doc *escpos.Document, sale *model.Sale, opts Options, l labels) {
+	if opts.Logo != nil {
+		doc.Image(opts.Logo)
+	}
+
+	if outlet := sale.Outlet; outlet != nil {
+		if outlet.Business != nil {
+			doc.Line(outlet.Business.Name, title)
+		}
+		doc.Line(outlet.Name, center)
+		doc.Line(outlet.Address, center)
+		if outlet.Phone != nil {
+			doc.Line(*outlet.Phone, center)
+		}
+	}
+
+	doc.Separator()
+	doc.Row(l.Invoice, sale.InvoiceNumber, plain)
+	doc.Row(l.Date, sale.SaleDate.In(time.Local).Format("2006-01-02 15:04"), plain)
+	if sale.OutletStaff != nil {
+		doc.Row(l.Cashier, sale.OutletStaff.Name, plain)
+	}
+	if sale.Table != nil {
+		doc.Row(l.Table, sale.Table.Name, plain)
+	}
+	orderDetails(doc, sale, l)
+}
+
+// orderDetails prints how a takeaway or delivery order leaves the outlet.
+func orderDetails(doc *escpos.Document, sale *model.Sale, l labels) {
+	if name := l.orderType(sale.OrderType); name != "" {
+		doc.Line(name, escpos.Style{Align: escpos.AlignCenter, Bold: true})
+	}
+	if sale.PickupAt != nil {
+		doc.Row(l.Pickup, sale.PickupAt.In(time.Local).Format("2006-01-02 15:04"), plain)
+	}
+	if sale.OrderType != config.OrderTypeDelivery {
+		return
+	}
+
+	doc.Line(l.DeliverTo+":", bold)
+	for _, line := range []*string{sale.DeliveryContact, sale.DeliveryPhone, sale.DeliveryAddress} {
+		if line != nil && *line != "" {
+			doc.Line(*line, plain)
+		}
+	}
+}
+
+func items(doc *escpos.Document, sale *model.Sale, currency money.Currency, l labels) {
+	for _, item := range sale.SaleItems {
+		name := item.ProductID.String()
+		if item.Product != nil {
+			name = item.Product.Name
+		}
+
+		doc.Line(name, plain)
+		quantity := fmt.Sprintf("  %d x %s", item.Quantity, currency.Format(item.Price))
+		doc.Row(quantity, currency.Format(item.Total+item.Discount), plain)
+		if item.Discount > 0 {
+			doc.Row("  "+l.Discount, currency.Format(-item.Discount), plain)
+		}
+	}
+}
+
+func totals(doc *escpos.Document, sale *model.Sale, currency money.Currency, l labels) {
+	doc.Row(l.Subtotal, currency.Format(sale.Total), plain)
+	if sale.Discount > 0 {
+		doc.Row(l.Discount, currency.Format(-sale.Discount), plain)
+	}
+
+	for _, charge := range taxBreakdown(sale) {
+		name := charge.Name + " " + strconv.FormatFloat(charge.Rate, 'f', -1, 64) + "%"
+		if charge.Inclusive {
+			name += " (" + l.Included + ")"
+		}
+		doc.Row(name, currency.Format(charge.Amount), plain)
+	}
+
+	if sale.DeliveryFee > 0 {
+		doc.Row(l.DeliveryFee, currency.Format(sale.DeliveryFee), plain)
+	}
+	if sale.CashRounding != 0 {
+		doc.Row(l.Rounding, currency.Format(sale.CashRounding), plain)
+	}
+	doc.Row(l.Total, currency.Format(sale.GrandTotal), bold)
+}
+
+func payments(doc *escpos.Document, sale *model.Sale, currency money.Currency, l labels) {
+	for _, payment := range sale.SalePayments {
+		if payment.Status != config.PaymentStatusPaid {
+			continue
+		}
+
+		name := ""
+		if payment.PaymentMethod != nil {
+			name = payment.PaymentMethod.Name
+		}
+
+		doc.Row(name, currency.Format(payment.Tendered), bold)
+		if payment.Tip > 0 {
+			doc.Row("  "+l.Tip, currency.Format(payment.Tip), plain)
+		}
+		if payment.Change > 0 {
+			doc.Row("  "+l.Change, currency.Format(payment.Change), plain)
+		}
+	}
+}
+
+// taxBreakdown sums the service charges and taxes of the items by name, rate and inclusiveness,
+// in the order they first appear.
+func taxBreakdown(sale *model.Sale) []model.SaleItemTax {
+	var charges []model.SaleItemTax
+	index := make(map[string]int)
+
+	for _, item := range sale.SaleItems {
+		for _, tax := range item.Taxes {
+			key := fmt.Sprintf("%s|%s|%g|%t", tax.Type, tax.Name, tax.Rate, tax.Inclusive)
+			i, ok := index[key]
+			if !ok {
+				i = len(charges)
+				index[key] = i
+				charges = append(charges, model.SaleItemTax{
+					Name: tax.Name, Type: tax.Type, Rate: tax.Rate, Inclusive: tax.Inclusive,
+				})
+			}
+			charges[i].Base += tax.Base
+			charges[i].Amount += tax.Amount
+			charges[i].Included += tax.Included
+		}
+	}
+
+	return charges
+}
@@ -0,0 +1,29 @@
+package receipt
+
+import (
+	"app/src/escpos"
+	"strconv"
+	"time"
+)
+
+// TestPage lays out a page to check that a printer is reachable and set up right: the paper
+// width, accented characters in the code page and a QR code.
+func TestPage(printerName string, opts Options) *escpos.Document {
+	doc := escpos.NewDocument(opts.Paper)
+	doc.CodePage = opts.CodePage
+
+	doc.Line("TEST PRINT", title)
+	doc.Line(printerName, center)
+	doc.Separator()
+	doc.Row("Paper", strconv.Itoa(opts.Paper.Width)+"mm", plain)
+	doc.Row("Code page", opts.CodePage.Name, plain)
+	doc.Row("Time", time.Now().In(time.Local).Format("2006-01-02 15:04:05"), plain)
+	doc.Separator()
+	doc.Line("ABCDEFGHIJKLMNOPQRSTUVWXYZ 0123456789", plain)
+	doc.Line("àáâäçèéêëìíîïñòóôöùúûü ÀÉÑÖÜ €", plain)
+	doc.Line("Bold", bold)
+	doc.QRCode("TEST PRINT")
+	doc.Feed(3)
+
+	return doc
+}
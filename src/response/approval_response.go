@@ -0,0 +1,10 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithApproval struct {
+	Code     int            `json:"code"`
+	Status   string         `json:"status"`
+	Message  string         `json:"message"`
+	Approval model.Approval `json:"approval"`
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"app/src/model"
+	"app/src/money"
+
+	"github.com/google/uuid"
+)
+
+// CouponValidation tells whether a code can be applied. A rejected code has the Reason and Message
+// explaining why, Coupon is left out when the code does not exist.
+type CouponValidation struct {
+	Valid    bool          `json:"valid"`
+	Reason   string        `json:"reason,omitempty"`
+	Message  string        `json:"message,omitempty"`
+	Coupon   *model.Coupon `json:"coupon,omitempty"`
+	Discount money.Amount  `json:"discount" swaggertype:"number"`
+}
+
+type SuccessWithCouponValidation struct {
+	Code             int              `json:"code"`
+	Status           string           `json:"status"`
+	Message          string           `json:"message"`
+	CouponValidation CouponValidation `json:"coupon_validation"`
+}
+
+type SuccessWithCoupon struct {
+	Code    int          `json:"code"`
+	Status  string       `json:"status"`
+	Message string       `json:"message"`
+	Coupon  model.Coupon `json:"coupon"`
+}
+
+type SuccessWithCoupons struct {
+	Code    int            `json:"code"`
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+	Coupons []model.Coupon `json:"coupons"`
+}
+
+// CouponUsage is how often a coupon was redeemed at an outlet and what it took off there.
+type CouponUsage struct {
+	OutletID   uuid.UUID    `json:"outlet_id"`
+	OutletName string       `json:"outlet_name"`
+	Uses       int64        `json:"uses"`
+	Discount   money.Amount `json:"discount" swaggertype:"number"`
+}
+
+type SuccessWithCouponUsage struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Usage   []CouponUsage `json:"usage"`
+}
@@ -0,0 +1,41 @@
+package response
+
+import (
+	"app/src/model"
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuccessWithCustomer struct {
+	Code     int            `json:"code"`
+	Status   string         `json:"status"`
+	Message  string         `json:"message"`
+	Customer model.Customer `json:"customer"`
+}
+
+// CustomerVisit is a sale of the customer at one of the outlets of the business.
+type CustomerVisit struct {
+	SaleID        uuid.UUID    `json:"sale_id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	OutletID      uuid.UUID    `json:"outlet_id"`
+	OutletName    string       `json:"outlet_name"`
+	Status        string       `json:"status"`
+	GrandTotal    money.Amount `json:"grand_total" swaggertype:"number"`
+	SaleDate      time.Time    `json:"sale_date"`
+}
+
+// CustomerDuplicates are customers that look like the same person, Match is what they share:
+// phone or name.
+type CustomerDuplicates struct {
+	Match     string           `json:"match"`
+	Customers []model.Customer `json:"customers"`
+}
+
+type SuccessWithCustomerDuplicates struct {
+	Code       int                  `json:"code"`
+	Status     string               `json:"status"`
+	Message    string               `json:"message"`
+	Duplicates []CustomerDuplicates `json:"duplicates"`
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"app/src/model"
+
+	"github.com/google/uuid"
+)
+
+type SuccessWithKitchenRoute struct {
+	Code         int                `json:"code"`
+	Status       string             `json:"status"`
+	Message      string             `json:"message"`
+	KitchenRoute model.KitchenRoute `json:"kitchen_route"`
+}
+
+type SuccessWithKitchenRoutes struct {
+	Code          int                  `json:"code"`
+	Status        string               `json:"status"`
+	Message       string               `json:"message"`
+	KitchenRoutes []model.KitchenRoute `json:"kitchen_routes"`
+}
+
+// KitchenEvent is a message of the kitchen display feed. Order ready events carry the sale and
+// all of its ready items.
+type KitchenEvent struct {
+	Event         string              `json:"event"`
+	SaleID        *uuid.UUID          `json:"sale_id,omitempty"`
+	InvoiceNumber string              `json:"invoice_number,omitempty"`
+	TableName     *string             `json:"table_name,omitempty"`
+	Items         []model.KitchenItem `json:"items"`
+}
+
+type SuccessWithKitchenItem struct {
+	Code        int               `json:"code"`
+	Status      string            `json:"status"`
+	Message     string            `json:"message"`
+	KitchenItem model.KitchenItem `json:"kitchen_item"`
+}
+
+type SuccessWithKitchenItems struct {
+	Code         int                 `json:"code"`
+	Status       string              `json:"status"`
+	Message      string              `json:"message"`
+	KitchenItems []model.KitchenItem `json:"kitchen_items"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithPrintJob struct {
+	Code     int            `json:"code"`
+	Status   string         `json:"status"`
+	Message  string         `json:"message"`
+	PrintJob model.PrintJob `json:"print_job"`
+}
+
+type SuccessWithPrintJobs struct {
+	Code      int              `json:"code"`
+	Status    string           `json:"status"`
+	Message   string           `json:"message"`
+	PrintJobs []model.PrintJob `json:"print_jobs"`
+}
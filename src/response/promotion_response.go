@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithPromotion struct {
+	Code      int             `json:"code"`
+	Status    string          `json:"status"`
+	Message   string          `json:"message"`
+	Promotion model.Promotion `json:"promotion"`
+}
+
+type SuccessWithPromotions struct {
+	Code       int               `json:"code"`
+	Status     string            `json:"status"`
+	Message    string            `json:"message"`
+	Promotions []model.Promotion `json:"promotions"`
+}
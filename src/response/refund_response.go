@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithRefund struct {
+	Code    int          `json:"code"`
+	Status  string       `json:"status"`
+	Message string       `json:"message"`
+	Refund  model.Refund `json:"refund"`
+}
+
+type SuccessWithRefunds struct {
+	Code    int            `json:"code"`
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+	Refunds []model.Refund `json:"refunds"`
+}
@@ -0,0 +1,70 @@
+package response
+
+import (
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EndOfDayReport struct {
+	OutletID      uuid.UUID            `json:"outlet_id"`
+	Date          string               `json:"date"`
+	Sales         int64                `json:"sales"`
+	Total         money.Amount         `json:"total" swaggertype:"number"`
+	Discount      money.Amount         `json:"discount" swaggertype:"number"`
+	ServiceCharge money.Amount         `json:"service_charge" swaggertype:"number"`
+	Tax           money.Amount         `json:"tax" swaggertype:"number"`
+	TaxIncluded   money.Amount         `json:"tax_included" swaggertype:"number"`
+	CashRounding  money.Amount         `json:"cash_rounding" swaggertype:"number"`
+	GrandTotal    money.Amount         `json:"grand_total" swaggertype:"number"`
+	Tips          money.Amount         `json:"tips" swaggertype:"number"`
+	Refunds       money.Amount         `json:"refunds" swaggertype:"number"`
+	Payments      []PaymentMethodTotal `json:"payments"`
+}
+
+type PaymentMethodTotal struct {
+	PaymentMethodID uuid.UUID    `json:"payment_method_id"`
+	Name            string       `json:"name"`
+	Type            string       `json:"type"`
+	Count           int64        `json:"count"`
+	Amount          money.Amount `json:"amount" swaggertype:"number"`
+	Change          money.Amount `json:"change" swaggertype:"number"`
+	Tips            money.Amount `json:"tips" swaggertype:"number"`
+}
+
+type SuccessWithEndOfDayReport struct {
+	Code    int            `json:"code"`
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+	Report  EndOfDayReport `json:"report"`
+}
+
+type TipPoolReport struct {
+	OutletID      uuid.UUID    `json:"outlet_id"`
+	From          time.Time    `json:"from"`
+	To            time.Time    `json:"to"`
+	Rule          string       `json:"rule"`
+	Tips          money.Amount `json:"tips" swaggertype:"number"`
+	Distributed   money.Amount `json:"distributed" swaggertype:"number"`
+	Undistributed money.Amount `json:"undistributed" swaggertype:"number"`
+	Staff         []TipShare   `json:"staff"`
+}
+
+// TipShare is what a staff member collected during the shift and what they get from the pool.
+type TipShare struct {
+	OutletStaffID uuid.UUID    `json:"outlet_staff_id"`
+	Name          string       `json:"name"`
+	Role          string       `json:"role"`
+	Hours         float64      `json:"hours"`
+	Weight        float64      `json:"weight"`
+	Collected     money.Amount `json:"collected" swaggertype:"number"`
+	Share         money.Amount `json:"share" swaggertype:"number"`
+}
+
+type SuccessWithTipPoolReport struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Report  TipPoolReport `json:"report"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithReservation struct {
+	Code        int               `json:"code"`
+	Status      string            `json:"status"`
+	Message     string            `json:"message"`
+	Reservation model.Reservation `json:"reservation"`
+}
+
+type SuccessWithReservations struct {
+	Code         int                 `json:"code"`
+	Status       string              `json:"status"`
+	Message      string              `json:"message"`
+	Reservations []model.Reservation `json:"reservations"`
+}
+
+// WaitlistEntry is a party on the waitlist with its place in line and current estimated wait,
+// both zero once the party is off the waitlist.
+type WaitlistEntry struct {
+	model.WaitlistEntry
+	Position             int `json:"position"`
+	EstimatedWaitMinutes int `json:"estimated_wait_minutes"`
+}
+
+type SuccessWithWaitlistEntry struct {
+	Code          int           `json:"code"`
+	Status        string        `json:"status"`
+	Message       string        `json:"message"`
+	WaitlistEntry WaitlistEntry `json:"waitlist_entry"`
+}
+
+type SuccessWithWaitlist struct {
+	Code     int             `json:"code"`
+	Status   string          `json:"status"`
+	Message  string          `json:"message"`
+	Waitlist []WaitlistEntry `json:"waitlist"`
+}
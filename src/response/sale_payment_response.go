@@ -0,0 +1,33 @@
+package response
+
+import (
+	"app/src/model"
+	"app/src/money"
+)
+
+type SalePaymentSummary struct {
+	GrandTotal   money.Amount `json:"grand_total" swaggertype:"number"`
+	Paid         money.Amount `json:"paid" swaggertype:"number"`
+	Pending      money.Amount `json:"pending" swaggertype:"number"`
+	Remaining    money.Amount `json:"remaining" swaggertype:"number"`
+	Change       money.Amount `json:"change" swaggertype:"number"`
+	CashRounding money.Amount `json:"cash_rounding" swaggertype:"number"`
+	Tips         money.Amount `json:"tips" swaggertype:"number"`
+	SaleStatus   string       `json:"sale_status"`
+}
+
+type SuccessWithSalePayment struct {
+	Code    int                `json:"code"`
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	Payment model.SalePayment  `json:"payment"`
+	Summary SalePaymentSummary `json:"summary"`
+}
+
+type SuccessWithSalePayments struct {
+	Code     int                 `json:"code"`
+	Status   string              `json:"status"`
+	Message  string              `json:"message"`
+	Payments []model.SalePayment `json:"payments"`
+	Summary  SalePaymentSummary  `json:"summary"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithSale struct {
+	Code    int        `json:"code"`
+	Status  string     `json:"status"`
+	Message string     `json:"message"`
+	Sale    model.Sale `json:"sale"`
+}
+
+type SuccessWithSales struct {
+	Code    int          `json:"code"`
+	Status  string       `json:"status"`
+	Message string       `json:"message"`
+	Sales   []model.Sale `json:"sales"`
+}
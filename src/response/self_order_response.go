@@ -0,0 +1,86 @@
+package response
+
+import (
+	"app/src/model"
+	"app/src/money"
+
+	"github.com/google/uuid"
+)
+
+// SelfOrderMenu is what guests can order from their table, the products at their dine-in price.
+type SelfOrderMenu struct {
+	Outlet     string              `json:"outlet"`
+	Table      string              `json:"table"`
+	Categories []SelfOrderCategory `json:"categories"`
+}
+
+type SelfOrderCategory struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	Products    []SelfOrderProduct `json:"products"`
+}
+
+type SelfOrderProduct struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description *string      `json:"description"`
+	Image       *string      `json:"image"`
+	Price       money.Amount `json:"price" swaggertype:"number"`
+}
+
+type SuccessWithSelfOrderMenu struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Menu    SelfOrderMenu `json:"menu"`
+}
+
+// SelfOrderCart is a priced cart with the promotions, taxes and service charges of the outlet.
+type SelfOrderCart struct {
+	Items         []model.SaleItem      `json:"items"`
+	Promotions    []model.SalePromotion `json:"promotions"`
+	Total         money.Amount          `json:"total" swaggertype:"number"`
+	Discount      money.Amount          `json:"discount" swaggertype:"number"`
+	ServiceCharge money.Amount          `json:"service_charge" swaggertype:"number"`
+	Tax           money.Amount          `json:"tax" swaggertype:"number"`
+	TaxIncluded   money.Amount          `json:"tax_included" swaggertype:"number"`
+	GrandTotal    money.Amount          `json:"grand_total" swaggertype:"number"`
+}
+
+type SuccessWithSelfOrderCart struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Cart    SelfOrderCart `json:"cart"`
+}
+
+type SuccessWithTableOrder struct {
+	Code       int              `json:"code"`
+	Status     string           `json:"status"`
+	Message    string           `json:"message"`
+	TableOrder model.TableOrder `json:"table_order"`
+}
+
+// SuccessWithConfirmedTableOrder has the confirmed order and the sale its items were added to.
+type SuccessWithConfirmedTableOrder struct {
+	Code       int              `json:"code"`
+	Status     string           `json:"status"`
+	Message    string           `json:"message"`
+	TableOrder model.TableOrder `json:"table_order"`
+	Sale       model.Sale       `json:"sale"`
+}
+
+// OrderingLink is the link printed as the QR code on a table.
+type OrderingLink struct {
+	TableID uuid.UUID `json:"table_id"`
+	Token   string    `json:"token"`
+	URL     string    `json:"url"`
+}
+
+type SuccessWithOrderingLink struct {
+	Code         int          `json:"code"`
+	Status       string       `json:"status"`
+	Message      string       `json:"message"`
+	OrderingLink OrderingLink `json:"ordering_link"`
+}
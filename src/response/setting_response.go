@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithSetting struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Setting model.Setting `json:"setting"`
+}
+
+type SuccessWithSettings struct {
+	Code     int             `json:"code"`
+	Status   string          `json:"status"`
+	Message  string          `json:"message"`
+	Settings []model.Setting `json:"settings"`
+}
@@ -0,0 +1,10 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithStaffShift struct {
+	Code    int              `json:"code"`
+	Status  string           `json:"status"`
+	Message string           `json:"message"`
+	Shift   model.StaffShift `json:"shift"`
+}
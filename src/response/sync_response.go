@@ -0,0 +1,47 @@
+package response
+
+import (
+	"app/src/model"
+	"time"
+)
+
+// SyncChanges is what changed for an outlet after a cursor, or everything when the cursor is 0.
+// Terminals keep Cursor and send it with their next request.
+type SyncChanges struct {
+	Cursor            int64                   `json:"cursor"`
+	Full              bool                    `json:"full"`
+	ServerTime        time.Time               `json:"server_time"`
+	Products          []model.Product         `json:"products"`
+	ProductCategories []model.ProductCategory `json:"product_categories"`
+	Taxes             []model.Tax             `json:"taxes"`
+	Staff             []model.OutletStaff     `json:"staff"`
+	PaymentMethods    []model.PaymentMethod   `json:"payment_methods"`
+	Deleted           []model.SyncTombstone   `json:"deleted"`
+}
+
+type SyncSaleResult struct {
+	ID            string         `json:"id"`
+	Status        string         `json:"status"`
+	InvoiceNumber string         `json:"invoice_number,omitempty"`
+	Message       string         `json:"message,omitempty"`
+	Conflicts     []SyncConflict `json:"conflicts,omitempty"`
+}
+
+type SyncConflict struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type SuccessWithSyncChanges struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Changes SyncChanges `json:"changes"`
+}
+
+type SuccessWithSyncResults struct {
+	Code    int              `json:"code"`
+	Status  string           `json:"status"`
+	Message string           `json:"message"`
+	Results []SyncSaleResult `json:"results"`
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"app/src/model"
+	"app/src/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuccessWithFloorArea struct {
+	Code      int             `json:"code"`
+	Status    string          `json:"status"`
+	Message   string          `json:"message"`
+	FloorArea model.FloorArea `json:"floor_area"`
+}
+
+type SuccessWithFloorAreas struct {
+	Code       int               `json:"code"`
+	Status     string            `json:"status"`
+	Message    string            `json:"message"`
+	FloorAreas []model.FloorArea `json:"floor_areas"`
+}
+
+type SuccessWithTable struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Table   model.Table `json:"table"`
+}
+
+type SuccessWithTables struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Tables  []model.Table `json:"tables"`
+}
+
+// TableStatus is a table on the live floor plan with the open sales at it.
+type TableStatus struct {
+	TableID         uuid.UUID    `json:"table_id"`
+	FloorAreaID     *uuid.UUID   `json:"floor_area_id"`
+	MergedIntoID    *uuid.UUID   `json:"merged_into_id"`
+	Name            string       `json:"name"`
+	Capacity        int          `json:"capacity"`
+	Status          string       `json:"status"`
+	StatusChangedAt *time.Time   `json:"status_changed_at"`
+	OpenSales       int          `json:"open_sales"`
+	OpenTotal       money.Amount `json:"open_total"`
+	OccupiedSince   *time.Time   `json:"occupied_since"`
+	PendingOrders   int          `json:"pending_orders"` // orders guests placed from the table's QR code
+}
+
+type SuccessWithTableStatuses struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Tables  []TableStatus `json:"tables"`
+}
+
+// SuccessWithTableMerge has the merged tables, the host table first, and the sale they share.
+type SuccessWithTableMerge struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Tables  []model.Table `json:"tables"`
+	Sale    *model.Sale   `json:"sale"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "app/src/model"
+
+type SuccessWithTax struct {
+	Code    int       `json:"code"`
+	Status  string    `json:"status"`
+	Message string    `json:"message"`
+	Tax     model.Tax `json:"tax"`
+}
+
+type SuccessWithTaxes struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Taxes   []model.Tax `json:"taxes"`
+}
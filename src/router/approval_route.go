@@ -0,0 +1,23 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ApprovalRoutes(v1 fiber.Router, u service.UserService, s service.ApprovalService) {
+	approvalController := controller.NewApprovalController(s)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/approvals", m.Auth(u, "getSales"), approvalController.GetApprovals)
+	outlet.Post("/:outletId/approvals", m.Auth(u, "manageSales"), approvalController.CreateApproval)
+	outlet.Put("/:outletId/staff/:staffId/pin", m.Auth(u, "manageOutlets"), approvalController.UpdateStaffPin)
+
+	approval := v1.Group("/approvals")
+	approval.Get("/:approvalId", m.Auth(u, "getSales"), approvalController.GetApprovalByID)
+	approval.Post("/:approvalId/approve", m.Auth(u, "manageOutlets"), approvalController.ApproveApproval)
+	approval.Post("/:approvalId/reject", m.Auth(u, "manageOutlets"), approvalController.RejectApproval)
+}
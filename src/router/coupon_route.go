@@ -0,0 +1,25 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CouponRoutes(v1 fiber.Router, u service.UserService, s service.CouponService) {
+	couponController := controller.NewCouponController(s)
+
+	business := v1.Group("/businesses")
+	business.Get("/:businessId/coupons", m.Auth(u, "getSales"), couponController.GetCoupons)
+	business.Post("/:businessId/coupons", m.Auth(u, "manageOutlets"), couponController.CreateCoupon)
+	business.Post("/:businessId/coupons/generate", m.Auth(u, "manageOutlets"), couponController.GenerateCoupons)
+	business.Get("/:businessId/coupons/export", m.Auth(u, "manageOutlets"), couponController.ExportCoupons)
+
+	coupon := v1.Group("/coupons")
+	coupon.Post("/validate", m.Auth(u, "getSales"), couponController.ValidateCoupon)
+	coupon.Get("/:couponId", m.Auth(u, "getSales"), couponController.GetCouponByID)
+	coupon.Get("/:couponId/usage", m.Auth(u, "getSales"), couponController.GetCouponUsage)
+	coupon.Patch("/:couponId", m.Auth(u, "manageOutlets"), couponController.UpdateCoupon)
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CustomerRoutes(v1 fiber.Router, u service.UserService, s service.CustomerService) {
+	customerController := controller.NewCustomerController(s)
+
+	business := v1.Group("/businesses")
+	business.Get("/:businessId/customers", m.Auth(u, "getSales"), customerController.GetCustomers)
+	business.Post("/:businessId/customers", m.Auth(u, "manageSales"), customerController.CreateCustomer)
+	business.Get("/:businessId/customers/duplicates", m.Auth(u, "manageOutlets"), customerController.GetCustomerDuplicates)
+
+	customer := v1.Group("/customers")
+	customer.Get("/:customerId", m.Auth(u, "getSales"), customerController.GetCustomerByID)
+	customer.Get("/:customerId/visits", m.Auth(u, "getSales"), customerController.GetCustomerVisits)
+	customer.Patch("/:customerId", m.Auth(u, "manageSales"), customerController.UpdateCustomer)
+	customer.Delete("/:customerId", m.Auth(u, "manageOutlets"), customerController.DeleteCustomer)
+	customer.Post("/:customerId/merge", m.Auth(u, "manageOutlets"), customerController.MergeCustomers)
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+func KitchenRoutes(v1 fiber.Router, u service.UserService, s service.KitchenService) {
+	kitchenController := controller.NewKitchenController(s)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/kitchen-routes", m.Auth(u, "getSales"), kitchenController.GetKitchenRoutes)
+	outlet.Post("/:outletId/kitchen-routes", m.Auth(u, "manageOutlets"), kitchenController.CreateKitchenRoute)
+	outlet.Get("/:outletId/kitchen-items", m.Auth(u, "getSales"), kitchenController.GetKitchenItems)
+	outlet.Get("/:outletId/kitchen-feed", m.WebSocketToken, m.Auth(u, "getSales"),
+		kitchenController.KitchenFeedUpgrade, websocket.New(kitchenController.KitchenFeed))
+
+	kitchenRoute := v1.Group("/kitchen-routes")
+	kitchenRoute.Delete("/:routeId", m.Auth(u, "manageOutlets"), kitchenController.DeleteKitchenRoute)
+
+	kitchenItem := v1.Group("/kitchen-items")
+	kitchenItem.Patch("/:itemId", m.Auth(u, "manageSales"), kitchenController.UpdateKitchenItem)
+
+	sale := v1.Group("/sales")
+	sale.Post("/:saleId/fire", m.Auth(u, "manageSales"), kitchenController.FireSale)
+	sale.Post("/:saleId/kitchen-tickets/reprint", m.Auth(u, "manageSales"), kitchenController.ReprintKitchenTickets)
+}
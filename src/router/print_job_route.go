@@ -0,0 +1,24 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PrintJobRoutes(v1 fiber.Router, u service.UserService, s service.PrintJobService) {
+	printJobController := controller.NewPrintJobController(s)
+
+	printer := v1.Group("/printers")
+	printer.Get("/:printerId/jobs", m.Auth(u, "getSales"), printJobController.GetPrintJobs)
+	printer.Post("/:printerId/test-print", m.Auth(u, "manageSales"), printJobController.TestPrint)
+
+	printJob := v1.Group("/print-jobs")
+	printJob.Get("/:jobId", m.Auth(u, "getSales"), printJobController.GetPrintJobByID)
+	printJob.Post("/:jobId/retry", m.Auth(u, "manageSales"), printJobController.RetryPrintJob)
+
+	sale := v1.Group("/sales")
+	sale.Post("/:saleId/print", m.Auth(u, "manageSales"), printJobController.PrintReceipt)
+}
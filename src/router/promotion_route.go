@@ -0,0 +1,22 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PromotionRoutes(v1 fiber.Router, u service.UserService, p service.PromotionService) {
+	promotionController := controller.NewPromotionController(p)
+
+	business := v1.Group("/businesses")
+	business.Get("/:businessId/promotions", m.Auth(u, "getSales"), promotionController.GetPromotions)
+	business.Post("/:businessId/promotions", m.Auth(u, "manageOutlets"), promotionController.CreatePromotion)
+
+	promotion := v1.Group("/promotions")
+	promotion.Get("/:promotionId", m.Auth(u, "getSales"), promotionController.GetPromotionByID)
+	promotion.Patch("/:promotionId", m.Auth(u, "manageOutlets"), promotionController.UpdatePromotion)
+	promotion.Delete("/:promotionId", m.Auth(u, "manageOutlets"), promotionController.DeletePromotion)
+}
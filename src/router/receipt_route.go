@@ -0,0 +1,17 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ReceiptRoutes(v1 fiber.Router, u service.UserService, s service.ReceiptService) {
+	receiptController := controller.NewReceiptController(s)
+
+	sale := v1.Group("/sales")
+	sale.Get("/:saleId/receipt", m.Auth(u, "getSales"), receiptController.GetReceipt)
+	sale.Get("/:saleId/receipt/preview", m.Auth(u, "getSales"), receiptController.PreviewReceipt)
+}
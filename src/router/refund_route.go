@@ -0,0 +1,20 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RefundRoutes(v1 fiber.Router, u service.UserService, r service.RefundService) {
+	refundController := controller.NewRefundController(r)
+
+	sale := v1.Group("/sales")
+	sale.Get("/:saleId/refunds", m.Auth(u, "getSales"), refundController.GetRefunds)
+	sale.Post("/:saleId/refunds", m.Auth(u, "manageSales"), refundController.CreateRefund)
+
+	refund := v1.Group("/refunds")
+	refund.Get("/:refundId", m.Auth(u, "getSales"), refundController.GetRefundByID)
+}
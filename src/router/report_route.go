@@ -0,0 +1,17 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ReportRoutes(v1 fiber.Router, u service.UserService, r service.ReportService) {
+	reportController := controller.NewReportController(r)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/reports/end-of-day", m.Auth(u, "getSales"), reportController.GetEndOfDay)
+	outlet.Get("/:outletId/reports/tip-pool", m.Auth(u, "getSales"), reportController.GetTipPool)
+}
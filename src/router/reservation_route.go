@@ -0,0 +1,29 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ReservationRoutes(v1 fiber.Router, u service.UserService, r service.ReservationService) {
+	reservationController := controller.NewReservationController(r)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/reservations", m.Auth(u, "getSales"), reservationController.GetReservations)
+	outlet.Post("/:outletId/reservations", m.Auth(u, "manageSales"), reservationController.CreateReservation)
+	outlet.Get("/:outletId/table-suggestions", m.Auth(u, "getSales"), reservationController.SuggestTables)
+	outlet.Get("/:outletId/waitlist", m.Auth(u, "getSales"), reservationController.GetWaitlist)
+	outlet.Post("/:outletId/waitlist", m.Auth(u, "manageSales"), reservationController.CreateWaitlistEntry)
+
+	reservation := v1.Group("/reservations")
+	reservation.Get("/:reservationId", m.Auth(u, "getSales"), reservationController.GetReservationByID)
+	reservation.Patch("/:reservationId", m.Auth(u, "manageSales"), reservationController.UpdateReservation)
+	reservation.Patch("/:reservationId/status", m.Auth(u, "manageSales"),
+		reservationController.UpdateReservationStatus)
+
+	waitlist := v1.Group("/waitlist")
+	waitlist.Patch("/:entryId", m.Auth(u, "manageSales"), reservationController.UpdateWaitlistEntry)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"app/src/config"
+	m "app/src/middleware"
 	"app/src/service"
 	"app/src/validation"
 
@@ -17,12 +18,50 @@ func Routes(app *fiber.App, db *gorm.DB) {
 	userService := service.NewUserService(db, validate)
 	tokenService := service.NewTokenService(db, validate, userService)
 	authService := service.NewAuthService(db, validate, userService, tokenService)
+	saleService := service.NewSaleService(db, validate)
+	refundService := service.NewRefundService(db, validate)
+	salePaymentService := service.NewSalePaymentService(db, validate)
+	settingService := service.NewSettingService(db, validate)
+	taxService := service.NewTaxService(db, validate)
+	reportService := service.NewReportService(db, validate)
+	staffShiftService := service.NewStaffShiftService(db, validate)
+	syncService := service.NewSyncService(db, validate)
+	approvalService := service.NewApprovalService(db, validate)
+	receiptService := service.NewReceiptService(db, validate)
+	printJobService := service.NewPrintJobService(db, validate)
+	kitchenService := service.NewKitchenService(db, validate)
+	tableService := service.NewTableService(db, validate)
+	reservationService := service.NewReservationService(db, validate, emailService)
+	selfOrderService := service.NewSelfOrderService(db, validate)
+	couponService := service.NewCouponService(db, validate)
+	promotionService := service.NewPromotionService(db, validate)
+	customerService := service.NewCustomerService(db, validate)
+	idempotencyService := service.NewIdempotencyService(db, validate)
 
 	v1 := app.Group("/v1")
+	v1.Use(m.Idempotency(idempotencyService))
 
 	HealthCheckRoutes(v1, healthCheckService)
 	AuthRoutes(v1, authService, userService, tokenService, emailService)
 	UserRoutes(v1, userService, tokenService)
+	SaleRoutes(v1, userService, saleService)
+	RefundRoutes(v1, userService, refundService)
+	SalePaymentRoutes(v1, userService, salePaymentService)
+	SettingRoutes(v1, userService, settingService)
+	TaxRoutes(v1, userService, taxService)
+	ReportRoutes(v1, userService, reportService)
+	StaffShiftRoutes(v1, userService, staffShiftService)
+	SyncRoutes(v1, userService, syncService)
+	ApprovalRoutes(v1, userService, approvalService)
+	ReceiptRoutes(v1, userService, receiptService)
+	PrintJobRoutes(v1, userService, printJobService)
+	KitchenRoutes(v1, userService, kitchenService)
+	TableRoutes(v1, userService, tableService)
+	ReservationRoutes(v1, userService, reservationService)
+	SelfOrderRoutes(v1, userService, selfOrderService)
+	CouponRoutes(v1, userService, couponService)
+	PromotionRoutes(v1, userService, promotionService)
+	CustomerRoutes(v1, userService, customerService)
 	// TODO: add another routes here...
 
 	if !config.IsProd {
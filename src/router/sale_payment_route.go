@@ -0,0 +1,18 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SalePaymentRoutes(v1 fiber.Router, u service.UserService, p service.SalePaymentService) {
+	salePaymentController := controller.NewSalePaymentController(p)
+
+	sale := v1.Group("/sales")
+	sale.Get("/:saleId/payments", m.Auth(u, "getSales"), salePaymentController.GetPayments)
+	sale.Post("/:saleId/payments", m.Auth(u, "manageSales"), salePaymentController.CreatePayment)
+	sale.Patch("/:saleId/payments/:paymentId", m.Auth(u, "manageSales"), salePaymentController.UpdatePayment)
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SaleRoutes(v1 fiber.Router, u service.UserService, s service.SaleService) {
+	saleController := controller.NewSaleController(s)
+
+	sale := v1.Group("/sales")
+	sale.Get("/", m.Auth(u, "getSales"), saleController.GetSales)
+	sale.Post("/", m.Auth(u, "manageSales"), saleController.CreateSale)
+	sale.Get("/:saleId", m.Auth(u, "getSales"), saleController.GetSaleByID)
+	sale.Post("/:saleId/items", m.Auth(u, "manageSales"), saleController.AddSaleItems)
+	sale.Delete("/:saleId/items/:itemId", m.Auth(u, "manageSales"), saleController.RemoveSaleItem)
+	sale.Post("/:saleId/hold", m.Auth(u, "manageSales"), saleController.HoldSale)
+	sale.Post("/:saleId/resume", m.Auth(u, "manageSales"), saleController.ResumeSale)
+	sale.Post("/:saleId/transfer", m.Auth(u, "manageSales"), saleController.TransferSale)
+	sale.Post("/:saleId/split", m.Auth(u, "manageSales"), saleController.SplitSale)
+	sale.Post("/:saleId/void", m.Auth(u, "manageSales"), saleController.VoidSale)
+	sale.Post("/:saleId/coupons", m.Auth(u, "manageSales"), saleController.ApplyCoupon)
+	sale.Delete("/:saleId/coupons/:couponId", m.Auth(u, "manageSales"), saleController.RemoveCoupon)
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"app/src/config"
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SelfOrderRoutes(v1 fiber.Router, u service.UserService, s service.SelfOrderService) {
+	selfOrderController := controller.NewSelfOrderController(s)
+
+	// Public, for guests ordering from the QR code on their table
+	browse := m.SelfOrderLimiter(config.SelfOrderRequestLimit, config.SelfOrderRequestWindow)
+	order := m.SelfOrderLimiter(config.SelfOrderOrderLimit, config.SelfOrderOrderWindow)
+
+	selfOrder := v1.Group("/self-order")
+	selfOrder.Get("/:token/menu", browse, selfOrderController.GetMenu)
+	selfOrder.Post("/:token/cart", browse, selfOrderController.PriceCart)
+	selfOrder.Post("/:token/orders", order, selfOrderController.CreateTableOrder)
+	selfOrder.Get("/:token/orders/:orderId", browse, selfOrderController.GetTableOrderStatus)
+
+	table := v1.Group("/tables")
+	table.Get("/:tableId/ordering-link", m.Auth(u, "manageOutlets"), selfOrderController.GetOrderingLink)
+	table.Post("/:tableId/ordering-link/rotate", m.Auth(u, "manageOutlets"), selfOrderController.RotateOrderingLink)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/table-orders", m.Auth(u, "getSales"), selfOrderController.GetTableOrders)
+
+	tableOrder := v1.Group("/table-orders")
+	tableOrder.Post("/:orderId/confirm", m.Auth(u, "manageSales"), selfOrderController.ConfirmTableOrder)
+	tableOrder.Post("/:orderId/reject", m.Auth(u, "manageSales"), selfOrderController.RejectTableOrder)
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SettingRoutes(v1 fiber.Router, u service.UserService, s service.SettingService) {
+	settingController := controller.NewSettingController(s)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/settings", m.Auth(u, "getSales"), settingController.GetSettings)
+	outlet.Put("/:outletId/settings/:key", m.Auth(u, "manageOutlets"), settingController.UpsertSetting)
+	outlet.Delete("/:outletId/settings/:key", m.Auth(u, "manageOutlets"), settingController.DeleteSetting)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func StaffShiftRoutes(v1 fiber.Router, u service.UserService, s service.StaffShiftService) {
+	staffShiftController := controller.NewStaffShiftController(s)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/shifts", m.Auth(u, "getSales"), staffShiftController.GetShifts)
+	outlet.Post("/:outletId/shifts", m.Auth(u, "manageSales"), staffShiftController.ClockIn)
+
+	shift := v1.Group("/shifts")
+	shift.Post("/:shiftId/clock-out", m.Auth(u, "manageSales"), staffShiftController.ClockOut)
+}
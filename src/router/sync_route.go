@@ -0,0 +1,17 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SyncRoutes(v1 fiber.Router, u service.UserService, s service.SyncService) {
+	syncController := controller.NewSyncController(s)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/sync", m.Auth(u, "getSales"), syncController.GetChanges)
+	outlet.Post("/:outletId/sync/sales", m.Auth(u, "manageSales"), syncController.UploadSales)
+}
@@ -0,0 +1,36 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TableRoutes(v1 fiber.Router, u service.UserService, t service.TableService) {
+	tableController := controller.NewTableController(t)
+
+	outlet := v1.Group("/outlets")
+	outlet.Get("/:outletId/floor-areas", m.Auth(u, "getSales"), tableController.GetFloorAreas)
+	outlet.Post("/:outletId/floor-areas", m.Auth(u, "manageOutlets"), tableController.CreateFloorArea)
+	outlet.Get("/:outletId/tables", m.Auth(u, "getSales"), tableController.GetTables)
+	outlet.Post("/:outletId/tables", m.Auth(u, "manageOutlets"), tableController.CreateTable)
+	outlet.Get("/:outletId/table-status", m.Auth(u, "getSales"), tableController.GetTableStatuses)
+	outlet.Get("/:outletId/table-moves", m.Auth(u, "getSales"), tableController.GetTableMoves)
+
+	floorArea := v1.Group("/floor-areas")
+	floorArea.Patch("/:areaId", m.Auth(u, "manageOutlets"), tableController.UpdateFloorArea)
+	floorArea.Delete("/:areaId", m.Auth(u, "manageOutlets"), tableController.DeleteFloorArea)
+
+	table := v1.Group("/tables")
+	table.Get("/:tableId", m.Auth(u, "getSales"), tableController.GetTableByID)
+	table.Patch("/:tableId", m.Auth(u, "manageOutlets"), tableController.UpdateTable)
+	table.Patch("/:tableId/status", m.Auth(u, "manageSales"), tableController.UpdateTableStatus)
+	table.Post("/:tableId/merge", m.Auth(u, "manageSales"), tableController.MergeTables)
+	table.Post("/:tableId/unmerge", m.Auth(u, "manageSales"), tableController.UnmergeTables)
+	table.Delete("/:tableId", m.Auth(u, "manageOutlets"), tableController.DeleteTable)
+
+	sale := v1.Group("/sales")
+	sale.Post("/:saleId/move", m.Auth(u, "manageSales"), tableController.MoveSale)
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"app/src/controller"
+	m "app/src/middleware"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TaxRoutes(v1 fiber.Router, u service.UserService, t service.TaxService) {
+	taxController := controller.NewTaxController(t)
+
+	business := v1.Group("/businesses")
+	business.Get("/:businessId/taxes", m.Auth(u, "getSales"), taxController.GetTaxes)
+	business.Post("/:businessId/taxes", m.Auth(u, "manageOutlets"), taxController.CreateTax)
+
+	tax := v1.Group("/taxes")
+	tax.Get("/:taxId", m.Auth(u, "getSales"), taxController.GetTaxByID)
+	tax.Patch("/:taxId", m.Auth(u, "manageOutlets"), taxController.UpdateTax)
+	tax.Delete("/:taxId", m.Auth(u, "manageOutlets"), taxController.DeleteTax)
+}
@@ -0,0 +1,458 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ApprovalService interface {
+	GetApprovals(c *fiber.Ctx, outletID string, params *validation.QueryApproval) ([]model.Approval, int64, error)
+	GetApprovalByID(c *fiber.Ctx, id string) (*model.Approval, error)
+	CreateApproval(c *fiber.Ctx, outletID string, req *validation.CreateApproval) (*model.Approval, error)
+	DecideApproval(c *fiber.Ctx, id string, user *model.User, approve bool) (*model.Approval, error)
+	UpdateStaffPin(c *fiber.Ctx, outletID, staffID string, req *validation.UpdateStaffPin) error
+}
+
+type approvalService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewApprovalService(db *gorm.DB, validate *validator.Validate) ApprovalService {
+	return &approvalService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *approvalService) GetApprovals(
+	c *fiber.Ctx, outletID string, params *validation.QueryApproval,
+) ([]model.Approval, int64, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, 0, err
+	}
+
+	var approvals []model.Approval
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.Approval{}).
+		Where("outlet_id = ?", outletID).
+		Order("created_at desc")
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count approvals: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Limit(params.Limit).Offset(offset).Find(&approvals).Error; err != nil {
+		s.Log.Errorf("Failed to get approvals: %+v", err)
+		return nil, 0, err
+	}
+
+	return approvals, totalResults, nil
+}
+
+func (s *approvalService) GetApprovalByID(c *fiber.Ctx, id string) (*model.Approval, error) {
+	approval := new(model.Approval)
+
+	db := s.DB.WithContext(c.Context())
+	result := db.First(approval, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Approval not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get approval by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, approval.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (s *approvalService) CreateApproval(
+	c *fiber.Ctx, outletID string, req *validation.CreateApproval,
+) (*model.Approval, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	requestedBy := uuid.MustParse(req.RequestedBy)
+	approval := &model.Approval{
+		OutletID:      uuid.MustParse(outletID),
+		Action:        req.Action,
+		Status:        config.ApprovalStatusPending,
+		Method:        config.ApprovalMethodPush,
+		Amount:        req.Amount,
+		Reason:        req.Reason,
+		RequestedByID: &requestedBy,
+		ExpiresAt:     now.Add(config.ApprovalExpiryMinutes * time.Minute),
+	}
+
+	if user, ok := c.Locals("user").(*model.User); ok {
+		approval.RequestedByUserID = &user.ID
+	}
+
+	if req.SaleID != "" {
+		saleID := uuid.MustParse(req.SaleID)
+		approval.SaleID = &saleID
+	}
+
+	// Checked before the transaction, so wrong PINs are counted even though the approval fails
+	if req.ApproverID != "" {
+		approverID, err := checkManagerPin(s.DB.WithContext(c.Context()), approval.OutletID, req.ApproverID, req.Pin)
+		if err != nil {
+			var fiberErr *fiber.Error
+			if !errors.As(err, &fiberErr) {
+				s.Log.Errorf("Failed to check manager pin: %+v", err)
+			}
+			return nil, err
+		}
+
+		approval.Method = config.ApprovalMethodPin
+		approval.Status = config.ApprovalStatusApproved
+		approval.ApprovedByStaffID = &approverID
+		approval.DecidedAt = &now
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := checkOutletStaff(tx, approval.OutletID, requestedBy); err != nil {
+			return err
+		}
+
+		if approval.SaleID != nil {
+			var sales int64
+			if err := tx.Model(&model.Sale{}).
+				Where("id = ? AND outlet_id = ?", approval.SaleID, approval.OutletID).
+				Count(&sales).Error; err != nil {
+				return err
+			}
+			if sales == 0 {
+				return fiber.NewError(fiber.StatusBadRequest, "Sale does not belong to this outlet")
+			}
+		}
+
+		return tx.Create(approval).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create approval: %+v", err)
+		}
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// DecideApproval answers a pending push approval. Only owners and admins of the outlet's
+// business, or admins, may answer, and never the user who asked for it.
+func (s *approvalService) DecideApproval(
+	c *fiber.Ctx, id string, user *model.User, approve bool,
+) (*model.Approval, error) {
+	approval := new(model.Approval)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(approval, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Approval not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if approval.Status != config.ApprovalStatusPending {
+			return fiber.NewError(fiber.StatusBadRequest, "Approval was already answered")
+		}
+		if approval.ExpiresAt.Before(time.Now()) {
+			return fiber.NewError(fiber.StatusBadRequest, "Approval has expired")
+		}
+
+		err := checkOutletAccess(c, tx, approval.OutletID.String(), config.BusinessRoleOwner, config.BusinessRoleAdmin)
+		if err != nil {
+			return err
+		}
+		if approval.RequestedByUserID != nil && *approval.RequestedByUserID == user.ID {
+			return fiber.NewError(fiber.StatusForbidden, "You can't answer your own approval request")
+		}
+
+		now := time.Now()
+		approval.Status = config.ApprovalStatusRejected
+		if approve {
+			approval.Status = config.ApprovalStatusApproved
+			approval.ExpiresAt = now.Add(config.ApprovalExpiryMinutes * time.Minute)
+		}
+		approval.ApprovedByUserID = &user.ID
+		approval.DecidedAt = &now
+
+		return tx.Model(approval).Updates(map[string]interface{}{
+			"status":              approval.Status,
+			"approved_by_user_id": approval.ApprovedByUserID,
+			"decided_at":          approval.DecidedAt,
+			"expires_at":          approval.ExpiresAt,
+		}).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to answer approval: %+v", err)
+		}
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// UpdateStaffPin sets the PIN of a staff member. Owners and admins of the business may set it
+// right away, other users of the business need the current PIN.
+func (s *approvalService) UpdateStaffPin(
+	c *fiber.Ctx, outletID, staffID string, req *validation.UpdateStaffPin,
+) error {
+	if err := s.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	db := s.DB.WithContext(c.Context())
+	roles := []string{config.BusinessRoleOwner, config.BusinessRoleAdmin}
+	if req.CurrentPin != "" {
+		roles = nil
+	}
+
+	if err := checkOutletAccess(c, db, outletID, roles...); err != nil {
+		return err
+	}
+
+	staff := new(model.OutletStaff)
+	result := db.Where("id = ? AND outlet_id = ?", staffID, outletID).Limit(1).Find(staff)
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get staff: %+v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Staff not found")
+	}
+
+	if req.CurrentPin != "" {
+		valid, err := checkStaffPin(db, staff, req.CurrentPin)
+		if err != nil {
+			var fiberErr *fiber.Error
+			if !errors.As(err, &fiberErr) {
+				s.Log.Errorf("Failed to check staff pin: %+v", err)
+			}
+			return err
+		}
+		if !valid {
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid current PIN")
+		}
+	}
+
+	hashedPin, err := utils.HashPassword(req.Pin)
+	if err != nil {
+		s.Log.Errorf("Failed to hash pin: %+v", err)
+		return err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(staff).Update("pin", hashedPin).Error; err != nil {
+			return err
+		}
+
+		// A new PIN starts without a lockout
+		return tx.Delete(&model.StaffPinAttempt{}, "outlet_staff_id = ?", staff.ID).Error
+	})
+
+	if err != nil {
+		s.Log.Errorf("Failed to update staff pin: %+v", err)
+	}
+
+	return err
+}
+
+// checkManagerPin returns the approver when they manage the outlet and the PIN is theirs.
+func checkManagerPin(db *gorm.DB, outletID uuid.UUID, approverID, pin string) (uuid.UUID, error) {
+	approver := new(model.OutletStaff)
+
+	result := db.Where("id = ? AND outlet_id = ?", approverID, outletID).Limit(1).Find(approver)
+	if result.Error != nil {
+		return uuid.Nil, result.Error
+	}
+
+	invalid := fiber.NewError(fiber.StatusUnauthorized, "Invalid manager PIN")
+	if result.RowsAffected == 0 {
+		return uuid.Nil, invalid
+	}
+
+	valid, err := checkStaffPin(db, approver, pin)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !valid {
+		return uuid.Nil, invalid
+	}
+
+	if approver.Role != config.StaffRoleManager && approver.Role != config.StaffRoleOwner {
+		return uuid.Nil, fiber.NewError(fiber.StatusForbidden, "Only managers can approve")
+	}
+
+	return approver.ID, nil
+}
+
+// checkStaffPin tells whether pin is the PIN of the staff member. Wrong PINs are counted, after
+// config.StaffPinMaxAttempts in a row the staff member is locked out for a while so PINs can't be
+// guessed. db must not be a transaction that is rolled back when the PIN is wrong.
+func checkStaffPin(db *gorm.DB, staff *model.OutletStaff, pin string) (bool, error) {
+	now := time.Now()
+	attempt := new(model.StaffPinAttempt)
+
+	result := db.Where("outlet_staff_id = ?", staff.ID).Limit(1).Find(attempt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+		return false, fiber.NewError(fiber.StatusTooManyRequests, "Too many wrong PINs, try again later")
+	}
+
+	if staff.Pin != nil && utils.CheckPasswordHash(pin, *staff.Pin) {
+		if result.RowsAffected == 0 {
+			return true, nil
+		}
+		return true, db.Delete(attempt, "outlet_staff_id = ?", staff.ID).Error
+	}
+
+	// The count starts over once the lockout is set
+	lockedUntil := now.Add(config.StaffPinLockoutMinutes * time.Minute)
+	return false, db.Exec(`INSERT INTO staff_pin_attempts (outlet_staff_id, failed_count, updated_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (outlet_staff_id) DO UPDATE SET
+			failed_count = CASE WHEN staff_pin_attempts.failed_count + 1 >= ? THEN 0
+				ELSE staff_pin_attempts.failed_count + 1 END,
+			locked_until = CASE WHEN staff_pin_attempts.failed_count + 1 >= ? THEN ?
+				ELSE staff_pin_attempts.locked_until END,
+			updated_at = EXCLUDED.updated_at`,
+		staff.ID, now, config.StaffPinMaxAttempts, config.StaffPinMaxAttempts, lockedUntil).Error
+}
+
+var approvalActions = map[string]string{
+	config.ApprovalActionVoidSale:      "void a paid sale",
+	config.ApprovalActionDiscount:      "give this discount",
+	config.ApprovalActionPriceOverride: "override prices",
+	config.ApprovalActionVoidPayment:   "void a paid payment",
+}
+
+// useApproval consumes one of the given approvals for an action on a sale. The approval must
+// be granted for the outlet and the action, cover the amount and not be used or expired. When
+// none of them can be used the error tells why the oldest one can't.
+func useApproval(tx *gorm.DB, approvalIDs []string, sale *model.Sale, action string, amount money.Amount) error {
+	required := fiber.NewError(fiber.StatusForbidden,
+		fmt.Sprintf("Manager approval is required to %s", approvalActions[action]))
+	if len(approvalIDs) == 0 {
+		return required
+	}
+
+	now := time.Now()
+	granted := "id IN ? AND outlet_id = ? AND action = ? AND status = ?"
+	grantedArgs := []interface{}{approvalIDs, sale.OutletID, action, config.ApprovalStatusApproved}
+
+	approval := new(model.Approval)
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(granted, grantedArgs...).
+		Where("expires_at > ? AND (sale_id IS NULL OR sale_id = ?) AND amount >= ?", now, sale.ID, amount).
+		Order("created_at asc").
+		Limit(1).
+		Find(approval)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		result = tx.Where(granted, grantedArgs...).Order("created_at asc").Limit(1).Find(approval)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		switch {
+		case result.RowsAffected == 0:
+			return required
+		case approval.ExpiresAt.Before(now):
+			return fiber.NewError(fiber.StatusForbidden, "Manager approval has expired")
+		case approval.SaleID != nil && *approval.SaleID != sale.ID:
+			return fiber.NewError(fiber.StatusForbidden, "Manager approval was given for another sale")
+		default:
+			return fiber.NewError(fiber.StatusForbidden,
+				fmt.Sprintf("Manager approval covers %s but %s needs approval", approval.Amount, amount))
+		}
+	}
+
+	return tx.Model(approval).Updates(map[string]interface{}{
+		"status":  config.ApprovalStatusUsed,
+		"sale_id": sale.ID,
+		"used_at": now,
+	}).Error
+}
+
+// authorizeSaleItems asks for approvals when new items are discounted beyond the outlet's
+// threshold or sold at an overridden price.
+func authorizeSaleItems(
+	tx *gorm.DB, sale *model.Sale, items []model.SaleItem, overridden money.Amount, approvalIDs []string,
+) error {
+	percent, err := outletSettingInt(tx, sale.OutletID, config.SettingApprovalDiscountPercent, -1)
+	if err != nil {
+		return err
+	}
+
+	var discount money.Amount
+	for _, item := range items {
+		gross := item.Price.Mul(item.Quantity)
+		if percent >= 0 && item.Discount > 0 && item.Discount*100 > gross*money.Amount(percent) {
+			discount += item.Discount
+		}
+	}
+
+	if discount > 0 {
+		if err = useApproval(tx, approvalIDs, sale, config.ApprovalActionDiscount, discount); err != nil {
+			return err
+		}
+	}
+
+	if overridden > 0 {
+		return useApproval(tx, approvalIDs, sale, config.ApprovalActionPriceOverride, overridden)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"app/src/config"
 	"app/src/model"
 	"app/src/validation"
 	"errors"
@@ -91,17 +92,33 @@ func (s *businessService) CreateBusiness(c *fiber.Ctx, req *validation.CreateBus
 		Logo:    &req.Logo,
 	}
 
-	result := s.DB.WithContext(c.Context()).Create(business)
+	// The user creating the business owns it, see checkBusinessAccess.
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(business).Error; err != nil {
+			return err
+		}
 
-	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		user, _ := c.Locals("user").(*model.User)
+		if user == nil {
+			return nil
+		}
+
+		return tx.Create(&model.BusinessUser{
+			BusinessID: business.ID,
+			UserID:     user.ID,
+			Role:       config.BusinessRoleOwner,
+		}).Error
+	})
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
 		return nil, fiber.NewError(fiber.StatusConflict, "Business with this domain already exists")
 	}
 
-	if result.Error != nil {
-		s.Log.Errorf("Failed to create business: %+v", result.Error)
+	if err != nil {
+		s.Log.Errorf("Failed to create business: %+v", err)
 	}
 
-	return business, result.Error
+	return business, err
 }
 
 func (s *businessService) UpdateBusiness(c *fiber.Ctx, id string, req *validation.UpdateBusiness) (*model.Business, error) {
@@ -159,3 +176,56 @@ func (s *businessService) DeleteBusiness(c *fiber.Ctx, id string) error {
 
 	return nil
 }
+
+// checkBusinessAccess makes sure the signed in user works for the business, in one of roles when
+// any are given. Admins can access every business.
+func checkBusinessAccess(c *fiber.Ctx, db *gorm.DB, businessID string, roles ...string) error {
+	return checkMembership(c, db, businessID, roles)
+}
+
+// checkOutletAccess is checkBusinessAccess for the business the outlet belongs to.
+func checkOutletAccess(c *fiber.Ctx, db *gorm.DB, outletID string, roles ...string) error {
+	return checkMembership(c, db, db.Model(&model.Outlet{}).Select("business_id").Where("id = ?", outletID), roles)
+}
+
+func checkMembership(c *fiber.Ctx, db *gorm.DB, businessID interface{}, roles []string) error {
+	user, _ := c.Locals("user").(*model.User)
+	if user == nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "Please authenticate")
+	}
+	if user.Role == "admin" {
+		return nil
+	}
+
+	query := db.WithContext(c.Context()).Model(&model.BusinessUser{}).
+		Where("user_id = ? AND business_id = (?)", user.ID, businessID)
+	if len(roles) > 0 {
+		query = query.Where("role IN ?", roles)
+	}
+
+	var members int64
+	if err := query.Count(&members).Error; err != nil {
+		return err
+	}
+	if members == 0 {
+		return fiber.NewError(fiber.StatusForbidden, "You don't have permission to access this business")
+	}
+
+	return nil
+}
+
+// memberOutlets limits query to rows of outlets whose business the signed in user works for,
+// admins see every outlet.
+func memberOutlets(c *fiber.Ctx, db *gorm.DB, query *gorm.DB) (*gorm.DB, error) {
+	user, _ := c.Locals("user").(*model.User)
+	if user == nil {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Please authenticate")
+	}
+	if user.Role == "admin" {
+		return query, nil
+	}
+
+	return query.Where("outlet_id IN (?)", db.Model(&model.Outlet{}).Select("id").
+		Where("business_id IN (?)", db.Model(&model.BusinessUser{}).Select("business_id").
+			Where("user_id = ?", user.ID))), nil
+}
@@ -0,0 +1,799 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CouponService interface {
+	GetCoupons(c *fiber.Ctx, businessID string, params *validation.QueryCoupon) ([]model.Coupon, int64, error)
+	GetCouponByID(c *fiber.Ctx, id string) (*model.Coupon, error)
+	GetCouponUsage(c *fiber.Ctx, id string) ([]response.CouponUsage, error)
+	CreateCoupon(c *fiber.Ctx, businessID string, req *validation.CreateCoupon) (*model.Coupon, error)
+	GenerateCoupons(c *fiber.Ctx, businessID string, req *validation.GenerateCoupons) ([]model.Coupon, error)
+	UpdateCoupon(c *fiber.Ctx, id string, req *validation.UpdateCoupon) (*model.Coupon, error)
+	ExportCoupons(c *fiber.Ctx, businessID, campaign string) ([]model.Coupon, error)
+	ValidateCoupon(c *fiber.Ctx, req *validation.ValidateCoupon) (*response.CouponValidation, error)
+}
+
+// couponCodeLength is the length of the random part of generated codes.
+const couponCodeLength = 8
+
+type couponService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewCouponService(db *gorm.DB, validate *validator.Validate) CouponService {
+	return &couponService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *couponService) GetCoupons(
+	c *fiber.Ctx, businessID string, params *validation.QueryCoupon,
+) ([]model.Coupon, int64, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, 0, err
+	}
+
+	var coupons []model.Coupon
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.Coupon{}).
+		Where("business_id = ?", businessID).
+		Order("created_at desc")
+
+	if params.OutletID != "" {
+		query = query.Where("outlet_ids IS NULL OR outlet_ids = '[]' OR outlet_ids @> ?",
+			fmt.Sprintf("[%q]", params.OutletID))
+	}
+
+	if params.Campaign != "" {
+		query = query.Where("campaign = ?", params.Campaign)
+	}
+
+	if params.Search != "" {
+		query = query.Where("UPPER(code) LIKE ?", "%"+strings.ToUpper(params.Search)+"%")
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count coupons: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Limit(params.Limit).Offset(offset).Find(&coupons).Error; err != nil {
+		s.Log.Errorf("Failed to get coupons: %+v", err)
+		return nil, 0, err
+	}
+
+	return coupons, totalResults, nil
+}
+
+func (s *couponService) GetCouponByID(c *fiber.Ctx, id string) (*model.Coupon, error) {
+	coupon := new(model.Coupon)
+
+	result := s.DB.WithContext(c.Context()).First(coupon, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Coupon not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get coupon by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkBusinessAccess(c, s.DB, coupon.BusinessID.String()); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// GetCouponUsage breaks the redemptions of a coupon down by outlet, busiest outlet first. Coupons
+// of voided and refunded sales were given back and are left out.
+func (s *couponService) GetCouponUsage(c *fiber.Ctx, id string) ([]response.CouponUsage, error) {
+	if _, err := s.GetCouponByID(c, id); err != nil {
+		return nil, err
+	}
+
+	usage := []response.CouponUsage{}
+
+	if err := s.DB.WithContext(c.Context()).Model(&model.SaleCoupon{}).
+		Select("outlets.id AS outlet_id, outlets.name AS outlet_name, COUNT(*) AS uses, "+
+			"COALESCE(SUM(sales_coupons.discount), 0) AS discount").
+		Joins("JOIN sales ON sales.id = sales_coupons.sale_id").
+		Joins("JOIN outlets ON outlets.id = sales.outlet_id").
+		Where("sales_coupons.coupon_id = ? AND sales.status NOT IN ?", id,
+			[]string{config.SaleStatusVoid, config.SaleStatusRefunded}).
+		Group("outlets.id, outlets.name").
+		Order("uses desc, outlets.name asc").
+		Scan(&usage).Error; err != nil {
+		s.Log.Errorf("Failed to get coupon usage: %+v", err)
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (s *couponService) CreateCoupon(
+	c *fiber.Ctx, businessID string, req *validation.CreateCoupon,
+) (*model.Coupon, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	coupon := newCoupon(businessID, &req.CouponOffer)
+	coupon.Code = req.Code
+	coupon.MaxUses = req.MaxUses
+
+	if err := checkCouponOffer(coupon); err != nil {
+		return nil, err
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := checkBusiness(tx, coupon.BusinessID); err != nil {
+			return err
+		}
+
+		if err := checkCouponTargets(tx, coupon); err != nil {
+			return err
+		}
+
+		var taken int64
+		if err := tx.Model(&model.Coupon{}).
+			Where("business_id = ? AND UPPER(code) = ?", coupon.BusinessID, strings.ToUpper(coupon.Code)).
+			Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return fiber.NewError(fiber.StatusConflict, "Coupon code already exists")
+		}
+
+		return tx.Create(coupon).Error
+	})
+
+	// Another request can take the code between the check and the insert
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Coupon code already exists")
+	}
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create coupon: %+v", err)
+		}
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// GenerateCoupons creates unique single use codes for a campaign, all with the same offer.
+func (s *couponService) GenerateCoupons(
+	c *fiber.Ctx, businessID string, req *validation.GenerateCoupons,
+) ([]model.Coupon, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	template := newCoupon(businessID, &req.CouponOffer)
+	template.MaxUses = 1
+	template.Campaign = &req.Campaign
+
+	if err := checkCouponOffer(template); err != nil {
+		return nil, err
+	}
+
+	var coupons []model.Coupon
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := checkBusiness(tx, template.BusinessID); err != nil {
+			return err
+		}
+
+		if err := checkCouponTargets(tx, template); err != nil {
+			return err
+		}
+
+		codes, err := uniqueCouponCodes(tx, template.BusinessID, strings.ToUpper(req.Prefix), req.Count)
+		if err != nil {
+			return err
+		}
+
+		coupons = make([]model.Coupon, len(codes))
+		for i, code := range codes {
+			coupons[i] = *template
+			coupons[i].Code = code
+		}
+
+		return tx.CreateInBatches(&coupons, 500).Error
+	})
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Coupon code already exists")
+	}
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to generate coupons: %+v", err)
+		}
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+func (s *couponService) UpdateCoupon(c *fiber.Ctx, id string, req *validation.UpdateCoupon) (*model.Coupon, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	coupon := new(model.Coupon)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(coupon, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Coupon not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkBusinessAccess(c, tx, coupon.BusinessID.String()); err != nil {
+			return err
+		}
+
+		applyCouponChanges(coupon, req)
+
+		if err := checkCouponOffer(coupon); err != nil {
+			return err
+		}
+
+		if req.OutletIDs != nil || req.CustomerIDs != nil {
+			if err := checkCouponTargets(tx, coupon); err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(coupon).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update coupon: %+v", err)
+		}
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// ExportCoupons lists the codes of a business, of one campaign when campaign is set, oldest first.
+func (s *couponService) ExportCoupons(c *fiber.Ctx, businessID, campaign string) ([]model.Coupon, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	var coupons []model.Coupon
+
+	query := s.DB.WithContext(c.Context()).Where("business_id = ?", businessID).Order("created_at asc, code asc")
+	if campaign != "" {
+		query = query.Where("campaign = ?", campaign)
+	}
+
+	if err := query.Find(&coupons).Error; err != nil {
+		s.Log.Errorf("Failed to export coupons: %+v", err)
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+// ValidateCoupon tells whether a code can be applied and what it would take off, without redeeming
+// it. A rejected code is a valid answer, not an error.
+func (s *couponService) ValidateCoupon(
+	c *fiber.Ctx, req *validation.ValidateCoupon,
+) (*response.CouponValidation, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if err := checkOutletAccess(c, s.DB, req.OutletID); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+	outletID := uuid.MustParse(req.OutletID)
+	subtotal, remaining := req.Total, req.Total
+	saleID := uuid.Nil
+	var customerID *uuid.UUID
+
+	if req.CustomerID != "" {
+		customer, err := findOutletCustomer(db, outletID, req.CustomerID)
+		if err != nil {
+			var fiberErr *fiber.Error
+			if !errors.As(err, &fiberErr) {
+				s.Log.Errorf("Failed to get customer: %+v", err)
+			}
+			return nil, err
+		}
+		customerID = &customer.ID
+	}
+
+	if req.SaleID != "" {
+		sale := new(model.Sale)
+		result := db.First(sale, "id = ? AND outlet_id = ?", req.SaleID, req.OutletID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+		if result.Error != nil {
+			s.Log.Errorf("Failed to get sale: %+v", result.Error)
+			return nil, result.Error
+		}
+		if sale.Status != config.SaleStatusUnpaid && sale.Status != config.SaleStatusHold {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Only open sales can take coupons")
+		}
+
+		saleID, customerID = sale.ID, sale.CustomerID
+		subtotal, remaining = sale.Total, sale.Total-sale.Discount
+	}
+
+	coupon, err := findCoupon(db, outletID, req.Code)
+	if err == nil {
+		err = checkCoupon(db, coupon, outletID, saleID, customerID)
+	}
+
+	var rejection *utils.CouponRejection
+	if errors.As(err, &rejection) {
+		return &response.CouponValidation{Reason: rejection.Reason, Message: rejection.Message, Coupon: coupon}, nil
+	}
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to validate coupon: %+v", err)
+		}
+		return nil, err
+	}
+
+	return &response.CouponValidation{
+		Valid:    true,
+		Coupon:   coupon,
+		Discount: couponDiscount(coupon, subtotal, remaining),
+	}, nil
+}
+
+func newCoupon(businessID string, offer *validation.CouponOffer) *model.Coupon {
+	return &model.Coupon{
+		BusinessID:         uuid.MustParse(businessID),
+		OutletIDs:          parseUUIDs(offer.OutletIDs),
+		Description:        emptyToNil(offer.Description),
+		DiscountType:       offer.DiscountType,
+		DiscountValue:      offer.DiscountValue,
+		MaxUsesPerCustomer: offer.MaxUsesPerCustomer,
+		FirstOrderOnly:     offer.FirstOrderOnly,
+		CustomerIDs:        parseUUIDs(offer.CustomerIDs),
+		CustomerTiers:      offer.CustomerTiers,
+		StartDate:          offer.StartDate,
+		EndDate:            offer.EndDate,
+		IsActive:           true,
+	}
+}
+
+func applyCouponChanges(coupon *model.Coupon, req *validation.UpdateCoupon) {
+	if req.Description != nil {
+		coupon.Description = emptyToNil(*req.Description)
+	}
+	if req.DiscountValue != nil {
+		coupon.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxUses != nil {
+		coupon.MaxUses = *req.MaxUses
+	}
+	if req.MaxUsesPerCustomer != nil {
+		coupon.MaxUsesPerCustomer = *req.MaxUsesPerCustomer
+	}
+	if req.FirstOrderOnly != nil {
+		coupon.FirstOrderOnly = *req.FirstOrderOnly
+	}
+	if req.OutletIDs != nil {
+		coupon.OutletIDs = parseUUIDs(*req.OutletIDs)
+	}
+	if req.CustomerIDs != nil {
+		coupon.CustomerIDs = parseUUIDs(*req.CustomerIDs)
+	}
+	if req.CustomerTiers != nil {
+		coupon.CustomerTiers = *req.CustomerTiers
+	}
+	if req.StartDate != nil {
+		coupon.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		coupon.EndDate = *req.EndDate
+	}
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+}
+
+func checkCouponOffer(coupon *model.Coupon) error {
+	if coupon.DiscountType == config.CouponDiscountPercentage && coupon.DiscountValue > money.FromUnits(100) {
+		return fiber.NewError(fiber.StatusBadRequest, "A percentage discount cannot be more than 100")
+	}
+
+	if !coupon.EndDate.After(coupon.StartDate) {
+		return fiber.NewError(fiber.StatusBadRequest, "End date must be after the start date")
+	}
+
+	return nil
+}
+
+func checkBusiness(tx *gorm.DB, businessID uuid.UUID) error {
+	var businesses int64
+	if err := tx.Model(&model.Business{}).Where("id = ?", businessID).Count(&businesses).Error; err != nil {
+		return err
+	}
+	if businesses == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Business not found")
+	}
+
+	return nil
+}
+
+// checkCouponTargets makes sure the outlets and customers a coupon is for belong to its business.
+func checkCouponTargets(tx *gorm.DB, coupon *model.Coupon) error {
+	if len(coupon.OutletIDs) > 0 {
+		var outlets int64
+		if err := tx.Model(&model.Outlet{}).
+			Where("id IN ? AND business_id = ?", coupon.OutletIDs, coupon.BusinessID).
+			Count(&outlets).Error; err != nil {
+			return err
+		}
+		if int(outlets) != len(coupon.OutletIDs) {
+			return fiber.NewError(fiber.StatusBadRequest, "Coupon outlets must belong to the business")
+		}
+	}
+
+	if len(coupon.CustomerIDs) > 0 {
+		var customers int64
+		if err := tx.Model(&model.Customer{}).
+			Where("id IN ? AND business_id = ?", coupon.CustomerIDs, coupon.BusinessID).
+			Count(&customers).Error; err != nil {
+			return err
+		}
+		if int(customers) != len(coupon.CustomerIDs) {
+			return fiber.NewError(fiber.StatusBadRequest, "Coupon customers must be customers of the business")
+		}
+	}
+
+	return nil
+}
+
+// uniqueCouponCodes generates count codes no other coupon of the business has. Codes that are taken
+// are replaced and the generation gives up when the prefix leaves too few free codes.
+func uniqueCouponCodes(tx *gorm.DB, businessID uuid.UUID, prefix string, count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	seen := make(map[string]bool, count)
+
+	for range 5 {
+		batch := make([]string, 0, count-len(codes))
+		for len(batch) < cap(batch) {
+			code, err := utils.GenerateCouponCode(prefix, couponCodeLength)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[code] {
+				seen[code] = true
+				batch = append(batch, code)
+			}
+		}
+
+		var taken []string
+		if err := tx.Model(&model.Coupon{}).
+			Where("business_id = ? AND UPPER(code) IN ?", businessID, batch).
+			Pluck("code", &taken).Error; err != nil {
+			return nil, err
+		}
+
+		for _, code := range batch {
+			if !slices.ContainsFunc(taken, func(other string) bool { return strings.EqualFold(other, code) }) {
+				codes = append(codes, code)
+			}
+		}
+
+		if len(codes) == count {
+			return codes, nil
+		}
+	}
+
+	return nil, fiber.NewError(fiber.StatusConflict, "Could not generate enough unique codes, use another prefix")
+}
+
+// findCoupon looks a code up among the coupons of the business the outlet belongs to, codes are
+// matched case insensitively. Whether the coupon can be used at the outlet is up to checkCoupon.
+func findCoupon(tx *gorm.DB, outletID uuid.UUID, code string) (*model.Coupon, error) {
+	coupon := new(model.Coupon)
+
+	result := tx.Where("business_id = (?) AND UPPER(code) = UPPER(?)",
+		tx.Model(&model.Outlet{}).Select("business_id").Where("id = ?", outletID), code).
+		First(coupon)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrCouponNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return coupon, nil
+}
+
+// checkCoupon returns the rejection of a coupon that cannot be applied now at the outlet, to the
+// sale when saleID is set and for the customer when customerID is set.
+func checkCoupon(tx *gorm.DB, coupon *model.Coupon, outletID, saleID uuid.UUID, customerID *uuid.UUID) error {
+	if err := utils.CheckCouponOutlet(coupon.OutletIDs, outletID); err != nil {
+		return err
+	}
+
+	if saleID != uuid.Nil {
+		var applied int64
+		if err := tx.Model(&model.SaleCoupon{}).
+			Where("sale_id = ? AND coupon_id = ?", saleID, coupon.ID).
+			Count(&applied).Error; err != nil {
+			return err
+		}
+		if applied > 0 {
+			return utils.ErrCouponApplied
+		}
+	}
+
+	if err := utils.CheckCoupon(utils.CouponTerms{
+		IsActive:  coupon.IsActive,
+		StartDate: coupon.StartDate,
+		EndDate:   coupon.EndDate,
+		MaxUses:   coupon.MaxUses,
+		UsedCount: coupon.UsedCount,
+	}, time.Now()); err != nil {
+		return err
+	}
+
+	audience := couponAudience(coupon)
+	if !audience.NeedsCustomer() {
+		return nil
+	}
+
+	var customer *utils.CouponCustomer
+	if customerID != nil {
+		var err error
+		if customer, err = couponCustomer(tx, coupon.ID, saleID, *customerID); err != nil {
+			return err
+		}
+	}
+
+	return utils.CheckCouponCustomer(audience, customer)
+}
+
+func couponAudience(coupon *model.Coupon) utils.CouponAudience {
+	return utils.CouponAudience{
+		CustomerIDs:        coupon.CustomerIDs,
+		CustomerTiers:      coupon.CustomerTiers,
+		FirstOrderOnly:     coupon.FirstOrderOnly,
+		MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+	}
+}
+
+// couponCustomer counts the sales the customer redeemed the coupon on and their sales besides
+// saleID. Voided and refunded sales gave their coupons back, merged sales live on in another sale.
+func couponCustomer(tx *gorm.DB, couponID, saleID, customerID uuid.UUID) (*utils.CouponCustomer, error) {
+	customer := new(model.Customer)
+
+	result := tx.Select("id", "tier").First(customer, "id = ?", customerID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Customer not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var uses int64
+	if err := tx.Model(&model.SaleCoupon{}).
+		Joins("JOIN sales ON sales.id = sales_coupons.sale_id").
+		Where("sales_coupons.coupon_id = ? AND sales.customer_id = ? AND sales.status NOT IN ?", couponID, customerID,
+			[]string{config.SaleStatusVoid, config.SaleStatusRefunded}).
+		Count(&uses).Error; err != nil {
+		return nil, err
+	}
+
+	var orders int64
+	if err := tx.Model(&model.Sale{}).
+		Where("customer_id = ? AND id <> ? AND status NOT IN ?", customerID, saleID,
+			[]string{config.SaleStatusVoid, config.SaleStatusMerged}).
+		Count(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	couponCustomer := &utils.CouponCustomer{ID: customer.ID, Uses: int(uses), Orders: int(orders)}
+	if customer.Tier != nil {
+		couponCustomer.Tier = *customer.Tier
+	}
+
+	return couponCustomer, nil
+}
+
+// couponError turns a coupon rejection into the matching client error.
+func couponError(err error) error {
+	var rejection *utils.CouponRejection
+	if !errors.As(err, &rejection) {
+		return err
+	}
+
+	switch rejection {
+	case utils.ErrCouponNotFound:
+		return fiber.NewError(fiber.StatusNotFound, rejection.Message)
+	case utils.ErrCouponUsedUp, utils.ErrCouponApplied, utils.ErrCouponCustomerUsedUp:
+		return fiber.NewError(fiber.StatusConflict, rejection.Message)
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, rejection.Message)
+	}
+}
+
+// redeemCoupon applies a code to an open sale and takes one of its uses. The use is taken with a
+// conditional update so concurrent checkouts cannot redeem more than MaxUses, and checkouts of the
+// same customer wait for each other so the per customer limit holds as well.
+func redeemCoupon(tx *gorm.DB, sale *model.Sale, code string) error {
+	coupon, err := findCoupon(tx, sale.OutletID, code)
+	if err != nil {
+		return couponError(err)
+	}
+
+	if sale.CustomerID != nil && couponAudience(coupon).NeedsCustomer() {
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			First(&model.Customer{}, "id = ?", sale.CustomerID).Error; err != nil {
+			return err
+		}
+	}
+
+	if err = checkCoupon(tx, coupon, sale.OutletID, sale.ID, sale.CustomerID); err != nil {
+		return couponError(err)
+	}
+
+	result := tx.Model(&model.Coupon{}).
+		Where("id = ? AND (max_uses = 0 OR used_count < max_uses)", coupon.ID).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return couponError(utils.ErrCouponUsedUp)
+	}
+
+	return tx.Create(&model.SaleCoupon{SaleID: sale.ID, CouponID: coupon.ID}).Error
+}
+
+// giveBackCoupons returns one use to each of the coupons.
+func giveBackCoupons(tx *gorm.DB, couponIDs []uuid.UUID) error {
+	if len(couponIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&model.Coupon{}).
+		Where("id IN ?", couponIDs).
+		Update("used_count", gorm.Expr("GREATEST(used_count - 1, 0)")).Error
+}
+
+// releaseSaleCoupons gives back the uses the coupons of voided or refunded sales took. The coupons
+// stay on the sales as a record of what was redeemed.
+func releaseSaleCoupons(tx *gorm.DB, saleIDs []uuid.UUID) error {
+	if len(saleIDs) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE coupons SET used_count = GREATEST(coupons.used_count - uses.count, 0)
+		FROM (SELECT coupon_id, COUNT(*) AS count FROM sales_coupons WHERE sale_id IN ? GROUP BY coupon_id) uses
+		WHERE coupons.id = uses.coupon_id`, saleIDs).Error
+}
+
+// moveSaleCoupons moves the coupons of a sale merged into target. A coupon both sales redeemed is
+// kept once and the other use is given back.
+func moveSaleCoupons(tx *gorm.DB, source, target *model.Sale) error {
+	var duplicates []uuid.UUID
+	if err := tx.Model(&model.SaleCoupon{}).
+		Where("sale_id = ? AND coupon_id IN (?)", source.ID,
+			tx.Model(&model.SaleCoupon{}).Select("coupon_id").Where("sale_id = ?", target.ID)).
+		Pluck("coupon_id", &duplicates).Error; err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		if err := tx.Where("sale_id = ? AND coupon_id IN ?", source.ID, duplicates).
+			Delete(&model.SaleCoupon{}).Error; err != nil {
+			return err
+		}
+
+		if err := giveBackCoupons(tx, duplicates); err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&model.SaleCoupon{}).Where("sale_id = ?", source.ID).Update("sale_id", target.ID).Error
+}
+
+// applySaleCoupons adds what the coupons of the sale take off its items to its discount. Coupons
+// are applied in the order they were redeemed and never take off more than is left of the items total.
+func applySaleCoupons(tx *gorm.DB, sale *model.Sale) error {
+	if sale.ID == uuid.Nil {
+		return nil
+	}
+
+	var saleCoupons []model.SaleCoupon
+	if err := tx.Preload("Coupon").Where("sale_id = ?", sale.ID).
+		Order("created_at asc").Find(&saleCoupons).Error; err != nil {
+		return err
+	}
+
+	var subtotal money.Amount
+	for _, item := range sale.SaleItems {
+		subtotal += item.Total
+	}
+
+	for i := range saleCoupons {
+		saleCoupon := &saleCoupons[i]
+		discount := couponDiscount(saleCoupon.Coupon, subtotal, subtotal-sale.Discount)
+		sale.Discount += discount
+
+		if discount == saleCoupon.Discount {
+			continue
+		}
+
+		saleCoupon.Discount = discount
+		if err := tx.Model(saleCoupon).Update("discount", discount).Error; err != nil {
+			return err
+		}
+	}
+
+	sale.SaleCoupons = saleCoupons
+	return nil
+}
+
+// couponDiscount is what the coupon takes off subtotal, limited to what is left of it.
+func couponDiscount(coupon *model.Coupon, subtotal, remaining money.Amount) money.Amount {
+	discount := utils.CouponDiscount(
+		coupon.DiscountType == config.CouponDiscountPercentage, coupon.DiscountValue, subtotal, config.Currency,
+	)
+
+	return max(min(discount, remaining), 0)
+}
@@ -0,0 +1,510 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerService interface {
+	GetCustomers(c *fiber.Ctx, businessID string, params *validation.QueryCustomer) ([]model.Customer, int64, error)
+	GetCustomerByID(c *fiber.Ctx, id string) (*model.Customer, error)
+	GetCustomerVisits(
+		c *fiber.Ctx, id string, params *validation.QueryCustomerVisit,
+	) ([]response.CustomerVisit, int64, error)
+	GetCustomerDuplicates(c *fiber.Ctx, businessID string) ([]response.CustomerDuplicates, error)
+	CreateCustomer(c *fiber.Ctx, businessID string, req *validation.CreateCustomer) (*model.Customer, error)
+	UpdateCustomer(c *fiber.Ctx, id string, req *validation.UpdateCustomer) (*model.Customer, error)
+	MergeCustomers(c *fiber.Ctx, id string, req *validation.MergeCustomers) (*model.Customer, error)
+	DeleteCustomer(c *fiber.Ctx, id string) error
+}
+
+// Phones and names are compared the way utils.PhoneDigits and utils.CustomerNameKey do.
+const (
+	customerPhoneDigits = `regexp_replace(phone, '[^0-9]', '', 'g')`
+	customerNameKey     = `LOWER(regexp_replace(TRIM(name), '\s+', ' ', 'g'))`
+)
+
+type customerService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewCustomerService(db *gorm.DB, validate *validator.Validate) CustomerService {
+	return &customerService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+// GetCustomers lists the customers of a business. The search matches part of the name or email,
+// or the digits of the phone number.
+func (s *customerService) GetCustomers(
+	c *fiber.Ctx, businessID string, params *validation.QueryCustomer,
+) ([]model.Customer, int64, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, 0, err
+	}
+
+	var customers []model.Customer
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.Customer{}).
+		Where("business_id = ?", businessID).
+		Order("name asc, created_at asc")
+
+	if params.Tier != "" {
+		query = query.Where("tier = ?", params.Tier)
+	}
+
+	if search := strings.TrimSpace(params.Search); search != "" {
+		pattern := "%" + search + "%"
+		condition := s.DB.Where("name ILIKE ? OR email ILIKE ?", pattern, pattern)
+		if digits := utils.PhoneDigits(search); digits != "" {
+			condition = condition.Or(customerPhoneDigits+" LIKE ?", "%"+digits+"%")
+		}
+		query = query.Where(condition)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count customers: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Limit(params.Limit).Offset(offset).Find(&customers).Error; err != nil {
+		s.Log.Errorf("Failed to get customers: %+v", err)
+		return nil, 0, err
+	}
+
+	return customers, totalResults, nil
+}
+
+func (s *customerService) GetCustomerByID(c *fiber.Ctx, id string) (*model.Customer, error) {
+	customer := new(model.Customer)
+
+	result := s.DB.WithContext(c.Context()).First(customer, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Customer not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get customer by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkBusinessAccess(c, s.DB, customer.BusinessID.String()); err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// GetCustomerVisits lists the sales of a customer at every outlet of the business, latest first.
+// Voided sales and sales merged into another are left out.
+func (s *customerService) GetCustomerVisits(
+	c *fiber.Ctx, id string, params *validation.QueryCustomerVisit,
+) ([]response.CustomerVisit, int64, error) {
+	visits := []response.CustomerVisit{}
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := s.GetCustomerByID(c, id); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.Sale{}).
+		Joins("JOIN outlets ON outlets.id = sales.outlet_id").
+		Where("sales.customer_id = ? AND sales.status NOT IN ?", id,
+			[]string{config.SaleStatusVoid, config.SaleStatusMerged})
+
+	if params.OutletID != "" {
+		query = query.Where("sales.outlet_id = ?", params.OutletID)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count customer visits: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.
+		Select("sales.id AS sale_id, sales.invoice_number, sales.outlet_id, outlets.name AS outlet_name, " +
+			"sales.status, sales.grand_total, sales.sale_date").
+		Order("sales.sale_date desc").
+		Limit(params.Limit).Offset(offset).
+		Scan(&visits).Error; err != nil {
+		s.Log.Errorf("Failed to get customer visits: %+v", err)
+		return nil, 0, err
+	}
+
+	return visits, totalResults, nil
+}
+
+// GetCustomerDuplicates finds customers of a business that are likely the same person: customers
+// with the same phone number and customers with the same name. Emails are unique already.
+func (s *customerService) GetCustomerDuplicates(
+	c *fiber.Ctx, businessID string,
+) ([]response.CustomerDuplicates, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+	duplicates := []response.CustomerDuplicates{}
+
+	matches := []struct {
+		match string
+		expr  string
+		key   func(customer *model.Customer) string
+	}{
+		{"phone", customerPhoneDigits, func(customer *model.Customer) string {
+			if customer.Phone == nil {
+				return ""
+			}
+			return utils.PhoneDigits(*customer.Phone)
+		}},
+		{"name", customerNameKey, func(customer *model.Customer) string {
+			return utils.CustomerNameKey(customer.Name)
+		}},
+	}
+
+	for _, match := range matches {
+		var customers []model.Customer
+		if err := db.
+			Where("business_id = ? AND "+match.expr+" IN (?)", businessID,
+				db.Model(&model.Customer{}).Select(match.expr).
+					Where("business_id = ? AND "+match.expr+" <> ''", businessID).
+					Group(match.expr).Having("COUNT(*) > 1")).
+			Order("created_at asc").
+			Find(&customers).Error; err != nil {
+			s.Log.Errorf("Failed to get customer duplicates: %+v", err)
+			return nil, err
+		}
+
+		keys := make([]string, len(customers))
+		for i := range customers {
+			keys[i] = match.key(&customers[i])
+		}
+
+		for _, group := range utils.DuplicateGroups(keys) {
+			duplicate := response.CustomerDuplicates{Match: match.match, Customers: make([]model.Customer, len(group))}
+			for i, index := range group {
+				duplicate.Customers[i] = customers[index]
+			}
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	return duplicates, nil
+}
+
+func (s *customerService) CreateCustomer(
+	c *fiber.Ctx, businessID string, req *validation.CreateCustomer,
+) (*model.Customer, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	customer := &model.Customer{
+		BusinessID: uuid.MustParse(businessID),
+		Name:       strings.TrimSpace(req.Name),
+		Email:      emptyToNil(strings.TrimSpace(req.Email)),
+		Phone:      emptyToNil(strings.TrimSpace(req.Phone)),
+		Address:    emptyToNil(req.Address),
+		Tier:       emptyToNil(req.Tier),
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := checkBusiness(tx, customer.BusinessID); err != nil {
+			return err
+		}
+
+		if req.OutletID != "" {
+			outletID := uuid.MustParse(req.OutletID)
+
+			var outlets int64
+			if err := tx.Model(&model.Outlet{}).
+				Where("id = ? AND business_id = ?", outletID, customer.BusinessID).
+				Count(&outlets).Error; err != nil {
+				return err
+			}
+			if outlets == 0 {
+				return fiber.NewError(fiber.StatusBadRequest, "Outlet does not belong to this business")
+			}
+
+			customer.OutletID = &outletID
+		}
+
+		if err := checkCustomerEmail(tx, customer); err != nil {
+			return err
+		}
+
+		return tx.Create(customer).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create customer: %+v", err)
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (s *customerService) UpdateCustomer(
+	c *fiber.Ctx, id string, req *validation.UpdateCustomer,
+) (*model.Customer, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	customer := new(model.Customer)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(customer, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Customer not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkBusinessAccess(c, tx, customer.BusinessID.String()); err != nil {
+			return err
+		}
+
+		if name := strings.TrimSpace(req.Name); name != "" {
+			customer.Name = name
+		}
+		if req.Email != nil {
+			customer.Email = emptyToNil(strings.TrimSpace(*req.Email))
+		}
+		if req.Phone != nil {
+			customer.Phone = emptyToNil(strings.TrimSpace(*req.Phone))
+		}
+		if req.Address != nil {
+			customer.Address = emptyToNil(*req.Address)
+		}
+		if req.Tier != nil {
+			customer.Tier = emptyToNil(*req.Tier)
+		}
+
+		if err := checkCustomerEmail(tx, customer); err != nil {
+			return err
+		}
+
+		return tx.Save(customer).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update customer: %+v", err)
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// MergeCustomers folds duplicates of a customer into it. Their sales, reservations, waitlist
+// entries and the coupons they were given move to the customer, details the customer is missing are
+// taken over, loyalty points and no-shows are added up, and the duplicates are deleted.
+func (s *customerService) MergeCustomers(
+	c *fiber.Ctx, id string, req *validation.MergeCustomers,
+) (*model.Customer, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	customer := new(model.Customer)
+	duplicateIDs := parseUUIDs(req.CustomerIDs)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(customer, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Customer not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// The duplicates must belong to the same business, so they are covered by this check.
+		if err := checkBusinessAccess(c, tx, customer.BusinessID.String()); err != nil {
+			return err
+		}
+
+		if slices.Contains(duplicateIDs, customer.ID) {
+			return fiber.NewError(fiber.StatusBadRequest, "A customer cannot be merged into itself")
+		}
+
+		var duplicates []model.Customer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND business_id = ?", duplicateIDs, customer.BusinessID).
+			Order("created_at asc").
+			Find(&duplicates).Error; err != nil {
+			return err
+		}
+		if len(duplicates) != len(duplicateIDs) {
+			return fiber.NewError(fiber.StatusBadRequest, "Customers to merge must belong to the same business")
+		}
+
+		for i := range duplicates {
+			mergeCustomerDetails(customer, &duplicates[i])
+		}
+
+		for _, owned := range []interface{}{&model.Sale{}, &model.Reservation{}, &model.WaitlistEntry{}} {
+			if err := tx.Model(owned).Where("customer_id IN ?", duplicateIDs).
+				Update("customer_id", customer.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := moveCouponCustomers(tx, customer, duplicateIDs); err != nil {
+			return err
+		}
+
+		// The duplicates go first, the customer may take over one of their emails.
+		if err := tx.Delete(&model.Customer{}, "id IN ?", duplicateIDs).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(customer).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to merge customers: %+v", err)
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// DeleteCustomer removes a customer, their sales and reservations are kept without a customer.
+func (s *customerService) DeleteCustomer(c *fiber.Ctx, id string) error {
+	customer, err := s.GetCustomerByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DB.WithContext(c.Context()).Delete(customer).Error; err != nil {
+		s.Log.Errorf("Failed to delete customer: %+v", err)
+		return err
+	}
+
+	return nil
+}
+
+// checkCustomerEmail makes sure no other customer of the business has the customer's email.
+func checkCustomerEmail(tx *gorm.DB, customer *model.Customer) error {
+	if customer.Email == nil {
+		return nil
+	}
+
+	var taken int64
+	if err := tx.Model(&model.Customer{}).
+		Where("business_id = ? AND LOWER(email) = LOWER(?) AND id <> ?", customer.BusinessID, *customer.Email,
+			customer.ID).
+		Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return fiber.NewError(fiber.StatusConflict, "Email is already in use")
+	}
+
+	return nil
+}
+
+// mergeCustomerDetails fills in what the customer is missing from a duplicate and adds up their
+// loyalty points and no-shows.
+func mergeCustomerDetails(customer, duplicate *model.Customer) {
+	if customer.Email == nil {
+		customer.Email = duplicate.Email
+	}
+	if customer.Phone == nil {
+		customer.Phone = duplicate.Phone
+	}
+	if customer.Address == nil {
+		customer.Address = duplicate.Address
+	}
+	if customer.Tier == nil {
+		customer.Tier = duplicate.Tier
+	}
+	if customer.UserID == nil {
+		customer.UserID = duplicate.UserID
+	}
+	if customer.OutletID == nil {
+		customer.OutletID = duplicate.OutletID
+	}
+
+	customer.LoyaltyPoints += duplicate.LoyaltyPoints
+	customer.NoShowCount += duplicate.NoShowCount
+	if duplicate.LastNoShowAt != nil &&
+		(customer.LastNoShowAt == nil || duplicate.LastNoShowAt.After(*customer.LastNoShowAt)) {
+		customer.LastNoShowAt = duplicate.LastNoShowAt
+	}
+}
+
+// moveCouponCustomers gives the coupons meant for the duplicates to the customer instead.
+func moveCouponCustomers(tx *gorm.DB, customer *model.Customer, duplicateIDs []uuid.UUID) error {
+	for _, duplicateID := range duplicateIDs {
+		var coupons []model.Coupon
+		if err := tx.Where("business_id = ? AND customer_ids @> ?", customer.BusinessID,
+			fmt.Sprintf("[%q]", duplicateID.String())).
+			Find(&coupons).Error; err != nil {
+			return err
+		}
+
+		for i := range coupons {
+			customerIDs := make([]uuid.UUID, 0, len(coupons[i].CustomerIDs))
+			for _, customerID := range coupons[i].CustomerIDs {
+				if customerID == duplicateID {
+					customerID = customer.ID
+				}
+				if !slices.Contains(customerIDs, customerID) {
+					customerIDs = append(customerIDs, customerID)
+				}
+			}
+
+			coupons[i].CustomerIDs = customerIDs
+			if err := tx.Model(&coupons[i]).Select("CustomerIDs").Updates(&coupons[i]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"app/src/config"
+	"app/src/model"
 	"app/src/utils"
 	"fmt"
 
@@ -13,6 +14,8 @@ type EmailService interface {
 	SendEmail(to, subject, body string) error
 	SendResetPasswordEmail(to, token string) error
 	SendVerificationEmail(to, token string) error
+	SendReservationConfirmationEmail(to, outlet string, reservation *model.Reservation) error
+	SendReservationReminderEmail(to, outlet string, reservation *model.Reservation) error
 }
 
 type emailService struct {
@@ -72,3 +75,25 @@ To verify your email, click on this link: %s
 If you did not create an account, then ignore this email.`, verificationEmailURL)
 	return s.SendEmail(to, subject, body)
 }
+
+func (s *emailService) SendReservationConfirmationEmail(to, outlet string, reservation *model.Reservation) error {
+	subject := fmt.Sprintf("Your reservation at %s", outlet)
+	body := fmt.Sprintf(`Dear %s,
+
+Your table for %d at %s is booked for %s.
+
+If your plans change, please let us know so we can give the table to another guest.`,
+		reservation.Name, reservation.PartySize, outlet, reservation.ReservedAt.Format("Monday 2 January 2006, 15:04"))
+	return s.SendEmail(to, subject, body)
+}
+
+func (s *emailService) SendReservationReminderEmail(to, outlet string, reservation *model.Reservation) error {
+	subject := fmt.Sprintf("Reminder: your reservation at %s", outlet)
+	body := fmt.Sprintf(`Dear %s,
+
+This is a reminder of your table for %d at %s today at %s.
+
+We look forward to seeing you.`,
+		reservation.Name, reservation.PartySize, outlet, reservation.ReservedAt.Format("15:04"))
+	return s.SendEmail(to, subject, body)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyService interface {
+	Reserve(c *fiber.Ctx, scope, key, requestHash string) (*model.IdempotencyKey, bool, error)
+	Complete(c *fiber.Ctx, record *model.IdempotencyKey) error
+	Release(c *fiber.Ctx, record *model.IdempotencyKey) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewIdempotencyService(db *gorm.DB, validate *validator.Validate) IdempotencyService {
+	return &idempotencyService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+// Reserve claims a key for a new request. When the key is already taken it returns the stored
+// record instead and false, so the caller can replay or reject the request.
+func (s *idempotencyService) Reserve(
+	c *fiber.Ctx, scope, key, requestHash string,
+) (*model.IdempotencyKey, bool, error) {
+	if err := s.Validate.Struct(&validation.IdempotencyKey{Key: key}); err != nil {
+		return nil, false, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+	now := time.Now()
+
+	// An expired key is free again even when the cleanup job has not removed it yet
+	if err := db.Where("scope = ? AND key = ? AND expires_at < ?", scope, key, now).
+		Delete(&model.IdempotencyKey{}).Error; err != nil {
+		s.Log.Errorf("Failed to delete expired idempotency key: %+v", err)
+		return nil, false, err
+	}
+
+	record := &model.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(time.Duration(config.IdempotencyKeyTTL) * time.Hour),
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		s.Log.Errorf("Failed to reserve idempotency key: %+v", result.Error)
+		return nil, false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	existing := new(model.IdempotencyKey)
+	result = db.First(existing, "scope = ? AND key = ?", scope, key)
+
+	// The other request was released between our insert and this read, let the client retry
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, false, fiber.NewError(fiber.StatusConflict,
+			"A request with this idempotency key is still being processed")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get idempotency key: %+v", result.Error)
+		return nil, false, result.Error
+	}
+
+	return existing, false, nil
+}
+
+// Complete stores the response of a reserved key for replay.
+func (s *idempotencyService) Complete(c *fiber.Ctx, record *model.IdempotencyKey) error {
+	err := s.DB.WithContext(c.Context()).Model(record).Updates(map[string]interface{}{
+		"status_code":   record.StatusCode,
+		"content_type":  record.ContentType,
+		"response_body": record.ResponseBody,
+	}).Error
+
+	if err != nil {
+		s.Log.Errorf("Failed to save idempotent response: %+v", err)
+	}
+
+	return err
+}
+
+// Release frees a reserved key so the request can be retried, used when it failed on our side.
+func (s *idempotencyService) Release(c *fiber.Ctx, record *model.IdempotencyKey) error {
+	err := s.DB.WithContext(c.Context()).Delete(record).Error
+
+	if err != nil {
+		s.Log.Errorf("Failed to release idempotency key: %+v", err)
+	}
+
+	return err
+}
+
+func (s *idempotencyService) DeleteExpired(ctx context.Context) (int64, error) {
+	result := s.DB.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&model.IdempotencyKey{})
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to delete expired idempotency keys: %+v", result.Error)
+	}
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,732 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/receipt"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KitchenService interface {
+	GetKitchenRoutes(c *fiber.Ctx, outletID string) ([]model.KitchenRoute, error)
+	CreateKitchenRoute(c *fiber.Ctx, outletID string, req *validation.CreateKitchenRoute) (*model.KitchenRoute, error)
+	DeleteKitchenRoute(c *fiber.Ctx, id string) error
+	FireSale(c *fiber.Ctx, saleID string) ([]model.PrintJob, error)
+	ReprintKitchenTickets(c *fiber.Ctx, saleID string) ([]model.PrintJob, error)
+	GetKitchenItems(c *fiber.Ctx, outletID string, query *validation.QueryKitchenItem) ([]model.KitchenItem, error)
+	UpdateKitchenItem(c *fiber.Ctx, id string, req *validation.UpdateKitchenItem) (*model.KitchenItem, error)
+	CheckKitchenFeed(c *fiber.Ctx, outletID string) error
+	SubscribeKitchenFeed(outletID, stationID string) (<-chan []byte, func())
+}
+
+// kitchenFeed carries the kitchen display events of this process, displays connected to another
+// instance only see the changes made through it.
+var kitchenFeed = utils.NewBroker(config.KitchenFeedBuffer)
+
+// kitchenStatusRank orders the states of a kitchen item, it can only move to a higher one.
+var kitchenStatusRank = map[string]int{
+	config.KitchenStatusNew:        0,
+	config.KitchenStatusInProgress: 1,
+	config.KitchenStatusReady:      2,
+	config.KitchenStatusServed:     3,
+}
+
+type kitchenService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewKitchenService(db *gorm.DB, validate *validator.Validate) KitchenService {
+	return &kitchenService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *kitchenService) GetKitchenRoutes(c *fiber.Ctx, outletID string) ([]model.KitchenRoute, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	var routes []model.KitchenRoute
+
+	if err := s.DB.WithContext(c.Context()).
+		Where("outlet_id = ?", outletID).
+		Order("created_at asc").
+		Find(&routes).Error; err != nil {
+		s.Log.Errorf("Failed to get kitchen routes: %+v", err)
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// CreateKitchenRoute routes a product category or a product of the outlet's business to one of
+// its network printers or kitchen displays.
+func (s *kitchenService) CreateKitchenRoute(
+	c *fiber.Ctx, outletID string, req *validation.CreateKitchenRoute,
+) (*model.KitchenRoute, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if req.ProductCategoryID != "" && req.ProductID != "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Route a product category or a product, not both")
+	}
+
+	route := &model.KitchenRoute{
+		OutletID:  uuid.MustParse(outletID),
+		PrinterID: uuid.MustParse(req.PrinterID),
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		outlet := new(model.Outlet)
+		result := tx.First(outlet, "id = ?", route.OutletID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Outlet not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		printer := new(model.Printer)
+		result = tx.Where("id = ? AND outlet_id = ?", route.PrinterID, route.OutletID).Limit(1).Find(printer)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Printer does not belong to this outlet")
+		}
+		if printer.ConnectionType != config.PrinterConnectionDisplay {
+			if err := checkNetworkPrinter(printer); err != nil {
+				return err
+			}
+		}
+
+		target := tx.Model(&model.Product{})
+		column := "product_id"
+		id := req.ProductID
+		if req.ProductCategoryID != "" {
+			target = tx.Model(&model.ProductCategory{})
+			column = "product_category_id"
+			id = req.ProductCategoryID
+		}
+
+		var found int64
+		if err := target.Where("id = ? AND business_id = ?", id, outlet.BusinessID).Count(&found).Error; err != nil {
+			return err
+		}
+		if found == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Product or category does not belong to this business")
+		}
+
+		var existing int64
+		if err := tx.Model(&model.KitchenRoute{}).
+			Where("printer_id = ? AND "+column+" = ?", route.PrinterID, id).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return fiber.NewError(fiber.StatusConflict, "Route already exists")
+		}
+
+		targetID := uuid.MustParse(id)
+		if req.ProductCategoryID != "" {
+			route.ProductCategoryID = &targetID
+		} else {
+			route.ProductID = &targetID
+		}
+
+		return tx.Create(route).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create kitchen route: %+v", err)
+		}
+		return nil, err
+	}
+
+	return route, nil
+}
+
+func (s *kitchenService) DeleteKitchenRoute(c *fiber.Ctx, id string) error {
+	route := new(model.KitchenRoute)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(route, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Kitchen route not found")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed get kitchen route by id: %+v", result.Error)
+		return result.Error
+	}
+
+	if err := checkOutletAccess(c, db, route.OutletID.String()); err != nil {
+		return err
+	}
+
+	if err := db.Delete(route).Error; err != nil {
+		s.Log.Errorf("Failed to delete kitchen route: %+v", err)
+		return err
+	}
+
+	return nil
+}
+
+// FireSale sends the items of a sale that were not sent yet to their kitchen printers and displays.
+func (s *kitchenService) FireSale(c *fiber.Ctx, saleID string) ([]model.PrintJob, error) {
+	var jobs []model.PrintJob
+	var fired []model.KitchenItem
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale := new(model.Sale)
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sale, "id = ?", saleID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		if sale.Status == config.SaleStatusVoid || sale.Status == config.SaleStatusRefunded {
+			return fiber.NewError(fiber.StatusBadRequest, "Only open or paid sales can be sent to the kitchen")
+		}
+
+		var items []model.SaleItem
+		if err := tx.Where("sale_id = ? AND fired_at IS NULL", sale.ID).
+			Order("created_at asc").
+			Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "No new items to send to the kitchen")
+		}
+
+		order, err := routeKitchenOrder(tx, sale, items)
+		if err != nil {
+			return err
+		}
+
+		if jobs, err = printKitchenTickets(tx, order, receipt.TicketOrder); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if fired, err = displayKitchenItems(tx, order, now); err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+
+		return tx.Model(&model.SaleItem{}).Where("id IN ?", ids).Update("fired_at", now).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to fire sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	publishKitchenItems(config.KitchenEventFired, fired)
+
+	return jobs, nil
+}
+
+// ReprintKitchenTickets prints the items already sent to the kitchen again, marked as a reprint.
+func (s *kitchenService) ReprintKitchenTickets(c *fiber.Ctx, saleID string) ([]model.PrintJob, error) {
+	var jobs []model.PrintJob
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale := new(model.Sale)
+		result := tx.First(sale, "id = ?", saleID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		var items []model.SaleItem
+		if err := tx.Where("sale_id = ? AND fired_at IS NOT NULL", sale.ID).
+			Order("created_at asc").
+			Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "No items were sent to the kitchen")
+		}
+
+		order, err := routeKitchenOrder(tx, sale, items)
+		if err != nil {
+			return err
+		}
+
+		jobs, err = printKitchenTickets(tx, order, receipt.TicketReprint)
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to reprint kitchen tickets: %+v", err)
+		}
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// GetKitchenItems lists the items of the outlet's kitchen displays, oldest first. Served and void
+// items are only listed for the last config.KitchenHistory.
+func (s *kitchenService) GetKitchenItems(
+	c *fiber.Ctx, outletID string, query *validation.QueryKitchenItem,
+) ([]model.KitchenItem, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(query); err != nil {
+		return nil, err
+	}
+
+	var items []model.KitchenItem
+
+	db := s.DB.WithContext(c.Context()).Where("outlet_id = ?", outletID)
+
+	if query.StationID != "" {
+		db = db.Where("printer_id = ?", query.StationID)
+	}
+
+	switch query.Status {
+	case "":
+		db = db.Where("status IN ?", []string{
+			config.KitchenStatusNew, config.KitchenStatusInProgress, config.KitchenStatusReady,
+		})
+	case config.KitchenStatusServed, config.KitchenStatusVoid:
+		db = db.Where("status = ? AND fired_at > ?", query.Status, time.Now().Add(-config.KitchenHistory))
+	default:
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Order("fired_at asc").Order("created_at asc").Find(&items).Error; err != nil {
+		s.Log.Errorf("Failed to get kitchen items: %+v", err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpdateKitchenItem bumps an item on a kitchen display to a later state and tells the front of
+// house once every item of its order is ready.
+func (s *kitchenService) UpdateKitchenItem(
+	c *fiber.Ctx, id string, req *validation.UpdateKitchenItem,
+) (*model.KitchenItem, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	item := new(model.KitchenItem)
+	var ready []model.KitchenItem
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(item, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Kitchen item not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, item.OutletID.String()); err != nil {
+			return err
+		}
+
+		if item.Status == config.KitchenStatusVoid {
+			return fiber.NewError(fiber.StatusBadRequest, "Kitchen item was voided")
+		}
+		if kitchenStatusRank[req.Status] <= kitchenStatusRank[item.Status] {
+			return fiber.NewError(fiber.StatusBadRequest, "Kitchen item is already "+item.Status)
+		}
+
+		now := time.Now()
+		item.Status = req.Status
+		switch req.Status {
+		case config.KitchenStatusInProgress:
+			item.StartedAt = &now
+		case config.KitchenStatusReady:
+			item.ReadyAt = &now
+		case config.KitchenStatusServed:
+			item.ServedAt = &now
+			if item.ReadyAt == nil {
+				item.ReadyAt = &now
+			}
+		}
+
+		if err := tx.Model(item).Select("status", "started_at", "ready_at", "served_at").Updates(item).Error; err != nil {
+			return err
+		}
+
+		if item.Status != config.KitchenStatusReady {
+			return nil
+		}
+
+		var waiting int64
+		if err := tx.Model(&model.KitchenItem{}).
+			Where("sale_id = ? AND status IN ?", item.SaleID,
+				[]string{config.KitchenStatusNew, config.KitchenStatusInProgress}).
+			Count(&waiting).Error; err != nil {
+			return err
+		}
+		if waiting > 0 {
+			return nil
+		}
+
+		return tx.Where("sale_id = ? AND status = ?", item.SaleID, config.KitchenStatusReady).
+			Order("fired_at asc").
+			Find(&ready).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update kitchen item: %+v", err)
+		}
+		return nil, err
+	}
+
+	publishKitchenItems(config.KitchenEventUpdated, []model.KitchenItem{*item})
+
+	if len(ready) > 0 {
+		publishKitchenEvent(kitchenTopic(item.OutletID, uuid.Nil), response.KitchenEvent{
+			Event:         config.KitchenEventOrderReady,
+			SaleID:        &item.SaleID,
+			InvoiceNumber: item.InvoiceNumber,
+			TableName:     item.Table,
+			Items:         ready,
+		})
+	}
+
+	return item, nil
+}
+
+// CheckKitchenFeed makes sure the user may follow the kitchen feed of the outlet, the websocket
+// handler that subscribes has no request to check anymore.
+func (s *kitchenService) CheckKitchenFeed(c *fiber.Ctx, outletID string) error {
+	return checkOutletAccess(c, s.DB, outletID)
+}
+
+// SubscribeKitchenFeed returns the events of one display station, or of every station of the
+// outlet along with the order ready events when stationID is empty.
+func (s *kitchenService) SubscribeKitchenFeed(outletID, stationID string) (<-chan []byte, func()) {
+	station := uuid.Nil
+	if stationID != "" {
+		station = uuid.MustParse(stationID)
+	}
+
+	return kitchenFeed.Subscribe(kitchenTopic(uuid.MustParse(outletID), station))
+}
+
+// kitchenOrder is a sale, with its table and staff loaded, and where its items are prepared.
+type kitchenOrder struct {
+	Sale     model.Sale
+	Stations []kitchenStation
+}
+
+// kitchenStation is a kitchen printer or display and the sale items it prepares.
+type kitchenStation struct {
+	Printer model.Printer
+	Items   []model.SaleItem
+}
+
+// routeKitchenOrder finds the stations the items of a sale are routed to. The sale is copied so
+// callers saving it don't touch the relations loaded for the tickets.
+func routeKitchenOrder(tx *gorm.DB, sale *model.Sale, items []model.SaleItem) (*kitchenOrder, error) {
+	order := &kitchenOrder{Sale: *sale}
+
+	var routes []model.KitchenRoute
+	if err := tx.Where("outlet_id = ?", sale.OutletID).Find(&routes).Error; err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return order, nil
+	}
+
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+
+	var products []model.Product
+	if err := tx.Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*model.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	lines := make([]utils.KitchenLine, len(items))
+	for i := range items {
+		items[i].Product = byID[items[i].ProductID]
+		lines[i].ProductID = items[i].ProductID
+		if items[i].Product != nil {
+			lines[i].CategoryID = items[i].Product.CategoryID
+		}
+	}
+
+	stations := utils.RouteKitchenLines(lines, kitchenRules(routes))
+	if len(stations) == 0 {
+		return order, nil
+	}
+
+	if err := loadTicketHeader(tx, &order.Sale); err != nil {
+		return nil, err
+	}
+
+	printerIDs := make([]uuid.UUID, len(stations))
+	for i, station := range stations {
+		printerIDs[i] = station.PrinterID
+	}
+
+	var printers []model.Printer
+	if err := tx.Where("id IN ?", printerIDs).Find(&printers).Error; err != nil {
+		return nil, err
+	}
+	printerByID := make(map[uuid.UUID]model.Printer, len(printers))
+	for _, printer := range printers {
+		printerByID[printer.ID] = printer
+	}
+
+	for _, station := range stations {
+		stationItems := make([]model.SaleItem, len(station.Lines))
+		for i, line := range station.Lines {
+			stationItems[i] = items[line]
+		}
+
+		order.Stations = append(order.Stations, kitchenStation{
+			Printer: printerByID[station.PrinterID],
+			Items:   stationItems,
+		})
+	}
+
+	return order, nil
+}
+
+func kitchenRules(routes []model.KitchenRoute) []utils.KitchenRule {
+	rules := make([]utils.KitchenRule, len(routes))
+	for i, route := range routes {
+		rules[i] = utils.KitchenRule{PrinterID: route.PrinterID}
+		if route.ProductID != nil {
+			rules[i].ProductID = *route.ProductID
+		}
+		if route.ProductCategoryID != nil {
+			rules[i].CategoryID = *route.ProductCategoryID
+		}
+	}
+
+	return rules
+}
+
+// printKitchenTickets queues a ticket of the given kind for every kitchen printer of the order.
+func printKitchenTickets(tx *gorm.DB, order *kitchenOrder, kind string) ([]model.PrintJob, error) {
+	jobs := make([]model.PrintJob, 0)
+
+	for _, station := range order.Stations {
+		if station.Printer.ConnectionType == config.PrinterConnectionDisplay {
+			continue
+		}
+
+		opts, err := receiptOptions(tx, order.Sale.OutletID, &station.Printer, "")
+		if err != nil {
+			return nil, err
+		}
+
+		job := model.PrintJob{
+			PrinterID: station.Printer.ID,
+			SaleID:    &order.Sale.ID,
+			Kind:      config.PrintJobKitchen,
+			Data:      receipt.KitchenTicket(&order.Sale, station.Printer.Name, station.Items, kind, opts).Bytes(),
+		}
+		if err = enqueuePrintJob(tx, &job); err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// displayKitchenItems puts the items of the order on its kitchen displays.
+func displayKitchenItems(tx *gorm.DB, order *kitchenOrder, firedAt time.Time) ([]model.KitchenItem, error) {
+	var table *string
+	if order.Sale.Table != nil {
+		table = &order.Sale.Table.Name
+	}
+
+	var items []model.KitchenItem
+	for _, station := range order.Stations {
+		if station.Printer.ConnectionType != config.PrinterConnectionDisplay {
+			continue
+		}
+
+		for _, item := range station.Items {
+			name := item.ProductID.String()
+			if item.Product != nil {
+				name = item.Product.Name
+			}
+
+			items = append(items, model.KitchenItem{
+				OutletID:      order.Sale.OutletID,
+				SaleID:        order.Sale.ID,
+				SaleItemID:    item.ID,
+				PrinterID:     station.Printer.ID,
+				InvoiceNumber: order.Sale.InvoiceNumber,
+				OrderType:     order.Sale.OrderType,
+				Table:         table,
+				ProductName:   name,
+				Quantity:      item.Quantity,
+				SeatNumber:    item.SeatNumber,
+				Note:          item.Note,
+				Modifiers:     item.Modifiers,
+				Status:        config.KitchenStatusNew,
+				FiredAt:       firedAt,
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	return items, tx.Create(&items).Error
+}
+
+// voidKitchenTickets tells the kitchen to stop preparing the given items that were already sent.
+// It returns the display items taken off, to be published once the transaction is committed.
+func voidKitchenTickets(tx *gorm.DB, sale *model.Sale, items []model.SaleItem) ([]model.KitchenItem, error) {
+	var voided []model.KitchenItem
+
+	fired := make([]model.SaleItem, 0, len(items))
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if item.FiredAt != nil {
+			fired = append(fired, item)
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(fired) == 0 {
+		return voided, nil
+	}
+
+	order, err := routeKitchenOrder(tx, sale, fired)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = printKitchenTickets(tx, order, receipt.TicketVoid); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Where("sale_item_id IN ? AND status <> ?", ids, config.KitchenStatusServed).
+		Find(&voided).Error; err != nil {
+		return nil, err
+	}
+	if len(voided) == 0 {
+		return voided, nil
+	}
+
+	for i := range voided {
+		voided[i].Status = config.KitchenStatusVoid
+	}
+
+	return voided, tx.Model(&model.KitchenItem{}).
+		Where("sale_item_id IN ? AND status <> ?", ids, config.KitchenStatusServed).
+		Update("status", config.KitchenStatusVoid).Error
+}
+
+// loadTicketHeader loads the table and staff printed at the top of kitchen tickets.
+func loadTicketHeader(tx *gorm.DB, sale *model.Sale) error {
+	if sale.Table == nil && sale.TableID != nil {
+		sale.Table = new(model.Table)
+		if err := tx.First(sale.Table, "id = ?", sale.TableID).Error; err != nil {
+			return err
+		}
+	}
+
+	if sale.OutletStaff == nil {
+		sale.OutletStaff = new(model.OutletStaff)
+		if err := tx.First(sale.OutletStaff, "id = ?", sale.OutletStaffID).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// kitchenTopic is the feed of a display station, or of the whole outlet for uuid.Nil.
+func kitchenTopic(outletID, stationID uuid.UUID) string {
+	if stationID == uuid.Nil {
+		return "kitchen:" + outletID.String()
+	}
+	return "kitchen:" + outletID.String() + ":" + stationID.String()
+}
+
+// publishKitchenItems sends changed items to the feed of their outlet and of their station.
+func publishKitchenItems(event string, items []model.KitchenItem) {
+	byTopic := make(map[string][]model.KitchenItem)
+	for _, item := range items {
+		outlet := kitchenTopic(item.OutletID, uuid.Nil)
+		station := kitchenTopic(item.OutletID, item.PrinterID)
+		byTopic[outlet] = append(byTopic[outlet], item)
+		byTopic[station] = append(byTopic[station], item)
+	}
+
+	for topic, topicItems := range byTopic {
+		publishKitchenEvent(topic, response.KitchenEvent{Event: event, Items: topicItems})
+	}
+}
+
+func publishKitchenEvent(topic string, event response.KitchenEvent) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		utils.Log.Errorf("Failed to encode kitchen event: %+v", err)
+		return
+	}
+
+	kitchenFeed.Publish(topic, message)
+}
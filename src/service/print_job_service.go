@@ -0,0 +1,459 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/escpos"
+	"app/src/model"
+	"app/src/receipt"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Jobs claimed by one delivery round
+const printJobBatch = 50
+
+type PrintJobService interface {
+	GetPrintJobs(c *fiber.Ctx, printerID string, params *validation.QueryPrintJob) ([]model.PrintJob, int64, error)
+	GetPrintJobByID(c *fiber.Ctx, id string) (*model.PrintJob, error)
+	PrintReceipt(c *fiber.Ctx, saleID string, req *validation.PrintReceipt) (*model.PrintJob, error)
+	TestPrint(c *fiber.Ctx, printerID string) (*model.PrintJob, error)
+	RetryPrintJob(c *fiber.Ctx, id string) (*model.PrintJob, error)
+	DeliverPrintJobs(ctx context.Context) (int64, error)
+}
+
+type printJobService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewPrintJobService(db *gorm.DB, validate *validator.Validate) PrintJobService {
+	return &printJobService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *printJobService) GetPrintJobs(
+	c *fiber.Ctx, printerID string, params *validation.QueryPrintJob,
+) ([]model.PrintJob, int64, error) {
+	var jobs []model.PrintJob
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+	if _, err := s.findPrinter(c, db, printerID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := db.Model(&model.PrintJob{}).
+		Where("printer_id = ?", printerID).
+		Order("created_at desc")
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count print jobs: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Limit(params.Limit).Offset(offset).Find(&jobs).Error; err != nil {
+		s.Log.Errorf("Failed to get print jobs: %+v", err)
+		return nil, 0, err
+	}
+
+	return jobs, totalResults, nil
+}
+
+func (s *printJobService) GetPrintJobByID(c *fiber.Ctx, id string) (*model.PrintJob, error) {
+	job := new(model.PrintJob)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(job, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Print job not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get print job by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if _, err := s.findPrinter(c, db, job.PrinterID.String()); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// PrintReceipt renders the receipt of a sale now and queues it for a network printer.
+func (s *printJobService) PrintReceipt(
+	c *fiber.Ctx, saleID string, req *validation.PrintReceipt,
+) (*model.PrintJob, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+
+	sale, err := loadReceiptSale(db, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkOutletAccess(c, db, sale.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	printer, err := findOutletPrinter(db, sale.OutletID, req.PrinterID)
+	if err != nil {
+		return nil, err
+	}
+	if printer.ID == uuid.Nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Outlet has no default printer")
+	}
+	if err = checkNetworkPrinter(printer); err != nil {
+		return nil, err
+	}
+
+	doc, err := renderReceipt(c.Context(), db, sale, printer, req.Language)
+	if err != nil {
+		s.Log.Errorf("Failed to render receipt: %+v", err)
+		return nil, err
+	}
+
+	job := &model.PrintJob{
+		PrinterID: printer.ID,
+		SaleID:    &sale.ID,
+		Kind:      config.PrintJobReceipt,
+		Data:      doc.Bytes(),
+	}
+
+	if err = enqueuePrintJob(db, job); err != nil {
+		s.Log.Errorf("Failed to queue print job: %+v", err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// TestPrint queues a test page for a network printer.
+func (s *printJobService) TestPrint(c *fiber.Ctx, printerID string) (*model.PrintJob, error) {
+	db := s.DB.WithContext(c.Context())
+
+	printer, err := s.findPrinter(c, db, printerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkNetworkPrinter(printer); err != nil {
+		return nil, err
+	}
+
+	opts, err := receiptOptions(db, printer.OutletID, printer, "")
+	if err != nil {
+		s.Log.Errorf("Failed to get receipt settings: %+v", err)
+		return nil, err
+	}
+
+	job := &model.PrintJob{
+		PrinterID: printer.ID,
+		Kind:      config.PrintJobTest,
+		Data:      receipt.TestPage(printer.Name, opts).Bytes(),
+	}
+
+	if err = enqueuePrintJob(db, job); err != nil {
+		s.Log.Errorf("Failed to queue print job: %+v", err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RetryPrintJob queues a failed job again with a fresh number of attempts.
+func (s *printJobService) RetryPrintJob(c *fiber.Ctx, id string) (*model.PrintJob, error) {
+	job := new(model.PrintJob)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(job, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Print job not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if _, err := s.findPrinter(c, tx, job.PrinterID.String()); err != nil {
+			return err
+		}
+
+		if job.Status != config.PrintJobStatusFailed {
+			return fiber.NewError(fiber.StatusBadRequest, "Only failed print jobs can be retried")
+		}
+
+		job.Status = config.PrintJobStatusPending
+		job.Attempts = 0
+		job.NextAttemptAt = time.Now()
+
+		return tx.Model(job).Updates(map[string]interface{}{
+			"status":          job.Status,
+			"attempts":        job.Attempts,
+			"next_attempt_at": job.NextAttemptAt,
+		}).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to retry print job: %+v", err)
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// DeliverPrintJobs sends the jobs that are due to their printers and returns how many printed.
+// Printers are served in parallel and the jobs of a printer in the order they were queued. When
+// a printer cannot be reached its remaining jobs wait for the retry of the job that failed.
+func (s *printJobService) DeliverPrintJobs(ctx context.Context) (int64, error) {
+	jobs, printers, err := s.claimPrintJobs(ctx)
+	if err != nil {
+		s.Log.Errorf("Failed to claim print jobs: %+v", err)
+		return 0, err
+	}
+
+	queues := make(map[uuid.UUID][]model.PrintJob)
+	for _, job := range jobs {
+		queues[job.PrinterID] = append(queues[job.PrinterID], job)
+	}
+
+	var printed atomic.Int64
+	var wg sync.WaitGroup
+	for printerID, queue := range queues {
+		wg.Add(1)
+		go func(printer model.Printer, queue []model.PrintJob) {
+			defer wg.Done()
+			printed.Add(s.deliverQueue(ctx, &printer, queue))
+		}(printers[printerID], queue)
+	}
+	wg.Wait()
+
+	return printed.Load(), nil
+}
+
+// claimPrintJobs marks the due jobs as printing so other instances skip them. A job left
+// printing longer than the lease, e.g. by a crash, is due again.
+func (s *printJobService) claimPrintJobs(ctx context.Context) ([]model.PrintJob, map[uuid.UUID]model.Printer, error) {
+	var jobs []model.PrintJob
+	now := time.Now()
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?",
+				[]string{config.PrintJobStatusPending, config.PrintJobStatusPrinting}, now).
+			Order("created_at asc").
+			Limit(printJobBatch).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(jobs))
+		for i, job := range jobs {
+			ids[i] = job.ID
+		}
+
+		return tx.Model(&model.PrintJob{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":          config.PrintJobStatusPrinting,
+			"next_attempt_at": now.Add(config.PrintLease),
+		}).Error
+	})
+	if err != nil || len(jobs) == 0 {
+		return nil, nil, err
+	}
+
+	printerIDs := make([]uuid.UUID, 0, len(jobs))
+	for _, job := range jobs {
+		printerIDs = append(printerIDs, job.PrinterID)
+	}
+
+	var printers []model.Printer
+	if err = s.DB.WithContext(ctx).Where("id IN ?", printerIDs).Find(&printers).Error; err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[uuid.UUID]model.Printer, len(printers))
+	for _, printer := range printers {
+		byID[printer.ID] = printer
+	}
+
+	return jobs, byID, nil
+}
+
+// deliverQueue sends the jobs of one printer in order until the printer cannot be reached.
+func (s *printJobService) deliverQueue(ctx context.Context, printer *model.Printer, queue []model.PrintJob) int64 {
+	var printed int64
+	db := s.DB.WithContext(ctx)
+
+	for i := range queue {
+		job := &queue[i]
+
+		var address string
+		err := checkNetworkPrinter(printer)
+		if err == nil {
+			address, err = printerAddress(printer)
+		}
+		if err == nil {
+			err = escpos.Send(ctx, address, job.Data, config.PrintTimeout)
+		}
+
+		if err == nil {
+			now := time.Now()
+			if err = db.Model(job).Updates(map[string]interface{}{
+				"status":     config.PrintJobStatusPrinted,
+				"attempts":   job.Attempts + 1,
+				"last_error": nil,
+				"printed_at": now,
+			}).Error; err != nil {
+				s.Log.Errorf("Failed to update print job: %+v", err)
+			}
+			printed++
+			continue
+		}
+
+		s.Log.Warnf("Failed to print job %s on printer %s: %+v", job.ID, printer.Name, err)
+
+		retryAt, failErr := s.failPrintJob(db, job, err)
+		if failErr != nil {
+			s.Log.Errorf("Failed to update print job: %+v", failErr)
+		}
+		if retryAt.IsZero() {
+			continue
+		}
+
+		// The printer is unreachable, the jobs queued after this one wait for its retry
+		rest := make([]uuid.UUID, 0, len(queue)-i-1)
+		for _, next := range queue[i+1:] {
+			rest = append(rest, next.ID)
+		}
+		if len(rest) > 0 {
+			if err = db.Model(&model.PrintJob{}).Where("id IN ?", rest).Updates(map[string]interface{}{
+				"status":          config.PrintJobStatusPending,
+				"next_attempt_at": retryAt,
+			}).Error; err != nil {
+				s.Log.Errorf("Failed to release print jobs: %+v", err)
+			}
+		}
+		break
+	}
+
+	return printed
+}
+
+// failPrintJob records a failed attempt. It returns when the job is retried, or the zero time
+// when it ran out of attempts and failed for good.
+func (s *printJobService) failPrintJob(db *gorm.DB, job *model.PrintJob, cause error) (time.Time, error) {
+	message := cause.Error()
+	job.Attempts++
+	job.LastError = &message
+	job.Status = config.PrintJobStatusFailed
+
+	var retryAt time.Time
+	if job.Attempts < config.PrintMaxAttempts {
+		job.Status = config.PrintJobStatusPending
+		retryAt = time.Now().Add(utils.Backoff(job.Attempts, config.PrintRetryDelay, config.PrintRetryMaxDelay))
+		job.NextAttemptAt = retryAt
+	}
+
+	return retryAt, db.Model(job).Updates(map[string]interface{}{
+		"status":          job.Status,
+		"attempts":        job.Attempts,
+		"last_error":      job.LastError,
+		"next_attempt_at": job.NextAttemptAt,
+	}).Error
+}
+
+// enqueuePrintJob queues a job. When the printer is waiting to retry an earlier job, the new one
+// waits too so jobs print in the order they were queued.
+func enqueuePrintJob(db *gorm.DB, job *model.PrintJob) error {
+	var waiting []time.Time
+	if err := db.Model(&model.PrintJob{}).
+		Where("printer_id = ? AND status = ? AND attempts > 0", job.PrinterID, config.PrintJobStatusPending).
+		Order("next_attempt_at desc").
+		Limit(1).
+		Pluck("next_attempt_at", &waiting).Error; err != nil {
+		return err
+	}
+
+	job.Status = config.PrintJobStatusPending
+	job.NextAttemptAt = time.Now()
+	if len(waiting) > 0 && waiting[0].After(job.NextAttemptAt) {
+		job.NextAttemptAt = waiting[0]
+	}
+
+	return db.Create(job).Error
+}
+
+// findPrinter loads a printer of an outlet the user works for.
+func (s *printJobService) findPrinter(c *fiber.Ctx, db *gorm.DB, printerID string) (*model.Printer, error) {
+	printer := new(model.Printer)
+
+	result := db.First(printer, "id = ?", printerID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Printer not found")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed get printer by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, printer.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return printer, nil
+}
+
+func checkNetworkPrinter(printer *model.Printer) error {
+	if printer == nil || printer.ID == uuid.Nil {
+		return fiber.NewError(fiber.StatusNotFound, "Printer not found")
+	}
+	if printer.ConnectionType != config.PrinterConnectionNetwork || printer.IPAddress == nil || *printer.IPAddress == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Printer is not a network printer")
+	}
+	if _, err := printerAddress(printer); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("Printer address must be a private IP address on port %s", config.PrinterPort))
+	}
+	return nil
+}
+
+// printerAddress is the host and port of a network printer, see utils.PrinterAddress.
+func printerAddress(printer *model.Printer) (string, error) {
+	return utils.PrinterAddress(*printer.IPAddress, config.PrinterPort)
+}
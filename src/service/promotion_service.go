@@ -0,0 +1,477 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PromotionService interface {
+	GetPromotions(c *fiber.Ctx, businessID string) ([]model.Promotion, error)
+	GetPromotionByID(c *fiber.Ctx, id string) (*model.Promotion, error)
+	CreatePromotion(c *fiber.Ctx, businessID string, req *validation.CreatePromotion) (*model.Promotion, error)
+	UpdatePromotion(c *fiber.Ctx, id string, req *validation.UpdatePromotion) (*model.Promotion, error)
+	DeletePromotion(c *fiber.Ctx, id string) error
+}
+
+type promotionService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewPromotionService(db *gorm.DB, validate *validator.Validate) PromotionService {
+	return &promotionService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *promotionService) GetPromotions(c *fiber.Ctx, businessID string) ([]model.Promotion, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	var promotions []model.Promotion
+
+	result := s.DB.WithContext(c.Context()).
+		Where("business_id = ?", businessID).
+		Order("priority desc, created_at asc").
+		Find(&promotions)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get promotions: %+v", result.Error)
+	}
+
+	return promotions, result.Error
+}
+
+func (s *promotionService) GetPromotionByID(c *fiber.Ctx, id string) (*model.Promotion, error) {
+	promotion := new(model.Promotion)
+
+	result := s.DB.WithContext(c.Context()).First(promotion, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get promotion by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkBusinessAccess(c, s.DB, promotion.BusinessID.String()); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) CreatePromotion(
+	c *fiber.Ctx, businessID string, req *validation.CreatePromotion,
+) (*model.Promotion, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	promotion := &model.Promotion{
+		BusinessID:    uuid.MustParse(businessID),
+		Name:          req.Name,
+		Priority:      req.Priority,
+		Stackable:     req.Stackable == nil || *req.Stackable,
+		IsActive:      true,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+		DaysOfWeek:    req.DaysOfWeek,
+		MinSpend:      req.MinSpend,
+		ProductIDs:    parseUUIDs(req.ProductIDs),
+		CategoryIDs:   parseUUIDs(req.CategoryIDs),
+		CustomerTiers: req.CustomerTiers,
+		Action:        req.Action,
+		Value:         req.Value,
+		BuyQuantity:   req.BuyQuantity,
+		GetQuantity:   req.GetQuantity,
+	}
+
+	promotion.Description = emptyToNil(req.Description)
+	promotion.StartTime = emptyToNil(req.StartTime)
+	promotion.EndTime = emptyToNil(req.EndTime)
+
+	if err := checkPromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		var businesses int64
+		if err := tx.Model(&model.Business{}).Where("id = ?", promotion.BusinessID).Count(&businesses).Error; err != nil {
+			return err
+		}
+		if businesses == 0 {
+			return fiber.NewError(fiber.StatusNotFound, "Business not found")
+		}
+
+		if req.OutletID != "" {
+			outletID := uuid.MustParse(req.OutletID)
+
+			var outlets int64
+			if err := tx.Model(&model.Outlet{}).
+				Where("id = ? AND business_id = ?", outletID, promotion.BusinessID).
+				Count(&outlets).Error; err != nil {
+				return err
+			}
+			if outlets == 0 {
+				return fiber.NewError(fiber.StatusBadRequest, "Outlet does not belong to this business")
+			}
+
+			promotion.OutletID = &outletID
+		}
+
+		if err := checkPromotionTargets(tx, promotion); err != nil {
+			return err
+		}
+
+		return tx.Create(promotion).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create promotion: %+v", err)
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) UpdatePromotion(
+	c *fiber.Ctx, id string, req *validation.UpdatePromotion,
+) (*model.Promotion, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	promotion := new(model.Promotion)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(promotion, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkBusinessAccess(c, tx, promotion.BusinessID.String()); err != nil {
+			return err
+		}
+
+		applyPromotionChanges(promotion, req)
+
+		if err := checkPromotion(promotion); err != nil {
+			return err
+		}
+
+		if req.ProductIDs != nil || req.CategoryIDs != nil {
+			if err := checkPromotionTargets(tx, promotion); err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(promotion).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update promotion: %+v", err)
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// DeletePromotion removes a promotion, the sales that got it keep it by name.
+func (s *promotionService) DeletePromotion(c *fiber.Ctx, id string) error {
+	promotion, err := s.GetPromotionByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DB.WithContext(c.Context()).Delete(promotion).Error; err != nil {
+		s.Log.Errorf("Failed to delete promotion: %+v", err)
+		return err
+	}
+
+	return nil
+}
+
+func applyPromotionChanges(promotion *model.Promotion, req *validation.UpdatePromotion) {
+	if req.Name != "" {
+		promotion.Name = req.Name
+	}
+	if req.Description != nil {
+		promotion.Description = emptyToNil(*req.Description)
+	}
+	if req.Priority != nil {
+		promotion.Priority = *req.Priority
+	}
+	if req.Stackable != nil {
+		promotion.Stackable = *req.Stackable
+	}
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+	if req.StartDate != nil {
+		promotion.StartDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		promotion.EndDate = req.EndDate
+	}
+	if req.DaysOfWeek != nil {
+		promotion.DaysOfWeek = *req.DaysOfWeek
+	}
+	if req.StartTime != nil {
+		promotion.StartTime = emptyToNil(*req.StartTime)
+	}
+	if req.EndTime != nil {
+		promotion.EndTime = emptyToNil(*req.EndTime)
+	}
+	if req.MinSpend != nil {
+		promotion.MinSpend = *req.MinSpend
+	}
+	if req.ProductIDs != nil {
+		promotion.ProductIDs = parseUUIDs(*req.ProductIDs)
+	}
+	if req.CategoryIDs != nil {
+		promotion.CategoryIDs = parseUUIDs(*req.CategoryIDs)
+	}
+	if req.CustomerTiers != nil {
+		promotion.CustomerTiers = *req.CustomerTiers
+	}
+	if req.Value != nil {
+		promotion.Value = *req.Value
+	}
+	if req.BuyQuantity != nil {
+		promotion.BuyQuantity = *req.BuyQuantity
+	}
+	if req.GetQuantity != nil {
+		promotion.GetQuantity = *req.GetQuantity
+	}
+}
+
+// checkPromotion makes sure the schedule is consistent and the action has what it needs.
+func checkPromotion(promotion *model.Promotion) error {
+	if promotion.StartDate != nil && promotion.EndDate != nil && promotion.EndDate.Before(*promotion.StartDate) {
+		return fiber.NewError(fiber.StatusBadRequest, "End date must be after the start date")
+	}
+
+	if (promotion.StartTime == nil) != (promotion.EndTime == nil) {
+		return fiber.NewError(fiber.StatusBadRequest, "Set both the start and the end time")
+	}
+	if promotion.StartTime != nil && *promotion.StartTime == *promotion.EndTime {
+		return fiber.NewError(fiber.StatusBadRequest, "Start and end time must differ")
+	}
+
+	switch promotion.Action {
+	case utils.PromotionPercentOff:
+		if promotion.Value <= 0 || promotion.Value > money.FromUnits(100) {
+			return fiber.NewError(fiber.StatusBadRequest, "Percent off must be more than 0 and at most 100")
+		}
+	case utils.PromotionAmountOff:
+		if promotion.Value <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Amount off must be more than 0")
+		}
+	case utils.PromotionBuyXGetY:
+		if promotion.BuyQuantity < 1 || promotion.GetQuantity < 1 {
+			return fiber.NewError(fiber.StatusBadRequest, "Buy x get y needs a buy and a get quantity")
+		}
+	case utils.PromotionCheapestFree:
+		if promotion.BuyQuantity < 2 {
+			return fiber.NewError(fiber.StatusBadRequest, "Cheapest item free needs a buy quantity of at least 2")
+		}
+	case utils.PromotionBundlePrice:
+		if len(promotion.ProductIDs) < 2 {
+			return fiber.NewError(fiber.StatusBadRequest, "A bundle needs at least two products")
+		}
+		if promotion.Value <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Bundle price must be more than 0")
+		}
+	}
+
+	return nil
+}
+
+// checkPromotionTargets makes sure the products and categories of a promotion belong to its business.
+func checkPromotionTargets(tx *gorm.DB, promotion *model.Promotion) error {
+	if len(promotion.ProductIDs) > 0 {
+		var products int64
+		if err := tx.Model(&model.Product{}).
+			Where("id IN ? AND business_id = ?", promotion.ProductIDs, promotion.BusinessID).
+			Count(&products).Error; err != nil {
+			return err
+		}
+		if int(products) != len(promotion.ProductIDs) {
+			return fiber.NewError(fiber.StatusBadRequest, "Promotion products must belong to the business")
+		}
+	}
+
+	if len(promotion.CategoryIDs) > 0 {
+		var categories int64
+		if err := tx.Model(&model.ProductCategory{}).
+			Where("id IN ? AND business_id = ?", promotion.CategoryIDs, promotion.BusinessID).
+			Count(&categories).Error; err != nil {
+			return err
+		}
+		if int(categories) != len(promotion.CategoryIDs) {
+			return fiber.NewError(fiber.StatusBadRequest, "Promotion categories must belong to the business")
+		}
+	}
+
+	return nil
+}
+
+// parseUUIDs turns validated ids into uuids, dropping repeated ones.
+func parseUUIDs(ids []string) []uuid.UUID {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		value := uuid.MustParse(id)
+		if !seen[value] {
+			seen[value] = true
+			parsed = append(parsed, value)
+		}
+	}
+
+	return parsed
+}
+
+// applySalePromotions works out the promotions the sale gets at its outlet and adds them to its
+// discount. They are kept on sale.SalePromotions, saveSalePromotions stores them for an existing
+// sale and they are created with a new one.
+func applySalePromotions(tx *gorm.DB, sale *model.Sale, categories map[uuid.UUID]uuid.UUID) error {
+	sale.SalePromotions = []model.SalePromotion{}
+	if len(sale.SaleItems) == 0 {
+		return nil
+	}
+
+	var promotions []model.Promotion
+	if err := tx.Where("is_active = ?", true).
+		Where("outlet_id = ? OR (outlet_id IS NULL AND business_id = (?))", sale.OutletID,
+			tx.Model(&model.Outlet{}).Select("business_id").Where("id = ?", sale.OutletID)).
+		Order("created_at asc").
+		Find(&promotions).Error; err != nil {
+		return err
+	}
+	if len(promotions) == 0 {
+		return nil
+	}
+
+	promotionSale := utils.PromotionSale{At: sale.SaleDate}
+	if promotionSale.At.IsZero() {
+		promotionSale.At = time.Now()
+	}
+
+	// Schedules are in local time, as on the receipts, whatever zone the sale date was read in
+	promotionSale.At = promotionSale.At.In(time.Local)
+
+	if sale.CustomerID != nil {
+		var tiers []string
+		if err := tx.Model(&model.Customer{}).Where("id = ? AND tier IS NOT NULL", sale.CustomerID).
+			Pluck("tier", &tiers).Error; err != nil {
+			return err
+		}
+		if len(tiers) > 0 {
+			promotionSale.CustomerTier = tiers[0]
+		}
+	}
+
+	lines := make([]utils.PromotionLine, len(sale.SaleItems))
+	for i, item := range sale.SaleItems {
+		lines[i] = utils.PromotionLine{
+			ProductID:  item.ProductID,
+			CategoryID: categories[item.ProductID],
+			Quantity:   item.Quantity,
+			Amount:     item.Total,
+		}
+	}
+
+	rules := make([]utils.PromotionRule, len(promotions))
+	for i := range promotions {
+		rules[i] = promotionRule(&promotions[i])
+	}
+
+	for _, discount := range utils.ApplyPromotions(lines, rules, promotionSale, config.Currency) {
+		promotionID := discount.RuleID
+		sale.Discount += discount.Discount
+		sale.SalePromotions = append(sale.SalePromotions, model.SalePromotion{
+			SaleID:      sale.ID,
+			PromotionID: &promotionID,
+			Name:        discount.Name,
+			Discount:    discount.Discount,
+		})
+	}
+
+	return nil
+}
+
+func promotionRule(promotion *model.Promotion) utils.PromotionRule {
+	schedule := utils.PromotionSchedule{
+		StartDate: promotion.StartDate,
+		EndDate:   promotion.EndDate,
+		Days:      promotion.DaysOfWeek,
+	}
+	if promotion.StartTime != nil && promotion.EndTime != nil {
+		schedule.StartTime = *promotion.StartTime
+		schedule.EndTime = *promotion.EndTime
+	}
+
+	return utils.PromotionRule{
+		ID:            promotion.ID,
+		Name:          promotion.Name,
+		Priority:      promotion.Priority,
+		Stackable:     promotion.Stackable,
+		Schedule:      schedule,
+		CustomerTiers: promotion.CustomerTiers,
+		MinSpend:      promotion.MinSpend,
+		ProductIDs:    promotion.ProductIDs,
+		CategoryIDs:   promotion.CategoryIDs,
+		Action:        promotion.Action,
+		Value:         promotion.Value,
+		BuyQuantity:   promotion.BuyQuantity,
+		GetQuantity:   promotion.GetQuantity,
+	}
+}
+
+// saveSalePromotions replaces the stored promotions of an existing sale with the applied ones.
+func saveSalePromotions(tx *gorm.DB, sale *model.Sale) error {
+	if err := tx.Where("sale_id = ?", sale.ID).Delete(&model.SalePromotion{}).Error; err != nil {
+		return err
+	}
+
+	if len(sale.SalePromotions) == 0 {
+		return nil
+	}
+
+	for i := range sale.SalePromotions {
+		sale.SalePromotions[i].SaleID = sale.ID
+	}
+
+	return tx.Create(&sale.SalePromotions).Error
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/escpos"
+	"app/src/model"
+	"app/src/receipt"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReceiptService interface {
+	RenderReceipt(c *fiber.Ctx, saleID string, query *validation.QueryReceipt) (*escpos.Document, error)
+}
+
+type receiptService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewReceiptService(db *gorm.DB, validate *validator.Validate) ReceiptService {
+	return &receiptService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+// RenderReceipt lays out the receipt of a sale for a printer of its outlet, the default printer
+// when none is given. An explicit paper width or language wins over the printer and settings.
+func (s *receiptService) RenderReceipt(
+	c *fiber.Ctx, saleID string, query *validation.QueryReceipt,
+) (*escpos.Document, error) {
+	if err := s.Validate.Struct(query); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+
+	sale, err := loadReceiptSale(db, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkOutletAccess(c, db, sale.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	printer, err := findOutletPrinter(db, sale.OutletID, query.PrinterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.PaperWidth != 0 {
+		printer.PaperWidth = &query.PaperWidth
+	}
+
+	doc, err := renderReceipt(c.Context(), db, sale, printer, query.Language)
+	if err != nil {
+		s.Log.Errorf("Failed to render receipt: %+v", err)
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// findOutletPrinter loads a printer of the outlet, or its default printer when printerID is
+// empty. Without a default printer it returns an empty printer, laid out for 80mm paper.
+func findOutletPrinter(db *gorm.DB, outletID uuid.UUID, printerID string) (*model.Printer, error) {
+	printer := new(model.Printer)
+
+	query := db.Where("outlet_id = ?", outletID)
+	if printerID != "" {
+		query = query.Where("id = ?", printerID)
+	} else {
+		query = query.Where("default_printer = ?", true)
+	}
+
+	result := query.Limit(1).Find(printer)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		if printerID != "" {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Printer not found")
+		}
+		return new(model.Printer), nil
+	}
+
+	return printer, nil
+}
+
+// loadReceiptSale loads a sale with everything printed on its receipt.
+func loadReceiptSale(db *gorm.DB, saleID string) (*model.Sale, error) {
+	sale := new(model.Sale)
+
+	result := db.
+		Preload("Outlet.Business").
+		Preload("OutletStaff").
+		Preload("Table").
+		Preload("SaleItems", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("SaleItems.Product").
+		Preload("SaleItems.Taxes").
+		Preload("SalePayments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("SalePayments.PaymentMethod").
+		First(sale, "id = ?", saleID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+
+	return sale, result.Error
+}
+
+// renderReceipt lays out a sale loaded by loadReceiptSale for a printer. A logo that cannot be
+// loaded is left out rather than failing the receipt.
+func renderReceipt(
+	ctx context.Context, db *gorm.DB, sale *model.Sale, printer *model.Printer, language string,
+) (*escpos.Document, error) {
+	opts, err := receiptOptions(db, sale.OutletID, printer, language)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.QRCode = strings.ReplaceAll(opts.QRCode, "{invoice}", sale.InvoiceNumber)
+
+	if sale.Outlet != nil && sale.Outlet.Business != nil && sale.Outlet.Business.Logo != nil {
+		logo, logoErr := receipt.LoadLogo(ctx, *sale.Outlet.Business.Logo)
+		if logoErr != nil {
+			utils.Log.Warnf("Failed to load business logo: %+v", logoErr)
+		}
+		opts.Logo = logo
+	}
+
+	return receipt.Render(sale, opts), nil
+}
+
+// receiptOptions reads how an outlet prints on a printer, 80mm paper without a width. The language,
+// code page, footer and QR code come from the outlet settings, an explicit language wins.
+func receiptOptions(
+	db *gorm.DB, outletID uuid.UUID, printer *model.Printer, language string,
+) (receipt.Options, error) {
+	opts := receipt.Options{
+		Paper:    escpos.Paper80,
+		CodePage: escpos.DefaultCodePage,
+		Language: language,
+		Currency: config.Currency,
+	}
+
+	if printer.PaperWidth != nil {
+		opts.Paper = escpos.PaperFor(*printer.PaperWidth)
+	}
+
+	settings := []string{
+		config.SettingReceiptLanguage, config.SettingReceiptCodePage,
+		config.SettingReceiptFooter, config.SettingReceiptQRCode,
+	}
+	values := make(map[string]string, len(settings))
+	for _, key := range settings {
+		value, err := outletSetting(db, outletID, key)
+		if err != nil {
+			return opts, err
+		}
+		values[key] = value
+	}
+
+	if opts.Language == "" {
+		opts.Language = values[config.SettingReceiptLanguage]
+	}
+	if codePage, ok := escpos.LookupCodePage(values[config.SettingReceiptCodePage]); ok {
+		opts.CodePage = codePage
+	}
+	opts.Footer = values[config.SettingReceiptFooter]
+	opts.QRCode = values[config.SettingReceiptQRCode]
+
+	return opts, nil
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundService interface {
+	GetRefundsBySaleID(c *fiber.Ctx, saleID string) ([]model.Refund, error)
+	GetRefundByID(c *fiber.Ctx, id string) (*model.Refund, error)
+	CreateRefund(c *fiber.Ctx, saleID string, req *validation.CreateRefund) (*model.Refund, error)
+}
+
+type refundService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+// refundedLine is the quantity and amount already refunded for a single sale item.
+type refundedLine struct {
+	SaleItemID uuid.UUID
+	Quantity   int
+	Total      money.Amount
+}
+
+func NewRefundService(db *gorm.DB, validate *validator.Validate) RefundService {
+	return &refundService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *refundService) GetRefundsBySaleID(c *fiber.Ctx, saleID string) ([]model.Refund, error) {
+	var refunds []model.Refund
+	db := s.DB.WithContext(c.Context())
+
+	sale := new(model.Sale)
+	result := db.Select("id", "outlet_id").First(sale, "id = ?", saleID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed get sale by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, sale.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	result = db.
+		Preload("RefundItems").
+		Where("sale_id = ?", saleID).
+		Order("created_at asc").
+		Find(&refunds)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get refunds by sale id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	return refunds, nil
+}
+
+func (s *refundService) GetRefundByID(c *fiber.Ctx, id string) (*model.Refund, error) {
+	refund := new(model.Refund)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.Preload("RefundItems").First(refund, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Refund not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get refund by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, refund.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (s *refundService) CreateRefund(
+	c *fiber.Ctx, saleID string, req *validation.CreateRefund,
+) (*model.Refund, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	requested := make(map[uuid.UUID]int, len(req.Items))
+	for _, item := range req.Items {
+		requested[uuid.MustParse(item.SaleItemID)] += item.Quantity
+	}
+
+	refund := new(model.Refund)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale := new(model.Sale)
+
+		// Lock the sale so concurrent refunds cannot both pass the quantity check
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sale, "id = ?", saleID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		if sale.Status != config.SaleStatusPaid {
+			return fiber.NewError(fiber.StatusBadRequest, "Only paid sales can be refunded")
+		}
+
+		if err := tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+			return err
+		}
+
+		if req.Restock {
+			if err := checkRestockable(sale.SaleItems, requested); err != nil {
+				return err
+			}
+		}
+
+		paymentMethod := new(model.PaymentMethod)
+		result = tx.Where("id = ? AND outlet_id = ?", req.PaymentMethodID, sale.OutletID).First(paymentMethod)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "Payment method is not available for this outlet")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		refunded, err := s.refundedLines(tx, sale.ID)
+		if err != nil {
+			return err
+		}
+
+		items, total, fullyRefunded, err := buildRefundItems(sale.SaleItems, sale.Discount, refunded, requested)
+		if err != nil {
+			return err
+		}
+
+		refundable, err := refundableAmount(tx, sale)
+		if err != nil {
+			return err
+		}
+		if refundable <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Everything paid for this sale was refunded already")
+		}
+		total = capRefundItems(items, total, refundable)
+
+		var refundCount int64
+		if err = tx.Model(&model.Refund{}).Where("sale_id = ?", sale.ID).Count(&refundCount).Error; err != nil {
+			return err
+		}
+
+		refund = &model.Refund{
+			SaleID:          sale.ID,
+			OutletID:        sale.OutletID,
+			PaymentMethodID: paymentMethod.ID,
+			RefundNumber:    fmt.Sprintf("RF-%s-%d", sale.InvoiceNumber, refundCount+1),
+			ReasonCode:      req.ReasonCode,
+			Restock:         req.Restock,
+			Total:           total,
+			RefundItems:     items,
+		}
+
+		if req.OutletStaffID != "" {
+			staffID := uuid.MustParse(req.OutletStaffID)
+			refund.OutletStaffID = &staffID
+		}
+
+		if req.Note != "" {
+			refund.Note = &req.Note
+		}
+
+		if err = tx.Create(refund).Error; err != nil {
+			return err
+		}
+
+		if req.Restock {
+			movements := make([]model.StockMovement, 0, len(refund.RefundItems))
+			for _, item := range refund.RefundItems {
+				movements = append(movements, model.StockMovement{
+					OutletID:    sale.OutletID,
+					ProductID:   item.ProductID,
+					Quantity:    item.Quantity,
+					Type:        config.StockMovementRefund,
+					ReferenceID: &refund.ID,
+				})
+			}
+
+			if err = tx.Create(&movements).Error; err != nil {
+				return err
+			}
+		}
+
+		if fullyRefunded {
+			if err = releaseSaleCoupons(tx, []uuid.UUID{sale.ID}); err != nil {
+				return err
+			}
+
+			return tx.Model(sale).Update("status", config.SaleStatusRefunded).Error
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create refund: %+v", err)
+		}
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// checkRestockable refuses to restock shares of a line split between checks, each share holds
+// the line's full quantity so restocking every share would count the goods several times.
+func checkRestockable(saleItems []model.SaleItem, requested map[uuid.UUID]int) error {
+	for _, saleItem := range saleItems {
+		if _, ok := requested[saleItem.ID]; ok && saleItem.Portion < 1 {
+			return fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Sale item %s is a share of a split line, refund it without restocking", saleItem.ID))
+		}
+	}
+
+	return nil
+}
+
+func (s *refundService) refundedLines(tx *gorm.DB, saleID uuid.UUID) (map[uuid.UUID]refundedLine, error) {
+	var lines []refundedLine
+
+	err := tx.Model(&model.RefundItem{}).
+		Select("refund_items.sale_item_id, SUM(refund_items.quantity) AS quantity, SUM(refund_items.total) AS total").
+		Joins("JOIN refunds ON refunds.id = refund_items.refund_id").
+		Where("refunds.sale_id = ?", saleID).
+		Group("refund_items.sale_item_id").
+		Scan(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refunded := make(map[uuid.UUID]refundedLine, len(lines))
+	for _, line := range lines {
+		refunded[line.SaleItemID] = line
+	}
+
+	return refunded, nil
+}
+
+// refundableAmount is what is left to refund of what was paid for a sale. The delivery fee and a
+// cash rounding the customer paid are never refunded.
+func refundableAmount(tx *gorm.DB, sale *model.Sale) (money.Amount, error) {
+	var paid, refunded money.Amount
+
+	if err := tx.Model(&model.SalePayment{}).
+		Where("sale_id = ? AND status = ?", sale.ID, config.PaymentStatusPaid).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&paid).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Model(&model.Refund{}).
+		Where("sale_id = ?", sale.ID).
+		Select("COALESCE(SUM(total), 0)").
+		Scan(&refunded).Error; err != nil {
+		return 0, err
+	}
+
+	return paid - sale.DeliveryFee - max(sale.CashRounding, 0) - refunded, nil
+}
+
+// capRefundItems lowers the refund to refundable when the items add up to more, which happens
+// when the cash rounding went in the customer's favour. The last items give up the difference.
+func capRefundItems(items []model.RefundItem, total, refundable money.Amount) money.Amount {
+	excess := total - refundable
+	for i := len(items) - 1; i >= 0 && excess > 0; i-- {
+		cut := min(excess, items[i].Total)
+		items[i].Total -= cut
+		excess -= cut
+	}
+
+	return min(total, refundable)
+}
+
+// buildRefundItems checks the requested quantities against what is still refundable
+// and prorates what was charged for each line, including its discount, its share of the
+// sale discount, service charge and tax, over the refunded units.
+func buildRefundItems(
+	saleItems []model.SaleItem, discount money.Amount, refunded map[uuid.UUID]refundedLine,
+	requested map[uuid.UUID]int,
+) ([]model.RefundItem, money.Amount, bool, error) {
+	saleItemIDs := make(map[uuid.UUID]struct{}, len(saleItems))
+	for _, saleItem := range saleItems {
+		saleItemIDs[saleItem.ID] = struct{}{}
+	}
+
+	for saleItemID := range requested {
+		if _, ok := saleItemIDs[saleItemID]; !ok {
+			return nil, 0, false, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Sale item %s does not belong to this sale", saleItemID))
+		}
+	}
+
+	// The sale discount is spread over the lines the same way it was when the sale was priced
+	amounts := make([]money.Amount, len(saleItems))
+	for i, saleItem := range saleItems {
+		amounts[i] = saleItem.Total
+	}
+	discounts := config.Currency.Allocate(discount, amounts)
+
+	items := make([]model.RefundItem, 0, len(requested))
+	var total money.Amount
+	fullyRefunded := true
+
+	for i, saleItem := range saleItems {
+		previous := refunded[saleItem.ID]
+		quantity, ok := requested[saleItem.ID]
+		if !ok {
+			if previous.Quantity < saleItem.Quantity {
+				fullyRefunded = false
+			}
+			continue
+		}
+
+		remaining := saleItem.Quantity - previous.Quantity
+		if quantity > remaining {
+			return nil, 0, false, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Cannot refund %d of sale item %s, only %d remaining", quantity, saleItem.ID, remaining))
+		}
+
+		// The last units take whatever is left so repeated partial refunds never drift from the line total
+		charged := saleItem.Total - discounts[i] + saleItem.ServiceCharge + saleItem.Tax - saleItem.TaxIncluded
+		amount := config.Currency.Ratio(charged, int64(quantity), int64(saleItem.Quantity))
+		if quantity == remaining {
+			amount = charged - previous.Total
+		} else {
+			fullyRefunded = false
+		}
+
+		items = append(items, model.RefundItem{
+			SaleItemID: saleItem.ID,
+			ProductID:  saleItem.ProductID,
+			Quantity:   quantity,
+			Total:      amount,
+		})
+		total += amount
+	}
+
+	return items, total, fullyRefunded, nil
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReportService interface {
+	GetEndOfDay(c *fiber.Ctx, outletID string, params *validation.QueryEndOfDay) (*response.EndOfDayReport, error)
+	GetTipPool(c *fiber.Ctx, outletID string, params *validation.QueryTipPool) (*response.TipPoolReport, error)
+}
+
+type reportService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewReportService(db *gorm.DB, validate *validator.Validate) ReportService {
+	return &reportService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *reportService) GetEndOfDay(
+	c *fiber.Ctx, outletID string, params *validation.QueryEndOfDay,
+) (*response.EndOfDayReport, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if params.Date != "" {
+		start, _ = time.ParseInLocation("2006-01-02", params.Date, time.Local)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 1)
+
+	report := &response.EndOfDayReport{
+		OutletID: uuid.MustParse(outletID),
+		Date:     start.Format("2006-01-02"),
+		Payments: []response.PaymentMethodTotal{},
+	}
+	db := s.DB.WithContext(c.Context())
+
+	// Refunded sales were still sold that day, their refunds are reported separately
+	err := db.Model(&model.Sale{}).
+		Select(`COUNT(*) AS sales,
+			COALESCE(SUM(total), 0) AS total,
+			COALESCE(SUM(discount), 0) AS discount,
+			COALESCE(SUM(service_charge), 0) AS service_charge,
+			COALESCE(SUM(tax), 0) AS tax,
+			COALESCE(SUM(tax_included), 0) AS tax_included,
+			COALESCE(SUM(cash_rounding), 0) AS cash_rounding,
+			COALESCE(SUM(grand_total), 0) AS grand_total`).
+		Where("outlet_id = ? AND status IN ?", outletID, []string{config.SaleStatusPaid, config.SaleStatusRefunded}).
+		Where("sale_date >= ? AND sale_date < ?", start, end).
+		Scan(report).Error
+	if err != nil {
+		s.Log.Errorf("Failed to get end of day sales: %+v", err)
+		return nil, err
+	}
+
+	err = db.Model(&model.Refund{}).
+		Select("COALESCE(SUM(total), 0)").
+		Where("outlet_id = ? AND created_at >= ? AND created_at < ?", outletID, start, end).
+		Scan(&report.Refunds).Error
+	if err != nil {
+		s.Log.Errorf("Failed to get end of day refunds: %+v", err)
+		return nil, err
+	}
+
+	err = db.Model(&model.SalePayment{}).
+		Select(`payment_methods.id AS payment_method_id, payment_methods.name, payment_methods.type,
+			COUNT(*) AS count, SUM(sale_payments.amount) AS amount, SUM(sale_payments.change) AS change,
+			SUM(sale_payments.tip) AS tips`).
+		Joins("JOIN sales ON sales.id = sale_payments.sale_id").
+		Joins("JOIN payment_methods ON payment_methods.id = sale_payments.payment_method_id").
+		Where("sales.outlet_id = ? AND sale_payments.status = ?", outletID, config.PaymentStatusPaid).
+		Where("sale_payments.paid_at >= ? AND sale_payments.paid_at < ?", start, end).
+		Group("payment_methods.id, payment_methods.name, payment_methods.type").
+		Order("payment_methods.name asc").
+		Scan(&report.Payments).Error
+	if err != nil {
+		s.Log.Errorf("Failed to get end of day payments: %+v", err)
+		return nil, err
+	}
+
+	for _, payment := range report.Payments {
+		report.Tips += payment.Tips
+	}
+
+	return report, nil
+}
+
+func (s *reportService) GetTipPool(
+	c *fiber.Ctx, outletID string, params *validation.QueryTipPool,
+) (*response.TipPoolReport, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	from, _ := time.Parse(time.RFC3339, params.From)
+	to, _ := time.Parse(time.RFC3339, params.To)
+	if !to.After(from) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "The end of the shift must be after its start")
+	}
+
+	db := s.DB.WithContext(c.Context())
+	report := &response.TipPoolReport{
+		OutletID: uuid.MustParse(outletID),
+		From:     from,
+		To:       to,
+		Staff:    []response.TipShare{},
+	}
+
+	rule, roleWeights, err := outletTipPoolRule(db, report.OutletID)
+	if err != nil {
+		s.Log.Errorf("Failed to get tip pool rule: %+v", err)
+		return nil, err
+	}
+	report.Rule = rule
+
+	shares, err := tipPoolShares(db, report)
+	if err != nil {
+		s.Log.Errorf("Failed to get tip pool: %+v", err)
+		return nil, err
+	}
+
+	weights := make([]float64, len(shares))
+	for i, share := range shares {
+		weights[i] = tipPoolWeight(share, rule, roleWeights)
+	}
+
+	amounts := utils.DistributeTips(report.Tips, weights, config.Currency)
+	for i := range shares {
+		shares[i].Weight = weights[i]
+		shares[i].Share = amounts[i]
+		report.Distributed += amounts[i]
+	}
+
+	report.Undistributed = report.Tips - report.Distributed
+	report.Staff = shares
+	return report, nil
+}
+
+// tipPoolShares totals the tips paid during the shift and lists the staff who worked it or
+// collected tips in it, with their hours and collected tips filled in.
+func tipPoolShares(db *gorm.DB, report *response.TipPoolReport) ([]response.TipShare, error) {
+	var collected []struct {
+		OutletStaffID *uuid.UUID
+		Tips          money.Amount
+	}
+
+	err := db.Model(&model.SalePayment{}).
+		Select("sale_payments.outlet_staff_id, SUM(sale_payments.tip) AS tips").
+		Joins("JOIN sales ON sales.id = sale_payments.sale_id").
+		Where("sales.outlet_id = ? AND sale_payments.status = ?", report.OutletID, config.PaymentStatusPaid).
+		Where("sale_payments.paid_at >= ? AND sale_payments.paid_at < ?", report.From, report.To).
+		Where("sale_payments.tip > 0").
+		Group("sale_payments.outlet_staff_id").
+		Scan(&collected).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var shifts []model.StaffShift
+	err = db.Where("outlet_id = ? AND clock_in < ? AND (clock_out IS NULL OR clock_out > ?)",
+		report.OutletID, report.To, report.From).
+		Find(&shifts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hours := shiftHours(shifts, report.From, report.To)
+
+	tips := make(map[uuid.UUID]money.Amount)
+	for _, row := range collected {
+		report.Tips += row.Tips
+		if row.OutletStaffID != nil {
+			tips[*row.OutletStaffID] += row.Tips
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(hours)+len(tips))
+	for id := range hours {
+		ids = append(ids, id)
+	}
+	for id := range tips {
+		if _, ok := hours[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return []response.TipShare{}, nil
+	}
+
+	var staff []model.OutletStaff
+	if err = db.Where("id IN ?", ids).Order("name asc").Find(&staff).Error; err != nil {
+		return nil, err
+	}
+
+	shares := make([]response.TipShare, 0, len(staff))
+	for _, member := range staff {
+		shares = append(shares, response.TipShare{
+			OutletStaffID: member.ID,
+			Name:          member.Name,
+			Role:          member.Role,
+			Hours:         math.Round(hours[member.ID]*100) / 100,
+			Collected:     tips[member.ID],
+		})
+	}
+
+	return shares, nil
+}
+
+// shiftHours adds up the hours each staff member worked between from and to. A shift that is
+// still open counts until now.
+func shiftHours(shifts []model.StaffShift, from, to time.Time) map[uuid.UUID]float64 {
+	hours := make(map[uuid.UUID]float64)
+
+	end := to
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+
+	for _, shift := range shifts {
+		clockOut := end
+		if shift.ClockOut != nil && shift.ClockOut.Before(end) {
+			clockOut = *shift.ClockOut
+		}
+
+		clockIn := shift.ClockIn
+		if clockIn.Before(from) {
+			clockIn = from
+		}
+
+		if clockOut.After(clockIn) {
+			hours[shift.OutletStaffID] += clockOut.Sub(clockIn).Hours()
+		}
+	}
+
+	return hours
+}
+
+// tipPoolWeight is a staff member's part of the pool under the outlet's rule. Only staff who
+// worked during the shift take part.
+func tipPoolWeight(share response.TipShare, rule string, roleWeights map[string]float64) float64 {
+	if share.Hours <= 0 {
+		return 0
+	}
+
+	switch rule {
+	case config.TipPoolHours:
+		return share.Hours
+	case config.TipPoolRole:
+		if weight, ok := roleWeights[share.Role]; ok {
+			return weight
+		}
+		return 1
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,790 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationService interface {
+	GetReservations(c *fiber.Ctx, outletID string, params *validation.QueryReservation) ([]model.Reservation, error)
+	GetReservationByID(c *fiber.Ctx, id string) (*model.Reservation, error)
+	CreateReservation(c *fiber.Ctx, outletID string, req *validation.CreateReservation) (*model.Reservation, error)
+	UpdateReservation(c *fiber.Ctx, id string, req *validation.UpdateReservation) (*model.Reservation, error)
+	UpdateReservationStatus(
+		c *fiber.Ctx, id string, req *validation.UpdateReservationStatus,
+	) (*model.Reservation, error)
+	SuggestTables(c *fiber.Ctx, outletID string, params *validation.QueryTableSuggestion) ([]model.Table, error)
+	GetWaitlist(c *fiber.Ctx, outletID string) ([]response.WaitlistEntry, error)
+	CreateWaitlistEntry(
+		c *fiber.Ctx, outletID string, req *validation.CreateWaitlistEntry,
+	) (*response.WaitlistEntry, error)
+	UpdateWaitlistEntry(c *fiber.Ctx, id string, req *validation.UpdateWaitlistEntry) (*response.WaitlistEntry, error)
+	SendReservationReminders(ctx context.Context) (int, error)
+}
+
+type reservationService struct {
+	Log          *logrus.Logger
+	DB           *gorm.DB
+	Validate     *validator.Validate
+	EmailService EmailService
+}
+
+func NewReservationService(
+	db *gorm.DB, validate *validator.Validate, emailService EmailService,
+) ReservationService {
+	return &reservationService{
+		Log:          utils.Log,
+		DB:           db,
+		Validate:     validate,
+		EmailService: emailService,
+	}
+}
+
+// Reservations in these states hold their table
+var activeReservationStatuses = []string{
+	config.ReservationStatusBooked, config.ReservationStatusConfirmed, config.ReservationStatusSeated,
+}
+
+var reservationTransitions = map[string][]string{
+	config.ReservationStatusBooked: {
+		config.ReservationStatusConfirmed, config.ReservationStatusSeated,
+		config.ReservationStatusCancelled, config.ReservationStatusNoShow,
+	},
+	config.ReservationStatusConfirmed: {
+		config.ReservationStatusSeated, config.ReservationStatusCancelled, config.ReservationStatusNoShow,
+	},
+	config.ReservationStatusSeated: {config.ReservationStatusCompleted},
+}
+
+// Two bookings of a table overlap when each starts before the other ends
+const reservationOverlap = "reservations.reserved_at < ? AND " +
+	"reservations.reserved_at + reservations.duration_minutes * INTERVAL '1 minute' > ?"
+
+// GetReservations lists the reservations of an outlet on a day, today by default.
+func (s *reservationService) GetReservations(
+	c *fiber.Ctx, outletID string, params *validation.QueryReservation,
+) ([]model.Reservation, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if params.Date != "" {
+		start, _ = time.ParseInLocation("2006-01-02", params.Date, time.Local)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+
+	var reservations []model.Reservation
+	query := s.DB.WithContext(c.Context()).
+		Where("outlet_id = ? AND reserved_at >= ? AND reserved_at < ?", outletID, start, start.AddDate(0, 0, 1))
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	result := query.Order("reserved_at asc, created_at asc").Find(&reservations)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get reservations: %+v", result.Error)
+	}
+
+	return reservations, result.Error
+}
+
+func (s *reservationService) GetReservationByID(c *fiber.Ctx, id string) (*model.Reservation, error) {
+	reservation := new(model.Reservation)
+
+	result := s.DB.WithContext(c.Context()).First(reservation, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Reservation not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get reservation by id: %+v", result.Error)
+	}
+
+	return reservation, result.Error
+}
+
+// CreateReservation books a table and emails the guest a confirmation.
+func (s *reservationService) CreateReservation(
+	c *fiber.Ctx, outletID string, req *validation.CreateReservation,
+) (*model.Reservation, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if !req.ReservedAt.After(time.Now()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Reservations must be in the future")
+	}
+
+	reservation := &model.Reservation{
+		OutletID:        uuid.MustParse(outletID),
+		Name:            req.Name,
+		PartySize:       req.PartySize,
+		ReservedAt:      req.ReservedAt,
+		DurationMinutes: req.DurationMinutes,
+		Status:          config.ReservationStatusBooked,
+	}
+
+	if req.Phone != "" {
+		reservation.Phone = &req.Phone
+	}
+	if req.Email != "" {
+		reservation.Email = &req.Email
+	}
+	if req.Note != "" {
+		reservation.Note = &req.Note
+	}
+
+	outlet := new(model.Outlet)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if outlet, err = findOutlet(tx, outletID); err != nil {
+			return err
+		}
+
+		if req.CustomerID != "" {
+			customer, customerErr := findOutletCustomer(tx, outlet.ID, req.CustomerID)
+			if customerErr != nil {
+				return customerErr
+			}
+
+			reservation.CustomerID = &customer.ID
+			if reservation.Name == "" {
+				reservation.Name = customer.Name
+			}
+			if reservation.Phone == nil {
+				reservation.Phone = customer.Phone
+			}
+			if reservation.Email == nil {
+				reservation.Email = customer.Email
+			}
+		}
+
+		if reservation.DurationMinutes == 0 {
+			reservation.DurationMinutes, err = outletSettingInt(
+				tx, outlet.ID, config.SettingReservationMinutes, config.ReservationMinutes,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = assignReservationTable(tx, reservation, req.TableID); err != nil {
+			return err
+		}
+
+		return tx.Create(reservation).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create reservation: %+v", err)
+		}
+		return nil, err
+	}
+
+	s.sendConfirmation(c, outlet.Name, reservation)
+
+	return reservation, nil
+}
+
+// sendConfirmation emails the guest, a failed email does not undo the reservation.
+func (s *reservationService) sendConfirmation(c *fiber.Ctx, outletName string, reservation *model.Reservation) {
+	if reservation.Email == nil ||
+		s.EmailService.SendReservationConfirmationEmail(*reservation.Email, outletName, reservation) != nil {
+		return
+	}
+
+	now := time.Now()
+	reservation.ConfirmationSentAt = &now
+
+	if err := s.DB.WithContext(c.Context()).Model(reservation).
+		Update("confirmation_sent_at", now).Error; err != nil {
+		s.Log.Errorf("Failed to save reservation confirmation: %+v", err)
+	}
+}
+
+// UpdateReservation changes a booked or confirmed reservation. Its table is checked again when the
+// party, the time or the table change.
+func (s *reservationService) UpdateReservation(
+	c *fiber.Ctx, id string, req *validation.UpdateReservation,
+) (*model.Reservation, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	reservation := new(model.Reservation)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := lockReservation(tx, reservation, id); err != nil {
+			return err
+		}
+
+		if reservation.Status != config.ReservationStatusBooked &&
+			reservation.Status != config.ReservationStatusConfirmed {
+			return fiber.NewError(fiber.StatusBadRequest, "Only booked or confirmed reservations can be changed")
+		}
+
+		rebook := req.TableID != "" || req.PartySize != 0 || req.ReservedAt != nil || req.DurationMinutes != 0
+		if err := applyReservationChanges(reservation, req); err != nil {
+			return err
+		}
+
+		if rebook {
+			tableID := req.TableID
+			if tableID == "" && reservation.TableID != nil {
+				tableID = reservation.TableID.String()
+			}
+			if err := assignReservationTable(tx, reservation, tableID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(reservation).Select(
+			"table_id", "name", "phone", "email", "party_size", "reserved_at", "duration_minutes", "note",
+			"reminder_sent_at",
+		).Updates(reservation).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update reservation: %+v", err)
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// UpdateReservationStatus moves a reservation along. A no-show is counted on the reservation's customer.
+func (s *reservationService) UpdateReservationStatus(
+	c *fiber.Ctx, id string, req *validation.UpdateReservationStatus,
+) (*model.Reservation, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	reservation := new(model.Reservation)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := lockReservation(tx, reservation, id); err != nil {
+			return err
+		}
+
+		if !slices.Contains(reservationTransitions[reservation.Status], req.Status) {
+			return fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("A %s reservation cannot be moved to %s", reservation.Status, req.Status))
+		}
+
+		now := time.Now()
+		reservation.Status = req.Status
+		updates := map[string]interface{}{"status": reservation.Status}
+
+		switch req.Status {
+		case config.ReservationStatusSeated:
+			reservation.SeatedAt = &now
+			updates["seated_at"] = now
+		case config.ReservationStatusCancelled:
+			reservation.CancelledAt = &now
+			updates["cancelled_at"] = now
+		case config.ReservationStatusNoShow:
+			if reservation.CustomerID != nil {
+				if err := tx.Model(&model.Customer{}).Where("id = ?", reservation.CustomerID).Updates(map[string]interface{}{
+					"no_show_count":   gorm.Expr("no_show_count + 1"),
+					"last_no_show_at": now,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(reservation).Updates(updates).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update reservation status: %+v", err)
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// SuggestTables lists the tables free for the party at that time, the best fit first.
+func (s *reservationService) SuggestTables(
+	c *fiber.Ctx, outletID string, params *validation.QueryTableSuggestion,
+) ([]model.Table, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	start, _ := time.Parse(time.RFC3339, params.ReservedAt)
+	db := s.DB.WithContext(c.Context())
+
+	minutes := params.DurationMinutes
+	if minutes == 0 {
+		var err error
+		minutes, err = outletSettingInt(db, uuid.MustParse(outletID), config.SettingReservationMinutes,
+			config.ReservationMinutes)
+		if err != nil {
+			s.Log.Errorf("Failed to get reservation minutes: %+v", err)
+			return nil, err
+		}
+	}
+
+	tables, err := freeTables(db, uuid.MustParse(outletID), params.PartySize, start,
+		start.Add(time.Duration(minutes)*time.Minute), uuid.Nil)
+	if err != nil {
+		s.Log.Errorf("Failed to suggest tables: %+v", err)
+	}
+
+	return tables, err
+}
+
+// GetWaitlist lists the waiting parties in the order they arrived with their current estimated wait.
+func (s *reservationService) GetWaitlist(c *fiber.Ctx, outletID string) ([]response.WaitlistEntry, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+
+	var entries []model.WaitlistEntry
+	if err := db.Where("outlet_id = ? AND status = ?", outletID, config.WaitlistStatusWaiting).
+		Order("created_at asc").
+		Find(&entries).Error; err != nil {
+		s.Log.Errorf("Failed to get waitlist: %+v", err)
+		return nil, err
+	}
+
+	waitlist := make([]response.WaitlistEntry, len(entries))
+	freeAt := map[int][]time.Time{}
+	now := time.Now()
+
+	turn, err := outletTableTurn(db, uuid.MustParse(outletID))
+	if err != nil {
+		s.Log.Errorf("Failed to get table turn: %+v", err)
+		return nil, err
+	}
+
+	for i := range entries {
+		tables, ok := freeAt[entries[i].PartySize]
+		if !ok {
+			if tables, err = tableFreeTimes(db, entries[i].OutletID, entries[i].PartySize, turn, now); err != nil {
+				s.Log.Errorf("Failed to estimate waits: %+v", err)
+				return nil, err
+			}
+			freeAt[entries[i].PartySize] = tables
+		}
+
+		waitlist[i] = response.WaitlistEntry{
+			WaitlistEntry:        entries[i],
+			Position:             i + 1,
+			EstimatedWaitMinutes: waitMinutes(utils.EstimateWait(tables, i, turn, now)),
+		}
+	}
+
+	return waitlist, nil
+}
+
+// CreateWaitlistEntry puts a walk-in party at the end of the waitlist and quotes its wait.
+func (s *reservationService) CreateWaitlistEntry(
+	c *fiber.Ctx, outletID string, req *validation.CreateWaitlistEntry,
+) (*response.WaitlistEntry, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	entry := &model.WaitlistEntry{
+		OutletID:  uuid.MustParse(outletID),
+		Name:      req.Name,
+		PartySize: req.PartySize,
+		Status:    config.WaitlistStatusWaiting,
+	}
+
+	if req.Phone != "" {
+		entry.Phone = &req.Phone
+	}
+	if req.Note != "" {
+		entry.Note = &req.Note
+	}
+
+	var ahead int64
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if _, err := findOutlet(tx, outletID); err != nil {
+			return err
+		}
+
+		if req.CustomerID != "" {
+			customer, err := findOutletCustomer(tx, entry.OutletID, req.CustomerID)
+			if err != nil {
+				return err
+			}
+
+			entry.CustomerID = &customer.ID
+			if entry.Name == "" {
+				entry.Name = customer.Name
+			}
+			if entry.Phone == nil {
+				entry.Phone = customer.Phone
+			}
+		}
+
+		if err := tx.Model(&model.WaitlistEntry{}).
+			Where("outlet_id = ? AND status = ?", entry.OutletID, config.WaitlistStatusWaiting).
+			Count(&ahead).Error; err != nil {
+			return err
+		}
+
+		turn, err := outletTableTurn(tx, entry.OutletID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		tables, err := tableFreeTimes(tx, entry.OutletID, entry.PartySize, turn, now)
+		if err != nil {
+			return err
+		}
+		entry.QuotedWaitMinutes = waitMinutes(utils.EstimateWait(tables, int(ahead), turn, now))
+
+		return tx.Create(entry).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create waitlist entry: %+v", err)
+		}
+		return nil, err
+	}
+
+	return &response.WaitlistEntry{
+		WaitlistEntry:        *entry,
+		Position:             int(ahead) + 1,
+		EstimatedWaitMinutes: entry.QuotedWaitMinutes,
+	}, nil
+}
+
+// UpdateWaitlistEntry takes a waiting party off the waitlist, seated at a table or gone.
+func (s *reservationService) UpdateWaitlistEntry(
+	c *fiber.Ctx, id string, req *validation.UpdateWaitlistEntry,
+) (*response.WaitlistEntry, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	entry := new(model.WaitlistEntry)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(entry, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Waitlist entry not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if entry.Status != config.WaitlistStatusWaiting {
+			return fiber.NewError(fiber.StatusBadRequest, "The party is no longer waiting")
+		}
+
+		now := time.Now()
+		entry.Status = req.Status
+		updates := map[string]interface{}{"status": entry.Status}
+
+		if entry.Status == config.WaitlistStatusSeated {
+			if req.TableID != "" {
+				table, err := lockTable(tx, entry.OutletID, uuid.MustParse(req.TableID))
+				if err != nil {
+					return err
+				}
+				entry.TableID = &table.ID
+				updates["table_id"] = entry.TableID
+			}
+			entry.SeatedAt = &now
+			updates["seated_at"] = now
+		} else {
+			entry.LeftAt = &now
+			updates["left_at"] = now
+		}
+
+		return tx.Model(entry).Updates(updates).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update waitlist entry: %+v", err)
+		}
+		return nil, err
+	}
+
+	return &response.WaitlistEntry{WaitlistEntry: *entry}, nil
+}
+
+// SendReservationReminders emails the guests whose reservation starts within the reminder lead,
+// it is run on a schedule. Reservations booked within the lead only get the confirmation.
+func (s *reservationService) SendReservationReminders(ctx context.Context) (int, error) {
+	db := s.DB.WithContext(ctx)
+	now := time.Now()
+
+	var reservations []model.Reservation
+	err := db.Preload("Outlet").
+		Where("status IN ? AND email IS NOT NULL AND reminder_sent_at IS NULL",
+			[]string{config.ReservationStatusBooked, config.ReservationStatusConfirmed}).
+		Where("reserved_at > ? AND reserved_at <= ?", now, now.Add(config.ReservationReminderLead)).
+		Where("reserved_at - created_at > ? * INTERVAL '1 minute'", int(config.ReservationReminderLead.Minutes())).
+		Find(&reservations).Error
+	if err != nil {
+		s.Log.Errorf("Failed to get reservations to remind: %+v", err)
+		return 0, err
+	}
+
+	sent := 0
+	for i := range reservations {
+		reservation := &reservations[i]
+
+		// Failed emails are logged and tried again on the next run
+		if s.EmailService.SendReservationReminderEmail(*reservation.Email, reservation.Outlet.Name, reservation) != nil {
+			continue
+		}
+
+		if err = db.Model(reservation).Update("reminder_sent_at", time.Now()).Error; err != nil {
+			s.Log.Errorf("Failed to save reservation reminder: %+v", err)
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func lockReservation(tx *gorm.DB, reservation *model.Reservation, id string) error {
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(reservation, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Reservation not found")
+	}
+
+	return result.Error
+}
+
+func applyReservationChanges(reservation *model.Reservation, req *validation.UpdateReservation) error {
+	if req.ReservedAt != nil {
+		if !req.ReservedAt.After(time.Now()) {
+			return fiber.NewError(fiber.StatusBadRequest, "Reservations must be in the future")
+		}
+		reservation.ReservedAt = *req.ReservedAt
+		reservation.ReminderSentAt = nil
+	}
+	if req.Name != "" {
+		reservation.Name = req.Name
+	}
+	if req.Phone != nil {
+		reservation.Phone = emptyToNil(*req.Phone)
+	}
+	if req.Email != nil {
+		reservation.Email = emptyToNil(*req.Email)
+	}
+	if req.PartySize != 0 {
+		reservation.PartySize = req.PartySize
+	}
+	if req.DurationMinutes != 0 {
+		reservation.DurationMinutes = req.DurationMinutes
+	}
+	if req.Note != nil {
+		reservation.Note = emptyToNil(*req.Note)
+	}
+
+	return nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// findOutletCustomer finds a customer of the business the outlet belongs to.
+func findOutletCustomer(tx *gorm.DB, outletID uuid.UUID, customerID string) (*model.Customer, error) {
+	customer := new(model.Customer)
+
+	result := tx.Limit(1).Find(customer, "id = ? AND business_id = (?)", customerID,
+		tx.Model(&model.Outlet{}).Select("business_id").Where("id = ?", outletID))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Customer does not belong to this business")
+	}
+
+	return customer, nil
+}
+
+// assignReservationTable books the given table, or the smallest free one that fits the party when
+// tableID is empty, after making sure it is not booked for an overlapping time.
+func assignReservationTable(tx *gorm.DB, reservation *model.Reservation, tableID string) error {
+	end := reservation.ReservedAt.Add(time.Duration(reservation.DurationMinutes) * time.Minute)
+
+	if tableID == "" {
+		tables, err := freeTables(tx, reservation.OutletID, reservation.PartySize, reservation.ReservedAt, end,
+			reservation.ID)
+		if err != nil {
+			return err
+		}
+		if len(tables) == 0 {
+			return fiber.NewError(fiber.StatusConflict, "No table is free for this party at that time")
+		}
+		tableID = tables[0].ID.String()
+	}
+
+	// The lock keeps two bookings from taking the same table at once
+	table, err := lockTable(tx, reservation.OutletID, uuid.MustParse(tableID))
+	if err != nil {
+		return err
+	}
+
+	if table.Capacity < reservation.PartySize {
+		return fiber.NewError(fiber.StatusBadRequest, "The party is larger than the table")
+	}
+
+	var conflicts int64
+	if err = tx.Model(&model.Reservation{}).
+		Where("table_id = ? AND status IN ? AND id <> ?", table.ID, activeReservationStatuses, reservation.ID).
+		Where(reservationOverlap, end, reservation.ReservedAt).
+		Count(&conflicts).Error; err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return fiber.NewError(fiber.StatusConflict, "Table is already booked at that time")
+	}
+
+	reservation.TableID = &table.ID
+	return nil
+}
+
+// freeTables lists the tables of the outlet that fit the party and have no booking overlapping start
+// to end, the smallest first. The reservation with excludeID does not count as a booking.
+func freeTables(
+	db *gorm.DB, outletID uuid.UUID, partySize int, start, end time.Time, excludeID uuid.UUID,
+) ([]model.Table, error) {
+	tables := []model.Table{}
+
+	err := db.Where("outlet_id = ? AND merged_into_id IS NULL AND capacity >= ?", outletID, partySize).
+		Where(`NOT EXISTS (SELECT 1 FROM reservations WHERE reservations.table_id = tables.id
+			AND reservations.status IN ? AND reservations.id <> ? AND `+reservationOverlap+`)`,
+			activeReservationStatuses, excludeID, end, start).
+		Order("capacity asc, name asc").
+		Find(&tables).Error
+
+	return tables, err
+}
+
+// tableFreeTimes estimates when each table that fits the party is free for a walk-in. Occupied tables
+// are expected to free up a turn after their first sale, and tables booked soon only after the booking.
+func tableFreeTimes(db *gorm.DB, outletID uuid.UUID, partySize int, turn time.Duration, now time.Time) (
+	[]time.Time, error,
+) {
+	var tables []struct {
+		Status          string
+		StatusChangedAt *time.Time
+		OccupiedSince   *time.Time
+		BookedUntil     *time.Time
+	}
+
+	err := db.Model(&model.Table{}).
+		Select(`tables.status, tables.status_changed_at,
+			(SELECT MIN(sales.sale_date) FROM sales WHERE sales.table_id = tables.id AND sales.status IN ?)
+				AS occupied_since,
+			(SELECT MAX(reservations.reserved_at + reservations.duration_minutes * INTERVAL '1 minute')
+				FROM reservations WHERE reservations.table_id = tables.id AND reservations.status IN ?
+				AND `+reservationOverlap+`) AS booked_until`,
+			[]string{config.SaleStatusUnpaid, config.SaleStatusHold},
+			activeReservationStatuses, now.Add(turn), now).
+		Where("outlet_id = ? AND merged_into_id IS NULL AND capacity >= ?", outletID, partySize).
+		Scan(&tables).Error
+	if err != nil {
+		return nil, err
+	}
+
+	freeAt := make([]time.Time, len(tables))
+	for i, table := range tables {
+		free := now
+		switch table.Status {
+		case config.TableStatusOccupied:
+			since := now
+			if table.OccupiedSince != nil {
+				since = *table.OccupiedSince
+			} else if table.StatusChangedAt != nil {
+				since = *table.StatusChangedAt
+			}
+			// Parties staying past their turn are expected to leave shortly
+			free = since.Add(turn)
+			if soon := now.Add(config.TableCleaningTime); free.Before(soon) {
+				free = soon
+			}
+		case config.TableStatusNeedsCleaning:
+			free = now.Add(config.TableCleaningTime)
+		}
+
+		if table.BookedUntil != nil && table.BookedUntil.After(free) {
+			free = *table.BookedUntil
+		}
+		freeAt[i] = free
+	}
+
+	return freeAt, nil
+}
+
+func outletTableTurn(db *gorm.DB, outletID uuid.UUID) (time.Duration, error) {
+	minutes, err := outletSettingInt(db, outletID, config.SettingTableTurnMinutes, config.TableTurnMinutes)
+	return time.Duration(minutes) * time.Minute, err
+}
+
+// waitMinutes rounds a wait up to the quoting step, in minutes.
+func waitMinutes(wait time.Duration) int {
+	steps := (wait + config.WaitRoundTo - 1) / config.WaitRoundTo
+	return int(steps * config.WaitRoundTo / time.Minute)
+}
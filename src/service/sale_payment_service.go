@@ -0,0 +1,434 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SalePaymentService interface {
+	GetPayments(c *fiber.Ctx, saleID string) ([]model.SalePayment, *response.SalePaymentSummary, error)
+	CreatePayment(
+		c *fiber.Ctx, saleID string, req *validation.CreateSalePayment,
+	) (*model.SalePayment, *response.SalePaymentSummary, error)
+	UpdatePayment(
+		c *fiber.Ctx, saleID, paymentID string, req *validation.UpdateSalePayment,
+	) (*model.SalePayment, *response.SalePaymentSummary, error)
+}
+
+type salePaymentService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewSalePaymentService(db *gorm.DB, validate *validator.Validate) SalePaymentService {
+	return &salePaymentService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *salePaymentService) GetPayments(
+	c *fiber.Ctx, saleID string,
+) ([]model.SalePayment, *response.SalePaymentSummary, error) {
+	sale := new(model.Sale)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.
+		Preload("SalePayments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		First(sale, "id = ?", saleID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get sale payments: %+v", result.Error)
+		return nil, nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, sale.OutletID.String()); err != nil {
+		return nil, nil, err
+	}
+
+	summary := summarizePayments(sale, sale.SalePayments)
+
+	return sale.SalePayments, &summary, nil
+}
+
+func (s *salePaymentService) CreatePayment(
+	c *fiber.Ctx, saleID string, req *validation.CreateSalePayment,
+) (*model.SalePayment, *response.SalePaymentSummary, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	payment := new(model.SalePayment)
+	summary := new(response.SalePaymentSummary)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale, payments, err := lockSaleWithPayments(tx, saleID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		if sale.Status != config.SaleStatusUnpaid && sale.Status != config.SaleStatusHold {
+			return fiber.NewError(fiber.StatusBadRequest, "Sale is not open for payment")
+		}
+
+		paymentMethod := new(model.PaymentMethod)
+		result := tx.Where("id = ? AND outlet_id = ?", req.PaymentMethodID, sale.OutletID).First(paymentMethod)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "Payment method is not available for this outlet")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		current := summarizePayments(sale, payments)
+		if current.Remaining <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Sale is already fully paid")
+		}
+
+		isCash := paymentMethod.Type == config.PaymentTypeCash
+		if isCash {
+			if err = applyCashRounding(tx, sale, current.Remaining, req.Amount); err != nil {
+				return err
+			}
+			current = summarizePayments(sale, payments)
+		}
+
+		amount, change, err := allocatePayment(current.Remaining, req.Amount, isCash)
+		if err != nil {
+			return err
+		}
+
+		staffID, err := tipStaffID(tx, sale, req.OutletStaffID)
+		if err != nil {
+			return err
+		}
+
+		// Tips are paid on top of the amount and never count towards the grand total
+		tip := req.Tip
+		if req.TipPercent > 0 {
+			tip = config.Currency.Percent(amount, req.TipPercent)
+		}
+
+		payment = &model.SalePayment{
+			SaleID:          sale.ID,
+			PaymentMethodID: paymentMethod.ID,
+			Amount:          amount,
+			Tendered:        req.Amount,
+			Change:          change,
+			Tip:             tip,
+			OutletStaffID:   staffID,
+			Status:          config.PaymentStatusPaid,
+		}
+
+		// Cash is settled at the counter, other tenders may wait for a terminal or gateway confirmation
+		if req.Pending && !isCash {
+			payment.Status = config.PaymentStatusPending
+		} else {
+			now := time.Now()
+			payment.PaidAt = &now
+		}
+
+		if req.Reference != "" {
+			payment.Reference = &req.Reference
+		}
+
+		if err = tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		*summary, err = settleSale(tx, sale, append(payments, *payment))
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create sale payment: %+v", err)
+		}
+		return nil, nil, err
+	}
+
+	return payment, summary, nil
+}
+
+func (s *salePaymentService) UpdatePayment(
+	c *fiber.Ctx, saleID, paymentID string, req *validation.UpdateSalePayment,
+) (*model.SalePayment, *response.SalePaymentSummary, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	payment := new(model.SalePayment)
+	summary := new(response.SalePaymentSummary)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale, payments, err := lockSaleWithPayments(tx, saleID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		index := -1
+		for i := range payments {
+			if payments[i].ID.String() == paymentID {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return fiber.NewError(fiber.StatusNotFound, "Payment not found")
+		}
+
+		if err = checkPaymentChange(tx, sale); err != nil {
+			return err
+		}
+
+		// Voiding a paid payment reopens the sale, which could then be voided without an approval
+		if payments[index].Status == config.PaymentStatusPaid && req.Status == config.PaymentStatusVoid {
+			if err = useApproval(tx, req.ApprovalIDs, sale, config.ApprovalActionVoidPayment,
+				payments[index].Amount); err != nil {
+				return err
+			}
+		}
+
+		if err = transitionPayment(&payments[index], req.Status); err != nil {
+			return err
+		}
+
+		if err = tx.Select("status", "paid_at").Save(&payments[index]).Error; err != nil {
+			return err
+		}
+
+		*payment = payments[index]
+		*summary, err = settleSale(tx, sale, payments)
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update sale payment: %+v", err)
+		}
+		return nil, nil, err
+	}
+
+	return payment, summary, nil
+}
+
+func lockSaleWithPayments(tx *gorm.DB, saleID string) (*model.Sale, []model.SalePayment, error) {
+	sale := new(model.Sale)
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sale, "id = ?", saleID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	var payments []model.SalePayment
+	if err := tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&payments).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return sale, payments, nil
+}
+
+// settleSale moves an open sale to paid once its confirmed payments cover the grand total,
+// and reopens a paid sale when a payment is voided afterwards.
+func settleSale(tx *gorm.DB, sale *model.Sale, payments []model.SalePayment) (response.SalePaymentSummary, error) {
+	summary := summarizePayments(sale, payments)
+	isOpen := sale.Status == config.SaleStatusUnpaid || sale.Status == config.SaleStatusHold
+
+	updates := map[string]interface{}{}
+	switch utils.SettleSale(isOpen, sale.Status == config.SaleStatusPaid, summary.Paid, sale.GrandTotal) {
+	case utils.SettlementPaid:
+		sale.Status = config.SaleStatusPaid
+		updates["status"] = sale.Status
+		for _, payment := range payments {
+			if payment.Status == config.PaymentStatusPaid {
+				updates["payment_method_id"] = payment.PaymentMethodID
+				break
+			}
+		}
+	case utils.SettlementReopened:
+		sale.Status = config.SaleStatusUnpaid
+		updates["status"] = sale.Status
+
+		// The cash rounding belonged to the tender that settled the sale
+		if sale.CashRounding != 0 {
+			sale.GrandTotal -= sale.CashRounding
+			sale.CashRounding = 0
+			updates["grand_total"] = sale.GrandTotal
+			updates["cash_rounding"] = sale.CashRounding
+		}
+	}
+
+	if len(updates) == 0 {
+		return summary, nil
+	}
+
+	if err := tx.Model(sale).Updates(updates).Error; err != nil {
+		return summary, err
+	}
+
+	var err error
+	if sale.Status == config.SaleStatusPaid {
+		err = releaseTable(tx, sale.TableID, config.TableStatusNeedsCleaning)
+	} else {
+		err = occupyTable(tx, sale.TableID)
+	}
+
+	return summarizePayments(sale, payments), err
+}
+
+// applyCashRounding rounds the balance to the outlet's cash increment when a cash tender settles
+// the sale, and records the difference on the sale so it shows as its own line.
+func applyCashRounding(tx *gorm.DB, sale *model.Sale, remaining, tendered money.Amount) error {
+	increment, mode, err := outletCashRounding(tx, sale.OutletID)
+	if err != nil {
+		return err
+	}
+
+	rounding := utils.CashRounding(remaining, tendered, increment, mode)
+	if rounding == 0 {
+		return nil
+	}
+
+	sale.CashRounding += rounding
+	sale.GrandTotal += rounding
+
+	return tx.Model(sale).Updates(map[string]interface{}{
+		"cash_rounding": sale.CashRounding,
+		"grand_total":   sale.GrandTotal,
+	}).Error
+}
+
+// tipStaffID returns the staff member a payment's tip goes to, the staff on the sale by default.
+func tipStaffID(tx *gorm.DB, sale *model.Sale, outletStaffID string) (*uuid.UUID, error) {
+	if outletStaffID == "" {
+		return &sale.OutletStaffID, nil
+	}
+
+	staffID := uuid.MustParse(outletStaffID)
+
+	var staff int64
+	if err := tx.Model(&model.OutletStaff{}).
+		Where("id = ? AND outlet_id = ?", staffID, sale.OutletID).
+		Count(&staff).Error; err != nil {
+		return nil, err
+	}
+	if staff == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Staff does not belong to this outlet")
+	}
+
+	return &staffID, nil
+}
+
+func summarizePayments(sale *model.Sale, payments []model.SalePayment) response.SalePaymentSummary {
+	summary := response.SalePaymentSummary{
+		GrandTotal:   sale.GrandTotal,
+		CashRounding: sale.CashRounding,
+		SaleStatus:   sale.Status,
+	}
+
+	for _, payment := range payments {
+		switch payment.Status {
+		case config.PaymentStatusPaid:
+			summary.Paid += payment.Amount
+			summary.Change += payment.Change
+			summary.Tips += payment.Tip
+		case config.PaymentStatusPending:
+			summary.Pending += payment.Amount
+		}
+	}
+
+	summary.Remaining = sale.GrandTotal - summary.Paid - summary.Pending
+	if summary.Remaining < 0 {
+		summary.Remaining = 0
+	}
+
+	return summary
+}
+
+// allocatePayment splits a tendered amount into the part applied to the sale and the change due.
+// Only cash may be over-tendered, every other method must match the balance or less.
+func allocatePayment(remaining, tendered money.Amount, isCash bool) (money.Amount, money.Amount, error) {
+	amount, change, ok := utils.SplitTender(remaining, tendered, isCash)
+	if !ok {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("Payment of %s exceeds the remaining balance of %s", tendered, remaining))
+	}
+
+	return amount, change, nil
+}
+
+// checkPaymentChange rejects changes to the payments of sales that are closed for good: void,
+// merged into another sale, or with refunds.
+func checkPaymentChange(tx *gorm.DB, sale *model.Sale) error {
+	switch sale.Status {
+	case config.SaleStatusVoid, config.SaleStatusMerged, config.SaleStatusRefunded:
+		return fiber.NewError(fiber.StatusBadRequest,
+			fmt.Sprintf("Payments of a %s sale cannot change", sale.Status))
+	}
+
+	var refunds int64
+	if err := tx.Model(&model.Refund{}).Where("sale_id = ?", sale.ID).Count(&refunds).Error; err != nil {
+		return err
+	}
+	if refunds > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Payments of a refunded sale cannot change")
+	}
+
+	return nil
+}
+
+func transitionPayment(payment *model.SalePayment, status string) error {
+	allowed := map[string][]string{
+		config.PaymentStatusPending: {config.PaymentStatusPaid, config.PaymentStatusFailed, config.PaymentStatusVoid},
+		config.PaymentStatusPaid:    {config.PaymentStatusVoid},
+	}
+
+	for _, next := range allowed[payment.Status] {
+		if next == status {
+			payment.Status = status
+			if status == config.PaymentStatusPaid {
+				now := time.Now()
+				payment.PaidAt = &now
+			}
+			return nil
+		}
+	}
+
+	return fiber.NewError(fiber.StatusBadRequest,
+		fmt.Sprintf("Payment cannot change from %s to %s", payment.Status, status))
+}
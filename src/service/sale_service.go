@@ -0,0 +1,1213 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SaleService interface {
+	GetSales(c *fiber.Ctx, params *validation.QuerySale) ([]model.Sale, int64, error)
+	GetSaleByID(c *fiber.Ctx, id string) (*model.Sale, error)
+	CreateSale(c *fiber.Ctx, req *validation.CreateSale) (*model.Sale, error)
+	AddSaleItems(c *fiber.Ctx, id string, req *validation.AddSaleItems) (*model.Sale, error)
+	RemoveSaleItem(c *fiber.Ctx, id, itemID string) (*model.Sale, error)
+	HoldSale(c *fiber.Ctx, id string) (*model.Sale, error)
+	ResumeSale(c *fiber.Ctx, id string) (*model.Sale, error)
+	TransferSale(c *fiber.Ctx, id string, req *validation.TransferSale) (*model.Sale, error)
+	SplitSale(c *fiber.Ctx, id string, req *validation.SplitSale) ([]model.Sale, error)
+	VoidSale(c *fiber.Ctx, id string, req *validation.VoidSale) (*model.Sale, error)
+	ApplyCoupon(c *fiber.Ctx, id string, req *validation.ApplyCoupon) (*model.Sale, error)
+	RemoveCoupon(c *fiber.Ctx, id, couponID string) (*model.Sale, error)
+	ExpireHeldSales(ctx context.Context) (int64, error)
+}
+
+// portionScale is the precision of SaleItem.Portion, fractions of a line are rounded to it.
+const portionScale = 10000
+
+type saleService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewSaleService(db *gorm.DB, validate *validator.Validate) SaleService {
+	return &saleService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *saleService) GetSales(c *fiber.Ctx, params *validation.QuerySale) ([]model.Sale, int64, error) {
+	var sales []model.Sale
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.Sale{}).Order("sale_date desc")
+
+	// Without an outlet the sales of every outlet the user works for are listed
+	if params.OutletID != "" {
+		if err := checkOutletAccess(c, s.DB, params.OutletID); err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("outlet_id = ?", params.OutletID)
+	} else {
+		var err error
+		if query, err = memberOutlets(c, s.DB, query); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if params.TableID != "" {
+		query = query.Where("table_id = ?", params.TableID)
+	}
+
+	if params.OrderType != "" {
+		query = query.Where("order_type = ?", params.OrderType)
+	}
+
+	if params.OutletStaffID != "" {
+		query = query.Where("outlet_staff_id = ?", params.OutletStaffID)
+	}
+
+	switch params.Status {
+	case "":
+	case "open":
+		query = query.Where("status IN ?", []string{config.SaleStatusUnpaid, config.SaleStatusHold})
+	default:
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count sales: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Preload("SaleItems.Taxes").Limit(params.Limit).Offset(offset).Find(&sales).Error; err != nil {
+		s.Log.Errorf("Failed to get sales: %+v", err)
+		return nil, 0, err
+	}
+
+	return sales, totalResults, nil
+}
+
+func (s *saleService) GetSaleByID(c *fiber.Ctx, id string) (*model.Sale, error) {
+	sale := new(model.Sale)
+
+	result := s.DB.WithContext(c.Context()).
+		Preload("SaleItems.Taxes").Preload("SaleCoupons.Coupon").Preload("SalePromotions").
+		First(sale, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get sale by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, s.DB, sale.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return sale, nil
+}
+
+func (s *saleService) CreateSale(c *fiber.Ctx, req *validation.CreateSale) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if err := checkOutletAccess(c, s.DB, req.OutletID); err != nil {
+		return nil, err
+	}
+
+	sale := &model.Sale{
+		ID:            uuid.New(),
+		OutletID:      uuid.MustParse(req.OutletID),
+		OutletStaffID: uuid.MustParse(req.OutletStaffID),
+		InvoiceNumber: newInvoiceNumber(time.Now()),
+		Status:        config.SaleStatusUnpaid,
+		SaleDate:      time.Now(),
+	}
+
+	if req.CustomerID != "" {
+		customerID := uuid.MustParse(req.CustomerID)
+		sale.CustomerID = &customerID
+	}
+
+	if req.Note != "" {
+		sale.Note = &req.Note
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		outlet := new(model.Outlet)
+		result := tx.First(outlet, "id = ?", sale.OutletID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Outlet not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletStaff(tx, sale.OutletID, sale.OutletStaffID); err != nil {
+			return err
+		}
+
+		if req.CustomerID != "" {
+			if _, err := findOutletCustomer(tx, sale.OutletID, req.CustomerID); err != nil {
+				return err
+			}
+		}
+
+		if err := applySaleOrder(tx, sale, &req.SaleOrder); err != nil {
+			return err
+		}
+
+		items, overridden, err := buildSaleItems(tx, outlet.BusinessID, sale.OrderType, req.Items)
+		if err != nil {
+			return err
+		}
+
+		sale.SaleItems = items
+		if err = repriceSale(tx, sale); err != nil {
+			return err
+		}
+
+		if err = tx.Create(sale).Error; err != nil {
+			return err
+		}
+
+		// After the sale exists, approvals used for it point to it
+		if err = authorizeSaleItems(tx, sale, items, overridden, req.ApprovalIDs); err != nil {
+			return err
+		}
+
+		return occupyTable(tx, sale.TableID)
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	return sale, nil
+}
+
+func (s *saleService) AddSaleItems(c *fiber.Ctx, id string, req *validation.AddSaleItems) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		outlet := new(model.Outlet)
+		if err := tx.First(outlet, "id = ?", sale.OutletID).Error; err != nil {
+			return err
+		}
+
+		items, overridden, err := buildSaleItems(tx, outlet.BusinessID, sale.OrderType, req.Items)
+		if err != nil {
+			return err
+		}
+
+		if err = authorizeSaleItems(tx, sale, items, overridden, req.ApprovalIDs); err != nil {
+			return err
+		}
+
+		for i := range items {
+			items[i].SaleID = sale.ID
+		}
+
+		if err = tx.Create(&items).Error; err != nil {
+			return err
+		}
+
+		sale.SaleItems = append(sale.SaleItems, items...)
+		return nil
+	})
+}
+
+func (s *saleService) RemoveSaleItem(c *fiber.Ctx, id, itemID string) (*model.Sale, error) {
+	var voided []model.KitchenItem
+
+	sale, err := s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		var payments int64
+		err := tx.Model(&model.SalePayment{}).
+			Where("sale_id = ? AND status IN ?", sale.ID,
+				[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+			Count(&payments).Error
+		if err != nil {
+			return err
+		}
+		if payments > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Void the payments before removing items")
+		}
+
+		for i, item := range sale.SaleItems {
+			if item.ID.String() != itemID {
+				continue
+			}
+
+			if voided, err = voidKitchenTickets(tx, sale, []model.SaleItem{item}); err != nil {
+				return err
+			}
+
+			if err = tx.Delete(&item).Error; err != nil {
+				return err
+			}
+
+			sale.SaleItems = append(sale.SaleItems[:i], sale.SaleItems[i+1:]...)
+			return nil
+		}
+
+		return fiber.NewError(fiber.StatusNotFound, "Sale item not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishKitchenItems(config.KitchenEventVoided, voided)
+
+	return sale, nil
+}
+
+func (s *saleService) HoldSale(c *fiber.Ctx, id string) (*model.Sale, error) {
+	return s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		if sale.Status == config.SaleStatusHold {
+			return fiber.NewError(fiber.StatusBadRequest, "Sale is already on hold")
+		}
+
+		minutes, err := outletSettingInt(tx, sale.OutletID, config.SettingHeldSaleExpiryMinutes, config.HeldSaleExpiryMins)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sale.Status = config.SaleStatusHold
+		sale.HeldAt = &now
+		sale.HoldExpiresAt = nil
+		if minutes > 0 {
+			expiresAt := now.Add(time.Duration(minutes) * time.Minute)
+			sale.HoldExpiresAt = &expiresAt
+		}
+
+		return nil
+	})
+}
+
+func (s *saleService) ResumeSale(c *fiber.Ctx, id string) (*model.Sale, error) {
+	return s.amendSale(c, id, func(_ *gorm.DB, sale *model.Sale) error {
+		if sale.Status != config.SaleStatusHold {
+			return fiber.NewError(fiber.StatusBadRequest, "Sale is not on hold")
+		}
+
+		if sale.HoldExpiresAt != nil && sale.HoldExpiresAt.Before(time.Now()) {
+			return fiber.NewError(fiber.StatusBadRequest, "Held sale has expired")
+		}
+
+		sale.Status = config.SaleStatusUnpaid
+		sale.HeldAt = nil
+		sale.HoldExpiresAt = nil
+
+		return nil
+	})
+}
+
+func (s *saleService) TransferSale(c *fiber.Ctx, id string, req *validation.TransferSale) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		staffID := uuid.MustParse(req.OutletStaffID)
+		if err := checkOutletStaff(tx, sale.OutletID, staffID); err != nil {
+			return err
+		}
+
+		sale.OutletStaffID = staffID
+		return nil
+	})
+}
+
+// VoidSale cancels a sale. An open sale can be voided once its payments are voided, a paid sale
+// needs a manager's approval for its grand total and its payments are voided with it.
+func (s *saleService) VoidSale(c *fiber.Ctx, id string, req *validation.VoidSale) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	sale := new(model.Sale)
+	var voided []model.KitchenItem
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sale, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		var payments int64
+		if err := tx.Model(&model.SalePayment{}).
+			Where("sale_id = ? AND status IN ?", sale.ID,
+				[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+			Count(&payments).Error; err != nil {
+			return err
+		}
+
+		switch sale.Status {
+		case config.SaleStatusUnpaid, config.SaleStatusHold:
+			if payments > 0 {
+				return fiber.NewError(fiber.StatusBadRequest, "Void the payments before voiding the sale")
+			}
+		case config.SaleStatusPaid:
+			if err := voidPaidSale(tx, sale, req.ApprovalID); err != nil {
+				return err
+			}
+		default:
+			return fiber.NewError(fiber.StatusBadRequest, "Only open or paid sales can be voided")
+		}
+
+		var err error
+		voided, err = markSaleVoid(tx, sale, req.Reason)
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to void sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	publishKitchenItems(config.KitchenEventVoided, voided)
+
+	return sale, nil
+}
+
+// ExpireHeldSales voids held sales whose hold has run out, it is run on a schedule. They are
+// voided the way VoidSale does, held sales with paid or pending payments are left for staff.
+func (s *saleService) ExpireHeldSales(ctx context.Context) (int64, error) {
+	var voided []model.KitchenItem
+	now := time.Now()
+
+	var sales []model.Sale
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND hold_expires_at < ?", config.SaleStatusHold, now).
+			Where("NOT EXISTS (?)", tx.Model(&model.SalePayment{}).Select("1").
+				Where("sale_payments.sale_id = sales.id AND sale_payments.status IN ?",
+					[]string{config.PaymentStatusPaid, config.PaymentStatusPending})).
+			Find(&sales).Error; err != nil {
+			return err
+		}
+
+		for i := range sales {
+			items, err := markSaleVoid(tx, &sales[i], config.SaleVoidReasonHoldExpired)
+			if err != nil {
+				return err
+			}
+			voided = append(voided, items...)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		s.Log.Errorf("Failed to expire held sales: %+v", err)
+		return 0, err
+	}
+
+	publishKitchenItems(config.KitchenEventVoided, voided)
+
+	return int64(len(sales)), nil
+}
+
+// markSaleVoid voids a locked sale that may be voided: its kitchen tickets are voided, its coupon
+// uses given back and its table freed when nothing else is open at it. It returns the kitchen
+// items to announce once the transaction commits.
+func markSaleVoid(tx *gorm.DB, sale *model.Sale, reason string) ([]model.KitchenItem, error) {
+	var items []model.SaleItem
+	if err := tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	voided, err := voidKitchenTickets(tx, sale, items)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sale.Status = config.SaleStatusVoid
+	sale.VoidReason = &reason
+	sale.VoidedAt = &now
+
+	if err = tx.Model(sale).Updates(map[string]interface{}{
+		"status":      sale.Status,
+		"void_reason": sale.VoidReason,
+		"voided_at":   sale.VoidedAt,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err = releaseSaleCoupons(tx, []uuid.UUID{sale.ID}); err != nil {
+		return nil, err
+	}
+
+	return voided, releaseTable(tx, sale.TableID, config.TableStatusAvailable)
+}
+
+// ApplyCoupon redeems a coupon code on an open sale, its discount is applied when the sale is
+// repriced.
+func (s *saleService) ApplyCoupon(c *fiber.Ctx, id string, req *validation.ApplyCoupon) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		return redeemCoupon(tx, sale, req.Code)
+	})
+}
+
+// RemoveCoupon takes a coupon off an open sale and gives its use back.
+func (s *saleService) RemoveCoupon(c *fiber.Ctx, id, couponID string) (*model.Sale, error) {
+	return s.amendSale(c, id, func(tx *gorm.DB, sale *model.Sale) error {
+		result := tx.Where("sale_id = ? AND coupon_id = ?", sale.ID, couponID).Delete(&model.SaleCoupon{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fiber.NewError(fiber.StatusNotFound, "Coupon is not applied to this sale")
+		}
+
+		return giveBackCoupons(tx, []uuid.UUID{uuid.MustParse(couponID)})
+	})
+}
+
+// amendSale locks an open sale of an outlet the user works for with its items, applies change and
+// saves the recalculated sale.
+func (s *saleService) amendSale(
+	c *fiber.Ctx, id string, change func(tx *gorm.DB, sale *model.Sale) error,
+) (*model.Sale, error) {
+	sale := new(model.Sale)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if sale, err = lockOpenSale(tx, id); err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		if err = tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+			return err
+		}
+
+		if err = change(tx, sale); err != nil {
+			return err
+		}
+
+		if err = repriceSale(tx, sale); err != nil {
+			return err
+		}
+
+		if err = saveSaleItemTaxes(tx, sale.SaleItems); err != nil {
+			return err
+		}
+
+		if err = saveSalePromotions(tx, sale); err != nil {
+			return err
+		}
+
+		return tx.Model(sale).Select(
+			"outlet_staff_id", "total", "discount", "service_charge", "tax", "tax_included", "grand_total",
+			"status", "held_at", "hold_expires_at",
+		).Updates(sale).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	return sale, nil
+}
+
+func (s *saleService) SplitSale(c *fiber.Ctx, id string, req *validation.SplitSale) ([]model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var sales []model.Sale
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		sale, err := lockOpenSale(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		if err = checkSplittable(tx, sale); err != nil {
+			return err
+		}
+
+		if err = tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+			return err
+		}
+
+		var groups [][]model.SaleItem
+		switch req.Mode {
+		case "items":
+			groups, err = splitByItems(sale.SaleItems, req.Checks)
+		case "seats":
+			groups, err = splitBySeats(sale.SaleItems)
+		default:
+			groups = splitEqually(sale.SaleItems, req.Parts)
+		}
+		if err != nil {
+			return err
+		}
+
+		sales = buildSplitSales(sale, groups)
+
+		rules, err := outletTaxRules(tx, sale.OutletID, sale.OrderType)
+		if err != nil {
+			return err
+		}
+
+		categories, err := productCategories(tx, sale.SaleItems)
+		if err != nil {
+			return err
+		}
+
+		for i := range sales {
+			if err = applySalePromotions(tx, &sales[i], categories); err != nil {
+				return err
+			}
+			recalculateSale(&sales[i], rules, categories)
+
+			items := sales[i].SaleItems
+			sales[i].SaleItems = nil
+
+			if i == 0 {
+				err = tx.Model(&sales[i]).Select(
+					"total", "discount", "service_charge", "tax", "tax_included", "grand_total",
+				).Updates(&sales[i]).Error
+				if err == nil {
+					err = saveSalePromotions(tx, &sales[i])
+				}
+			} else {
+				err = tx.Create(&sales[i]).Error
+			}
+			if err != nil {
+				return err
+			}
+
+			for j := range items {
+				items[j].SaleID = sales[i].ID
+				if items[j].ID == uuid.Nil {
+					err = tx.Omit("Taxes").Create(&items[j]).Error
+				} else {
+					err = tx.Omit("Taxes").Save(&items[j]).Error
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if err = saveSaleItemTaxes(tx, items); err != nil {
+				return err
+			}
+			sales[i].SaleItems = items
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to split sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// checkSplittable refuses to split a sale with payments or coupons, they cannot be divided between
+// the checks.
+func checkSplittable(tx *gorm.DB, sale *model.Sale) error {
+	var payments int64
+	if err := tx.Model(&model.SalePayment{}).
+		Where("sale_id = ? AND status IN ?", sale.ID,
+			[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+		Count(&payments).Error; err != nil {
+		return err
+	}
+	if payments > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Sales with payments cannot be split")
+	}
+
+	var coupons int64
+	if err := tx.Model(&model.SaleCoupon{}).Where("sale_id = ?", sale.ID).Count(&coupons).Error; err != nil {
+		return err
+	}
+	if coupons > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Remove the coupons before splitting the sale")
+	}
+
+	return nil
+}
+
+// voidPaidSale uses the manager's approval to void a paid sale and voids its payments.
+func voidPaidSale(tx *gorm.DB, sale *model.Sale, approvalID string) error {
+	var refunds int64
+	if err := tx.Model(&model.Refund{}).Where("sale_id = ?", sale.ID).Count(&refunds).Error; err != nil {
+		return err
+	}
+	if refunds > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Refunded sales cannot be voided")
+	}
+
+	var approvalIDs []string
+	if approvalID != "" {
+		approvalIDs = append(approvalIDs, approvalID)
+	}
+
+	if err := useApproval(tx, approvalIDs, sale, config.ApprovalActionVoidSale, sale.GrandTotal); err != nil {
+		return err
+	}
+
+	return tx.Model(&model.SalePayment{}).
+		Where("sale_id = ? AND status IN ?", sale.ID,
+			[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+		Update("status", config.PaymentStatusVoid).Error
+}
+
+// lockOpenSale loads a sale for update and makes sure it can still be changed.
+func lockOpenSale(tx *gorm.DB, id string) (*model.Sale, error) {
+	sale := new(model.Sale)
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(sale, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if sale.Status != config.SaleStatusUnpaid && sale.Status != config.SaleStatusHold {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Only open sales can be changed")
+	}
+
+	return sale, nil
+}
+
+func checkOutletStaff(tx *gorm.DB, outletID, staffID uuid.UUID) error {
+	var staff int64
+	if err := tx.Model(&model.OutletStaff{}).
+		Where("id = ? AND outlet_id = ?", staffID, outletID).
+		Count(&staff).Error; err != nil {
+		return err
+	}
+
+	if staff == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Staff does not belong to this outlet")
+	}
+
+	return nil
+}
+
+// applySaleOrder sets how the sale is served, the table has to belong to the sale's outlet.
+func applySaleOrder(tx *gorm.DB, sale *model.Sale, order *validation.SaleOrder) error {
+	sale.OrderType = order.OrderType
+	if sale.OrderType == "" {
+		sale.OrderType = config.OrderTypeDineIn
+	}
+
+	if order.TableID != "" {
+		tableID := uuid.MustParse(order.TableID)
+		if err := checkOutletTable(tx, sale.OutletID, tableID); err != nil {
+			return err
+		}
+		sale.TableID = &tableID
+	} else if sale.OrderType == config.OrderTypeDineIn {
+		return fiber.NewError(fiber.StatusBadRequest, "Dine-in sales need a table")
+	}
+
+	if sale.OrderType == config.OrderTypeDelivery {
+		sale.DeliveryAddress = &order.DeliveryAddress
+		sale.DeliveryContact = &order.DeliveryContact
+		sale.DeliveryPhone = &order.DeliveryPhone
+		sale.DeliveryFee = order.DeliveryFee
+	} else if order.DeliveryFee > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Only delivery sales have a delivery fee")
+	}
+
+	sale.PickupAt = order.PickupAt
+	return nil
+}
+
+// checkOutletTable makes sure the table belongs to the outlet and is not merged into another one,
+// sales of merged tables are opened at the table they are merged into.
+func checkOutletTable(tx *gorm.DB, outletID, tableID uuid.UUID) error {
+	table := new(model.Table)
+	result := tx.Limit(1).Find(table, "id = ? AND outlet_id = ?", tableID, outletID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Table does not belong to this outlet")
+	}
+
+	if table.MergedIntoID != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Table is merged into another table")
+	}
+
+	return nil
+}
+
+// buildSaleItems prices the requested products from the catalog of the outlet's business. It also
+// returns how far overridden prices are from the catalog, which a manager has to approve.
+func buildSaleItems(
+	tx *gorm.DB, businessID uuid.UUID, orderType string, reqItems []validation.CreateSaleItem,
+) ([]model.SaleItem, money.Amount, error) {
+	productIDs := make([]string, 0, len(reqItems))
+	for _, item := range reqItems {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	var products []model.Product
+	if err := tx.Where("id IN ? AND business_id = ?", productIDs, businessID).Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+
+	prices := make(map[string]money.Amount, len(products))
+	for i := range products {
+		prices[products[i].ID.String()] = productPrice(&products[i], orderType)
+	}
+
+	var overridden money.Amount
+	items := make([]model.SaleItem, 0, len(reqItems))
+	for _, reqItem := range reqItems {
+		price, ok := prices[reqItem.ProductID]
+		if !ok {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Product %s is not available at this outlet", reqItem.ProductID))
+		}
+
+		if reqItem.Price != nil && *reqItem.Price != price {
+			difference := (*reqItem.Price - price).Mul(reqItem.Quantity)
+			overridden += max(difference, -difference)
+			price = *reqItem.Price
+		}
+
+		gross := price.Mul(reqItem.Quantity)
+		if reqItem.Discount > gross {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Discount on product %s exceeds its price", reqItem.ProductID))
+		}
+
+		items = append(items, model.SaleItem{
+			ProductID:  uuid.MustParse(reqItem.ProductID),
+			Quantity:   reqItem.Quantity,
+			Price:      price,
+			Discount:   reqItem.Discount,
+			Total:      gross - reqItem.Discount,
+			SeatNumber: reqItem.SeatNumber,
+			Portion:    1,
+			Note:       reqItem.Note,
+			Modifiers:  reqItem.Modifiers,
+		})
+	}
+
+	return items, overridden, nil
+}
+
+// productPrice is the price of a product on its price list for the order type.
+func productPrice(product *model.Product, orderType string) money.Amount {
+	if price, ok := product.OrderPrices[orderType]; ok {
+		return price
+	}
+	return product.Price
+}
+
+// repriceSale recalculates the sale with its promotions and coupons and the taxes and service
+// charges of its outlet. Coupons come off what is left after the promotions.
+func repriceSale(tx *gorm.DB, sale *model.Sale) error {
+	categories, err := productCategories(tx, sale.SaleItems)
+	if err != nil {
+		return err
+	}
+
+	sale.Discount = 0
+	if err = applySalePromotions(tx, sale, categories); err != nil {
+		return err
+	}
+
+	if err = applySaleCoupons(tx, sale); err != nil {
+		return err
+	}
+
+	rules, err := outletTaxRules(tx, sale.OutletID, sale.OrderType)
+	if err != nil {
+		return err
+	}
+
+	recalculateSale(sale, rules, categories)
+	return nil
+}
+
+// productCategories maps the products of the items to their category.
+func productCategories(tx *gorm.DB, items []model.SaleItem) (map[uuid.UUID]uuid.UUID, error) {
+	categories := make(map[uuid.UUID]uuid.UUID, len(items))
+	if len(items) == 0 {
+		return categories, nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	var products []model.Product
+	if err := tx.Select("id", "category_id").Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	for _, product := range products {
+		categories[product.ID] = product.CategoryID
+	}
+
+	return categories, nil
+}
+
+// recalculateSale derives the sale totals from its items. Total is the sum of the line totals and
+// the sale level discount is capped at that total and spread over the lines before the service
+// charges and taxes are applied. Inclusive tax is already in Total, so it is not added again.
+// The delivery fee is added untaxed.
+func recalculateSale(sale *model.Sale, rules []utils.TaxRule, categories map[uuid.UUID]uuid.UUID) {
+	amounts := make([]money.Amount, len(sale.SaleItems))
+	sale.Total = 0
+	for i, item := range sale.SaleItems {
+		amounts[i] = item.Total
+		sale.Total += item.Total
+	}
+
+	if sale.Discount > sale.Total {
+		sale.Discount = sale.Total
+	}
+
+	discounts := config.Currency.Allocate(sale.Discount, amounts)
+	lines := make([]utils.TaxableLine, len(sale.SaleItems))
+	for i, item := range sale.SaleItems {
+		lines[i] = utils.TaxableLine{
+			CategoryID: categories[item.ProductID],
+			Amount:     item.Total - discounts[i],
+		}
+	}
+
+	taxes := utils.CalculateTaxes(lines, rules, config.Currency)
+	for i := range sale.SaleItems {
+		item := &sale.SaleItems[i]
+		taxed := taxes.Lines[i]
+
+		item.ServiceCharge = taxed.ServiceCharge
+		item.Tax = taxed.Tax
+		item.TaxIncluded = taxed.TaxIncluded
+		item.Taxes = make([]model.SaleItemTax, 0, len(taxed.Charges))
+
+		for _, charge := range taxed.Charges {
+			taxID := charge.RuleID
+			taxType := config.TaxTypeTax
+			if charge.ServiceCharge {
+				taxType = config.TaxTypeServiceCharge
+			}
+
+			item.Taxes = append(item.Taxes, model.SaleItemTax{
+				SaleItemID: item.ID,
+				TaxID:      &taxID,
+				Name:       charge.Name,
+				Type:       taxType,
+				Rate:       charge.Rate,
+				Inclusive:  charge.Inclusive,
+				Base:       charge.Base,
+				Amount:     charge.Amount,
+				Included:   charge.Included,
+			})
+		}
+	}
+
+	sale.ServiceCharge = taxes.ServiceCharge
+	sale.Tax = taxes.Tax
+	sale.TaxIncluded = taxes.TaxIncluded
+	sale.GrandTotal = sale.Total - sale.Discount + sale.ServiceCharge + sale.Tax - sale.TaxIncluded +
+		sale.DeliveryFee + sale.CashRounding
+}
+
+// saveSaleItemTaxes replaces the stored tax breakdown of existing items with the calculated one.
+func saveSaleItemTaxes(tx *gorm.DB, items []model.SaleItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	var taxes []model.SaleItemTax
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+
+		if err := tx.Model(&model.SaleItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"service_charge": item.ServiceCharge,
+			"tax":            item.Tax,
+			"tax_included":   item.TaxIncluded,
+		}).Error; err != nil {
+			return err
+		}
+
+		for _, tax := range item.Taxes {
+			tax.SaleItemID = item.ID
+			taxes = append(taxes, tax)
+		}
+	}
+
+	if err := tx.Where("sale_item_id IN ?", itemIDs).Delete(&model.SaleItemTax{}).Error; err != nil {
+		return err
+	}
+
+	if len(taxes) == 0 {
+		return nil
+	}
+
+	return tx.Create(&taxes).Error
+}
+
+func newInvoiceNumber(date time.Time) string {
+	return fmt.Sprintf("INV-%s-%s", date.Format("20060102"), strings.ToUpper(uuid.NewString()[:8]))
+}
+
+// buildSplitSales keeps the first group on the original sale and opens a new sale for every
+// other group. The delivery fee stays on the original sale, the caller works out the promotions
+// and taxes of every resulting sale.
+func buildSplitSales(original *model.Sale, groups [][]model.SaleItem) []model.Sale {
+	sales := make([]model.Sale, len(groups))
+	for i, items := range groups {
+		if i == 0 {
+			sales[i] = *original
+		} else {
+			sales[i] = model.Sale{
+				OutletID:      original.OutletID,
+				OutletStaffID: original.OutletStaffID,
+				CustomerID:    original.CustomerID,
+				OrderType:     original.OrderType,
+				TableID:       original.TableID,
+				PickupAt:      original.PickupAt,
+				InvoiceNumber: newInvoiceNumber(time.Now()),
+				Status:        original.Status,
+				SaleDate:      original.SaleDate,
+				SplitFromID:   &original.ID,
+			}
+		}
+
+		sales[i].Discount = 0
+		sales[i].SaleItems = items
+	}
+
+	return sales
+}
+
+// splitByItems moves the requested lines, units or line shares into one group per check.
+// Whatever is not moved stays in the first group. Fractions are shares of the line as it was,
+// a line can't be split by fraction and by units, and the share that completes it moves the line.
+func splitByItems(items []model.SaleItem, checks []validation.SplitCheck) ([][]model.SaleItem, error) {
+	remaining := make([]model.SaleItem, len(items))
+	copy(remaining, items)
+
+	index := make(map[string]int, len(remaining))
+	for i := range remaining {
+		index[remaining[i].ID.String()] = i
+	}
+
+	moved := make(map[int]bool)
+	shared := make(map[int]int64)
+	groups := [][]model.SaleItem{nil}
+
+	for _, check := range checks {
+		var group []model.SaleItem
+
+		for _, requested := range check.Items {
+			i, ok := index[requested.SaleItemID]
+			if !ok || moved[i] {
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("Sale item %s is not available on this sale", requested.SaleItemID))
+			}
+			line := &remaining[i]
+
+			if (requested.Fraction > 0 && line.Quantity != items[i].Quantity) ||
+				(requested.Fraction == 0 && shared[i] > 0) {
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("Sale item %s can be split by units or by fractions, not both", line.ID))
+			}
+
+			switch {
+			case requested.Fraction > 0:
+				numerator := int64(math.Round(requested.Fraction * portionScale))
+				shared[i] += numerator
+				if shared[i] > portionScale {
+					return nil, fiber.NewError(fiber.StatusBadRequest,
+						fmt.Sprintf("Fractions of sale item %s add up to more than the whole line", line.ID))
+				}
+				if shared[i] == portionScale {
+					moved[i] = true
+					group = append(group, *line)
+					break
+				}
+				group = append(group, takeShare(line, &items[i], numerator, portionScale))
+			case requested.Quantity > line.Quantity:
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("Cannot move %d of sale item %s, only %d left", requested.Quantity, line.ID, line.Quantity))
+			case requested.Quantity > 0 && requested.Quantity < line.Quantity:
+				group = append(group, takeUnits(line, requested.Quantity))
+			default:
+				moved[i] = true
+				group = append(group, *line)
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	for i := range remaining {
+		if !moved[i] {
+			groups[0] = append(groups[0], remaining[i])
+		}
+	}
+
+	if len(groups[0]) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "At least one item must stay on the original sale")
+	}
+
+	return groups, nil
+}
+
+// splitBySeats opens one group per seat number. Items without a seat stay on the original
+// sale, or the lowest seat does when every item has a seat.
+func splitBySeats(items []model.SaleItem) ([][]model.SaleItem, error) {
+	var unseated []model.SaleItem
+	seats := make(map[int][]model.SaleItem)
+
+	for _, item := range items {
+		if item.SeatNumber == nil {
+			unseated = append(unseated, item)
+			continue
+		}
+		seats[*item.SeatNumber] = append(seats[*item.SeatNumber], item)
+	}
+
+	numbers := make([]int, 0, len(seats))
+	for number := range seats {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	groups := [][]model.SaleItem{unseated}
+	for _, number := range numbers {
+		groups = append(groups, seats[number])
+	}
+
+	if len(unseated) == 0 && len(groups) > 1 {
+		groups = groups[1:]
+	}
+
+	if len(groups) < 2 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Items must be assigned to at least two seats")
+	}
+
+	return groups, nil
+}
+
+// splitEqually shares every line across the given number of checks.
+func splitEqually(items []model.SaleItem, parts int) [][]model.SaleItem {
+	groups := make([][]model.SaleItem, parts)
+
+	for _, item := range items {
+		line := item
+		for part := parts - 1; part > 0; part-- {
+			groups[part] = append(groups[part], takeShare(&line, &line, 1, int64(part+1)))
+		}
+		groups[0] = append(groups[0], line)
+	}
+
+	return groups
+}
+
+// takeShare carves numerator/denominator of original into a new line and leaves the rest on line,
+// original is the line before earlier shares were taken from it.
+func takeShare(line, original *model.SaleItem, numerator, denominator int64) model.SaleItem {
+	share := *line
+	share.ID = uuid.Nil
+	share.Portion = original.Portion * float64(numerator) / float64(denominator)
+	share.Discount = config.Currency.Ratio(original.Discount, numerator, denominator)
+	share.Total = config.Currency.Ratio(original.Total, numerator, denominator)
+
+	line.Portion -= share.Portion
+	line.Discount -= share.Discount
+	line.Total -= share.Total
+
+	return share
+}
+
+// takeUnits carves whole units of a line into a new line.
+func takeUnits(line *model.SaleItem, quantity int) model.SaleItem {
+	units := *line
+	units.ID = uuid.Nil
+	units.Quantity = quantity
+	units.Discount = config.Currency.Ratio(line.Discount, int64(quantity), int64(line.Quantity))
+	units.Total = config.Currency.Ratio(line.Total, int64(quantity), int64(line.Quantity))
+
+	line.Quantity -= quantity
+	line.Discount -= units.Discount
+	line.Total -= units.Total
+
+	return units
+}
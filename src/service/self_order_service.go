@@ -0,0 +1,608 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SelfOrderService interface {
+	GetMenu(c *fiber.Ctx, token string) (*response.SelfOrderMenu, error)
+	PriceCart(c *fiber.Ctx, token string, req *validation.SelfOrderCart) (*response.SelfOrderCart, error)
+	CreateTableOrder(c *fiber.Ctx, token string, req *validation.CreateTableOrder) (*model.TableOrder, error)
+	GetTableOrderStatus(c *fiber.Ctx, token, orderID string) (*model.TableOrder, error)
+	GetOrderingLink(c *fiber.Ctx, tableID string) (*response.OrderingLink, error)
+	RotateOrderingLink(c *fiber.Ctx, tableID string) (*response.OrderingLink, error)
+	GetTableOrders(
+		c *fiber.Ctx, outletID string, params *validation.QueryTableOrder,
+	) ([]model.TableOrder, int64, error)
+	ConfirmTableOrder(
+		c *fiber.Ctx, id string, user *model.User, req *validation.ConfirmTableOrder,
+	) (*model.TableOrder, *model.Sale, error)
+	RejectTableOrder(
+		c *fiber.Ctx, id string, user *model.User, req *validation.RejectTableOrder,
+	) (*model.TableOrder, error)
+}
+
+type selfOrderService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewSelfOrderService(db *gorm.DB, validate *validator.Validate) SelfOrderService {
+	return &selfOrderService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+// GetMenu lists the products of the table's outlet by category at their dine-in price.
+func (s *selfOrderService) GetMenu(c *fiber.Ctx, token string) (*response.SelfOrderMenu, error) {
+	db := s.DB.WithContext(c.Context())
+
+	table, err := tableFromToken(db, token)
+	if err != nil {
+		return nil, err
+	}
+
+	outlet, err := findOutlet(db, table.OutletID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var categories []model.ProductCategory
+	if err = db.Preload("Products", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name asc")
+	}).
+		Where("business_id = ?", outlet.BusinessID).
+		Order("name asc").
+		Find(&categories).Error; err != nil {
+		s.Log.Errorf("Failed to get menu: %+v", err)
+		return nil, err
+	}
+
+	menu := &response.SelfOrderMenu{
+		Outlet:     outlet.Name,
+		Table:      table.Name,
+		Categories: make([]response.SelfOrderCategory, 0, len(categories)),
+	}
+
+	for _, category := range categories {
+		if len(category.Products) == 0 {
+			continue
+		}
+
+		products := make([]response.SelfOrderProduct, len(category.Products))
+		for i := range category.Products {
+			product := &category.Products[i]
+			products[i] = response.SelfOrderProduct{
+				ID:          product.ID,
+				Name:        product.Name,
+				Description: product.Description,
+				Image:       product.Image,
+				Price:       productPrice(product, config.OrderTypeDineIn),
+			}
+		}
+
+		menu.Categories = append(menu.Categories, response.SelfOrderCategory{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			Products:    products,
+		})
+	}
+
+	return menu, nil
+}
+
+// PriceCart prices a cart the way its sale would be, nothing is saved.
+func (s *selfOrderService) PriceCart(
+	c *fiber.Ctx, token string, req *validation.SelfOrderCart,
+) (*response.SelfOrderCart, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	db := s.DB.WithContext(c.Context())
+
+	table, err := tableFromToken(db, token)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := buildSelfOrderItems(db, table.OutletID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	sale := &model.Sale{OutletID: table.OutletID, OrderType: config.OrderTypeDineIn, SaleItems: items}
+	if err = repriceSale(db, sale); err != nil {
+		s.Log.Errorf("Failed to price cart: %+v", err)
+		return nil, err
+	}
+
+	return &response.SelfOrderCart{
+		Items:         sale.SaleItems,
+		Promotions:    sale.SalePromotions,
+		Total:         sale.Total,
+		Discount:      sale.Discount,
+		ServiceCharge: sale.ServiceCharge,
+		Tax:           sale.Tax,
+		TaxIncluded:   sale.TaxIncluded,
+		GrandTotal:    sale.GrandTotal,
+	}, nil
+}
+
+// CreateTableOrder places the guests' order at their table, pending until staff confirm it.
+func (s *selfOrderService) CreateTableOrder(
+	c *fiber.Ctx, token string, req *validation.CreateTableOrder,
+) (*model.TableOrder, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	order := new(model.TableOrder)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		table, err := tableFromToken(tx, token)
+		if err != nil {
+			return err
+		}
+
+		items, err := buildSelfOrderItems(tx, table.OutletID, req.Items)
+		if err != nil {
+			return err
+		}
+
+		order.OutletID = table.OutletID
+		order.TableID = table.ID
+		order.Status = config.TableOrderStatusPending
+		if req.Name != "" {
+			order.Name = &req.Name
+		}
+		if req.Note != "" {
+			order.Note = &req.Note
+		}
+
+		// Shown with the sale the guests are already on, it is added to the table's open sale once confirmed
+		var saleIDs []uuid.UUID
+		if err = tx.Model(&model.Sale{}).
+			Where("table_id = ? AND status IN ?", hostTableID(table),
+				[]string{config.SaleStatusUnpaid, config.SaleStatusHold}).
+			Order("sale_date asc").
+			Limit(1).
+			Pluck("id", &saleIDs).Error; err != nil {
+			return err
+		}
+		if len(saleIDs) > 0 {
+			order.SaleID = &saleIDs[0]
+		}
+
+		order.Items = make([]model.TableOrderItem, len(items))
+		for i := range items {
+			order.Items[i] = model.TableOrderItem{
+				ProductID: items[i].ProductID,
+				Quantity:  items[i].Quantity,
+				Price:     items[i].Price,
+				Note:      items[i].Note,
+				Modifiers: items[i].Modifiers,
+			}
+		}
+
+		return tx.Create(order).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create table order: %+v", err)
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetTableOrderStatus lets the guests follow an order placed from their table.
+func (s *selfOrderService) GetTableOrderStatus(c *fiber.Ctx, token, orderID string) (*model.TableOrder, error) {
+	db := s.DB.WithContext(c.Context())
+
+	table, err := tableFromToken(db, token)
+	if err != nil {
+		return nil, err
+	}
+
+	order := new(model.TableOrder)
+	result := db.Preload("Items").First(order, "id = ? AND table_id = ?", orderID, table.ID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Table order not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get table order: %+v", result.Error)
+	}
+
+	return order, result.Error
+}
+
+func (s *selfOrderService) GetOrderingLink(c *fiber.Ctx, tableID string) (*response.OrderingLink, error) {
+	table := new(model.Table)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(table, "id = ?", tableID)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Table not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get table by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, table.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return orderingLink(table), nil
+}
+
+// RotateOrderingLink signs a new ordering link for the table, the codes printed before stop working.
+func (s *selfOrderService) RotateOrderingLink(c *fiber.Ctx, tableID string) (*response.OrderingLink, error) {
+	table := new(model.Table)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(table, "id = ?", tableID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, table.OutletID.String()); err != nil {
+			return err
+		}
+
+		table.OrderingVersion++
+		return tx.Model(table).Update("ordering_version", table.OrderingVersion).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to rotate ordering link: %+v", err)
+		}
+		return nil, err
+	}
+
+	return orderingLink(table), nil
+}
+
+func (s *selfOrderService) GetTableOrders(
+	c *fiber.Ctx, outletID string, params *validation.QueryTableOrder,
+) ([]model.TableOrder, int64, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, 0, err
+	}
+
+	var orders []model.TableOrder
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).
+		Model(&model.TableOrder{}).
+		Where("outlet_id = ?", outletID).
+		Order("created_at asc")
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if params.TableID != "" {
+		query = query.Where("table_id = ?", params.TableID)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count table orders: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Items").Limit(params.Limit).Offset(offset).Find(&orders).Error; err != nil {
+		s.Log.Errorf("Failed to get table orders: %+v", err)
+		return nil, 0, err
+	}
+
+	return orders, totalResults, nil
+}
+
+// ConfirmTableOrder adds the order's items to the open sale of its table at the current menu price,
+// the sale of the host table when the table is merged. When the table has no open sale one is
+// opened for the given staff.
+func (s *selfOrderService) ConfirmTableOrder(
+	c *fiber.Ctx, id string, user *model.User, req *validation.ConfirmTableOrder,
+) (*model.TableOrder, *model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	order := new(model.TableOrder)
+	sale := new(model.Sale)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := lockPendingTableOrder(tx, order, id); err != nil {
+			return err
+		}
+
+		if err := checkOutletAccess(c, tx, order.OutletID.String()); err != nil {
+			return err
+		}
+
+		table := new(model.Table)
+		if err := tx.First(table, "id = ?", order.TableID).Error; err != nil {
+			return err
+		}
+
+		host, err := lockTable(tx, order.OutletID, hostTableID(table))
+		if err != nil {
+			return err
+		}
+
+		reqItems := make([]validation.SelfOrderItem, len(order.Items))
+		for i, item := range order.Items {
+			reqItems[i] = validation.SelfOrderItem{
+				ProductID: item.ProductID.String(),
+				Quantity:  item.Quantity,
+				Note:      item.Note,
+				Modifiers: item.Modifiers,
+			}
+		}
+
+		items, err := buildSelfOrderItems(tx, order.OutletID, reqItems)
+		if err != nil {
+			return err
+		}
+
+		if sale, err = addTableOrderItems(tx, host.ID, items, req.OutletStaffID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		order.SaleID = &sale.ID
+		order.Status = config.TableOrderStatusConfirmed
+		order.UserID = &user.ID
+		order.ConfirmedAt = &now
+
+		return tx.Model(order).Select("sale_id", "status", "user_id", "confirmed_at").Updates(order).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to confirm table order: %+v", err)
+		}
+		return nil, nil, err
+	}
+
+	return order, sale, nil
+}
+
+func (s *selfOrderService) RejectTableOrder(
+	c *fiber.Ctx, id string, user *model.User, req *validation.RejectTableOrder,
+) (*model.TableOrder, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	order := new(model.TableOrder)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := lockPendingTableOrder(tx, order, id); err != nil {
+			return err
+		}
+
+		if err := checkOutletAccess(c, tx, order.OutletID.String()); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		order.Status = config.TableOrderStatusRejected
+		order.RejectReason = &req.Reason
+		order.UserID = &user.ID
+		order.RejectedAt = &now
+
+		return tx.Model(order).Select("status", "reject_reason", "user_id", "rejected_at").Updates(order).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to reject table order: %+v", err)
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// tableFromToken is the table of an ordering link. Links signed before the table's ordering version
+// was bumped are gone.
+func tableFromToken(db *gorm.DB, token string) (*model.Table, error) {
+	tableID, version, err := utils.ParseTableToken(config.SelfOrderSecret, token)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Ordering link is not valid")
+	}
+
+	table := new(model.Table)
+	result := db.Limit(1).Find(table, "id = ?", tableID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Ordering link is not valid")
+	}
+
+	if table.OrderingVersion != version {
+		return nil, fiber.NewError(fiber.StatusGone, "Ordering link has expired, scan the code on the table again")
+	}
+
+	return table, nil
+}
+
+func orderingLink(table *model.Table) *response.OrderingLink {
+	token := utils.SignTableToken(config.SelfOrderSecret, table.ID, table.OrderingVersion)
+
+	return &response.OrderingLink{
+		TableID: table.ID,
+		Token:   token,
+		URL:     strings.TrimRight(config.SelfOrderURL, "/") + "/" + token,
+	}
+}
+
+// hostTableID is where the sales of the table are opened, the table it is merged into if any.
+func hostTableID(table *model.Table) uuid.UUID {
+	if table.MergedIntoID != nil {
+		return *table.MergedIntoID
+	}
+	return table.ID
+}
+
+// buildSelfOrderItems prices the guests' items from the dine-in menu of the outlet's business.
+func buildSelfOrderItems(
+	tx *gorm.DB, outletID uuid.UUID, reqItems []validation.SelfOrderItem,
+) ([]model.SaleItem, error) {
+	outlet, err := findOutlet(tx, outletID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	saleItems := make([]validation.CreateSaleItem, len(reqItems))
+	for i, item := range reqItems {
+		saleItems[i] = validation.CreateSaleItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Note:      item.Note,
+			Modifiers: item.Modifiers,
+		}
+	}
+
+	items, _, err := buildSaleItems(tx, outlet.BusinessID, config.OrderTypeDineIn, saleItems)
+	return items, err
+}
+
+func lockPendingTableOrder(tx *gorm.DB, order *model.TableOrder, id string) error {
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(order, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Table order not found")
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if order.Status != config.TableOrderStatusPending {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Table order is already %s", order.Status))
+	}
+
+	return tx.Where("table_order_id = ?", order.ID).Order("created_at asc").Find(&order.Items).Error
+}
+
+// addTableOrderItems adds the items to the oldest open sale at the table, or opens a dine-in sale
+// for staffID when there is none.
+func addTableOrderItems(tx *gorm.DB, tableID uuid.UUID, items []model.SaleItem, staffID string) (*model.Sale, error) {
+	sale, err := lockTableSale(tx, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sale == nil {
+		return openTableOrderSale(tx, tableID, items, staffID)
+	}
+
+	if err = tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].SaleID = sale.ID
+	}
+
+	if err = tx.Create(&items).Error; err != nil {
+		return nil, err
+	}
+
+	sale.SaleItems = append(sale.SaleItems, items...)
+	if err = repriceSale(tx, sale); err != nil {
+		return nil, err
+	}
+
+	if err = saveSaleItemTaxes(tx, sale.SaleItems); err != nil {
+		return nil, err
+	}
+
+	if err = saveSalePromotions(tx, sale); err != nil {
+		return nil, err
+	}
+
+	return sale, tx.Model(sale).Select(
+		"total", "discount", "service_charge", "tax", "tax_included", "grand_total",
+	).Updates(sale).Error
+}
+
+func openTableOrderSale(tx *gorm.DB, tableID uuid.UUID, items []model.SaleItem, staffID string) (*model.Sale, error) {
+	if staffID == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest,
+			"The table has no open sale, choose the staff to open one for")
+	}
+
+	table := new(model.Table)
+	if err := tx.First(table, "id = ?", tableID).Error; err != nil {
+		return nil, err
+	}
+
+	sale := &model.Sale{
+		ID:            uuid.New(),
+		OutletID:      table.OutletID,
+		OutletStaffID: uuid.MustParse(staffID),
+		OrderType:     config.OrderTypeDineIn,
+		TableID:       &table.ID,
+		InvoiceNumber: newInvoiceNumber(time.Now()),
+		Status:        config.SaleStatusUnpaid,
+		SaleDate:      time.Now(),
+		SaleItems:     items,
+	}
+
+	if err := checkOutletStaff(tx, sale.OutletID, sale.OutletStaffID); err != nil {
+		return nil, err
+	}
+
+	if err := repriceSale(tx, sale); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(sale).Error; err != nil {
+		return nil, err
+	}
+
+	return sale, occupyTable(tx, sale.TableID)
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/escpos"
+	"app/src/model"
+	"app/src/money"
+	"app/src/receipt"
+	"app/src/utils"
+	"app/src/validation"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingService interface {
+	GetSettings(c *fiber.Ctx, outletID string) ([]model.Setting, error)
+	UpsertSetting(c *fiber.Ctx, outletID, key string, req *validation.UpsertSetting) (*model.Setting, error)
+	DeleteSetting(c *fiber.Ctx, outletID, key string) error
+}
+
+type settingService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewSettingService(db *gorm.DB, validate *validator.Validate) SettingService {
+	return &settingService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *settingService) GetSettings(c *fiber.Ctx, outletID string) ([]model.Setting, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	var settings []model.Setting
+
+	result := s.DB.WithContext(c.Context()).Where("outlet_id = ?", outletID).Order("key asc").Find(&settings)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get settings: %+v", result.Error)
+	}
+
+	return settings, result.Error
+}
+
+func (s *settingService) UpsertSetting(
+	c *fiber.Ctx, outletID, key string, req *validation.UpsertSetting,
+) (*model.Setting, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if err := checkSettingValue(key, req.Value); err != nil {
+		return nil, err
+	}
+
+	setting := &model.Setting{
+		OutletID: uuid.MustParse(outletID),
+		Key:      key,
+		Value:    req.Value,
+	}
+
+	result := s.DB.WithContext(c.Context()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "outlet_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(setting)
+
+	if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Outlet not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to save setting: %+v", result.Error)
+	}
+
+	return setting, result.Error
+}
+
+func (s *settingService) DeleteSetting(c *fiber.Ctx, outletID, key string) error {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return err
+	}
+
+	result := s.DB.WithContext(c.Context()).Where("outlet_id = ? AND key = ?", outletID, key).Delete(&model.Setting{})
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to delete setting: %+v", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Setting not found")
+	}
+
+	return nil
+}
+
+// checkSettingValue rejects values the known settings cannot use, other keys are stored as they are.
+func checkSettingValue(key, value string) error {
+	switch key {
+	case config.SettingHeldSaleExpiryMinutes:
+		if minutes, err := strconv.Atoi(value); err != nil || minutes < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be a number of minutes")
+		}
+	case config.SettingReservationMinutes, config.SettingTableTurnMinutes:
+		if minutes, err := strconv.Atoi(value); err != nil || minutes < 1 {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be a positive number of minutes")
+		}
+	case config.SettingCashRoundingIncrement:
+		if increment, err := money.Parse(value); err != nil || increment < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be a positive amount")
+		}
+	case config.SettingCashRoundingMode:
+		if value != config.CashRoundingNearest && value != config.CashRoundingUp && value != config.CashRoundingDown {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be nearest, up or down")
+		}
+	case config.SettingTipPoolRule:
+		if value != config.TipPoolEqual && value != config.TipPoolHours && value != config.TipPoolRole {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be equal, hours or role")
+		}
+	case config.SettingTipPoolRoleWeights:
+		if _, err := parseRoleWeights(value); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must map roles to weights of zero or more")
+		}
+	case config.SettingReceiptLanguage:
+		if !receipt.IsLanguage(value) {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be en or id")
+		}
+	case config.SettingReceiptCodePage:
+		if _, ok := escpos.LookupCodePage(value); !ok {
+			return fiber.NewError(fiber.StatusBadRequest, "Setting value must be cp437, cp850, cp858, cp866 or wpc1252")
+		}
+	}
+
+	return nil
+}
+
+// outletSetting returns the raw value of an outlet setting, or an empty string when it is not set.
+func outletSetting(db *gorm.DB, outletID uuid.UUID, key string) (string, error) {
+	setting := new(model.Setting)
+
+	result := db.Where("outlet_id = ? AND key = ?", outletID, key).Limit(1).Find(setting)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	return setting.Value, nil
+}
+
+// outletSettingInt reads an integer outlet setting and falls back when it is missing or malformed.
+func outletSettingInt(db *gorm.DB, outletID uuid.UUID, key string, fallback int) (int, error) {
+	value, err := outletSetting(db, outletID, key)
+	if err != nil || value == "" {
+		return fallback, err
+	}
+
+	if number, convErr := strconv.Atoi(value); convErr == nil {
+		return number, nil
+	}
+
+	return fallback, nil
+}
+
+// outletCashRounding reads how cash tenders are rounded at an outlet. A zero increment means
+// cash is not rounded.
+func outletCashRounding(db *gorm.DB, outletID uuid.UUID) (money.Amount, money.RoundingMode, error) {
+	value, err := outletSetting(db, outletID, config.SettingCashRoundingIncrement)
+	if err != nil || value == "" {
+		return 0, "", err
+	}
+
+	increment, parseErr := money.Parse(value)
+	if parseErr != nil || increment <= 0 {
+		return 0, "", nil
+	}
+
+	mode, err := outletSetting(db, outletID, config.SettingCashRoundingMode)
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch mode {
+	case config.CashRoundingUp:
+		return increment, money.RoundUp, nil
+	case config.CashRoundingDown:
+		return increment, money.RoundDown, nil
+	default:
+		return increment, money.RoundHalfUp, nil
+	}
+}
+
+// outletTipPoolRule reads how pooled tips are shared at an outlet, equally by default. Roles
+// missing from the weights count as 1.
+func outletTipPoolRule(db *gorm.DB, outletID uuid.UUID) (string, map[string]float64, error) {
+	rule, err := outletSetting(db, outletID, config.SettingTipPoolRule)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch rule {
+	case config.TipPoolHours:
+		return rule, nil, nil
+	case config.TipPoolRole:
+		value, settingErr := outletSetting(db, outletID, config.SettingTipPoolRoleWeights)
+		if settingErr != nil {
+			return "", nil, settingErr
+		}
+
+		weights, parseErr := parseRoleWeights(value)
+		if parseErr != nil {
+			weights = map[string]float64{}
+		}
+		return rule, weights, nil
+	default:
+		return config.TipPoolEqual, nil, nil
+	}
+}
+
+func parseRoleWeights(value string) (map[string]float64, error) {
+	weights := map[string]float64{}
+	if value == "" {
+		return weights, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &weights); err != nil {
+		return nil, err
+	}
+
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, errors.New("negative role weight")
+		}
+	}
+
+	return weights, nil
+}
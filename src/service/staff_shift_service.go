@@ -0,0 +1,164 @@
+package service
+
+import (
+	"app/src/model"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StaffShiftService interface {
+	GetShifts(c *fiber.Ctx, outletID string, params *validation.QueryStaffShift) ([]model.StaffShift, int64, error)
+	ClockIn(c *fiber.Ctx, outletID string, req *validation.ClockIn) (*model.StaffShift, error)
+	ClockOut(c *fiber.Ctx, id string) (*model.StaffShift, error)
+}
+
+type staffShiftService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewStaffShiftService(db *gorm.DB, validate *validator.Validate) StaffShiftService {
+	return &staffShiftService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *staffShiftService) GetShifts(
+	c *fiber.Ctx, outletID string, params *validation.QueryStaffShift,
+) ([]model.StaffShift, int64, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, 0, err
+	}
+
+	var shifts []model.StaffShift
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).Model(&model.StaffShift{}).
+		Where("outlet_id = ?", outletID).
+		Order("clock_in desc")
+
+	if params.OutletStaffID != "" {
+		query = query.Where("outlet_staff_id = ?", params.OutletStaffID)
+	}
+
+	if params.Date != "" {
+		day, _ := time.ParseInLocation("2006-01-02", params.Date, time.Local)
+		query = query.Where("clock_in >= ? AND clock_in < ?", day, day.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count staff shifts: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Preload("OutletStaff").Limit(params.Limit).Offset(offset).Find(&shifts).Error; err != nil {
+		s.Log.Errorf("Failed to get staff shifts: %+v", err)
+		return nil, 0, err
+	}
+
+	return shifts, totalResults, nil
+}
+
+func (s *staffShiftService) ClockIn(c *fiber.Ctx, outletID string, req *validation.ClockIn) (*model.StaffShift, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	shift := &model.StaffShift{
+		OutletID:      uuid.MustParse(outletID),
+		OutletStaffID: uuid.MustParse(req.OutletStaffID),
+		ClockIn:       time.Now(),
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		staff := new(model.OutletStaff)
+		result := tx.Where("id = ? AND outlet_id = ?", shift.OutletStaffID, shift.OutletID).First(staff)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "Staff does not belong to this outlet")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var open int64
+		if err := tx.Model(&model.StaffShift{}).
+			Where("outlet_staff_id = ? AND clock_out IS NULL", shift.OutletStaffID).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return fiber.NewError(fiber.StatusConflict, "Staff is already clocked in")
+		}
+
+		if err := tx.Create(shift).Error; err != nil {
+			return err
+		}
+
+		shift.OutletStaff = staff
+		return nil
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to clock in: %+v", err)
+		}
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+func (s *staffShiftService) ClockOut(c *fiber.Ctx, id string) (*model.StaffShift, error) {
+	shift := new(model.StaffShift)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(shift, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Shift not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if shift.ClockOut != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Shift is already closed")
+		}
+
+		now := time.Now()
+		shift.ClockOut = &now
+
+		return tx.Model(shift).Update("clock_out", shift.ClockOut).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to clock out: %+v", err)
+		}
+		return nil, err
+	}
+
+	return shift, nil
+}
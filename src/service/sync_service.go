@@ -0,0 +1,476 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type SyncService interface {
+	GetChanges(c *fiber.Ctx, outletID string, params *validation.QuerySync) (*response.SyncChanges, error)
+	UploadSales(c *fiber.Ctx, outletID string, req *validation.SyncSales) ([]response.SyncSaleResult, error)
+}
+
+type syncService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewSyncService(db *gorm.DB, validate *validator.Validate) SyncService {
+	return &syncService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *syncService) GetChanges(
+	c *fiber.Ctx, outletID string, params *validation.QuerySync,
+) (*response.SyncChanges, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	changes := &response.SyncChanges{
+		Cursor:            params.Cursor,
+		Full:              params.Cursor == 0,
+		ServerTime:        time.Now(),
+		Products:          []model.Product{},
+		ProductCategories: []model.ProductCategory{},
+		Taxes:             []model.Tax{},
+		Staff:             []model.OutletStaff{},
+		PaymentMethods:    []model.PaymentMethod{},
+		Deleted:           []model.SyncTombstone{},
+	}
+
+	// One snapshot for every table. Changes of transactions still running when it is taken are
+	// not in it, the cursor stays below the horizon so they are sent next time.
+	var horizon int64
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT sync_version_horizon()").Scan(&horizon).Error; err != nil {
+			return err
+		}
+
+		outlet, err := findOutlet(tx, outletID)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Where("business_id = ? AND sync_version > ?", outlet.BusinessID, params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.Products).Error; err != nil {
+			return err
+		}
+
+		if err = tx.Where("business_id = ? AND sync_version > ?", outlet.BusinessID, params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.ProductCategories).Error; err != nil {
+			return err
+		}
+
+		if err = tx.Preload("TaxExemptions").
+			Where("business_id = ? AND (outlet_id IS NULL OR outlet_id = ?)", outlet.BusinessID, outlet.ID).
+			Where("sync_version > ?", params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.Taxes).Error; err != nil {
+			return err
+		}
+
+		if err = tx.Where("outlet_id = ? AND sync_version > ?", outlet.ID, params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.Staff).Error; err != nil {
+			return err
+		}
+
+		if err = tx.Where("outlet_id = ? AND sync_version > ?", outlet.ID, params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.PaymentMethods).Error; err != nil {
+			return err
+		}
+
+		// A full snapshot has nothing to delete
+		if changes.Full {
+			return nil
+		}
+
+		return tx.Where("outlet_id = ? OR (outlet_id IS NULL AND business_id = ?)", outlet.ID, outlet.BusinessID).
+			Where("sync_version > ?", params.Cursor).
+			Order("sync_version asc").
+			Find(&changes.Deleted).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to get sync changes: %+v", err)
+		}
+		return nil, err
+	}
+
+	changes.Cursor = syncCursor(changes, horizon)
+	return changes, nil
+}
+
+func (s *syncService) UploadSales(
+	c *fiber.Ctx, outletID string, req *validation.SyncSales,
+) ([]response.SyncSaleResult, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	outlet, err := findOutlet(s.DB.WithContext(c.Context()), outletID)
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to get outlet: %+v", err)
+		}
+		return nil, err
+	}
+
+	results := make([]response.SyncSaleResult, 0, len(req.Sales))
+	for i := range req.Sales {
+		results = append(results, s.uploadSale(c, outlet, &req.Sales[i]))
+	}
+
+	return results, nil
+}
+
+// uploadSale saves one sale made offline in its own transaction and reports what happened to it.
+// A sale that was uploaded before is reported as a duplicate, so terminals can safely resend.
+func (s *syncService) uploadSale(
+	c *fiber.Ctx, outlet *model.Outlet, record *validation.SyncSale,
+) response.SyncSaleResult {
+	result := response.SyncSaleResult{ID: record.ID, Status: config.SyncStatusRejected}
+
+	if err := s.Validate.Struct(record); err != nil {
+		result.Message = validationMessage(err)
+		return result
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		existing := new(model.Sale)
+		found := tx.Limit(1).Find(existing, "id = ?", record.ID)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected > 0 {
+			if existing.OutletID != outlet.ID {
+				return fiber.NewError(fiber.StatusConflict, "Sale id is already used by another outlet")
+			}
+			result.Status = config.SyncStatusDuplicate
+			result.InvoiceNumber = existing.InvoiceNumber
+			return nil
+		}
+
+		sale, overridden, conflicts, err := buildSyncSale(tx, outlet, record)
+		result.Conflicts = conflicts
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Create(sale).Error; err != nil {
+			return err
+		}
+
+		// Offline sales need the same approvals as sales made online, asked for before going offline
+		if err = authorizeSaleItems(tx, sale, sale.SaleItems, overridden, record.ApprovalIDs); err != nil {
+			return err
+		}
+
+		conflicts, err = syncPayments(tx, sale, record.Payments)
+		if err != nil {
+			return err
+		}
+
+		// Sales paid offline do not take their table again
+		if sale.Status == config.SaleStatusUnpaid {
+			if err = occupyTable(tx, sale.TableID); err != nil {
+				return err
+			}
+		}
+
+		result.Status = config.SyncStatusCreated
+		result.InvoiceNumber = sale.InvoiceNumber
+		result.Conflicts = append(result.Conflicts, conflicts...)
+		return nil
+	})
+
+	if err != nil {
+		result.Status = config.SyncStatusRejected
+		result.InvoiceNumber = ""
+
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			result.Message = fiberErr.Message
+		} else {
+			s.Log.Errorf("Failed to upload offline sale: %+v", err)
+			result.Message = "Sale could not be saved, upload it again later"
+		}
+	}
+
+	return result
+}
+
+// buildSyncSale prices a sale made offline. The prices the terminal charged are kept and every
+// difference with the catalog is reported, products that were deleted since reject the sale.
+// Differences with products that did not change after the sale are overrides made on the
+// terminal, it also returns how far those are from the catalog.
+func buildSyncSale(
+	tx *gorm.DB, outlet *model.Outlet, record *validation.SyncSale,
+) (*model.Sale, money.Amount, []response.SyncConflict, error) {
+	sale := &model.Sale{
+		ID:            uuid.MustParse(record.ID),
+		OutletID:      outlet.ID,
+		OutletStaffID: uuid.MustParse(record.OutletStaffID),
+		InvoiceNumber: newInvoiceNumber(record.SaleDate),
+		Status:        config.SaleStatusUnpaid,
+		SaleDate:      record.SaleDate,
+	}
+
+	if record.CustomerID != "" {
+		customer, err := findOutletCustomer(tx, outlet.ID, record.CustomerID)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		sale.CustomerID = &customer.ID
+	}
+
+	if record.Note != "" {
+		sale.Note = &record.Note
+	}
+
+	if err := checkOutletStaff(tx, sale.OutletID, sale.OutletStaffID); err != nil {
+		return nil, 0, nil, err
+	}
+
+	if err := applySaleOrder(tx, sale, &record.SaleOrder); err != nil {
+		return nil, 0, nil, err
+	}
+
+	productIDs := make([]string, 0, len(record.Items))
+	for _, item := range record.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	var products []model.Product
+	if err := tx.Where("id IN ? AND business_id = ?", productIDs, outlet.BusinessID).Find(&products).Error; err != nil {
+		return nil, 0, nil, err
+	}
+
+	catalog := make(map[string]*model.Product, len(products))
+	for i := range products {
+		catalog[products[i].ID.String()] = &products[i]
+	}
+
+	var conflicts []response.SyncConflict
+	var overridden money.Amount
+	for _, item := range record.Items {
+		product, ok := catalog[item.ProductID]
+		if !ok {
+			return nil, 0, nil, fiber.NewError(fiber.StatusConflict,
+				fmt.Sprintf("Product %s no longer exists", item.ProductID))
+		}
+
+		gross := item.Price.Mul(item.Quantity)
+		if item.Discount > gross {
+			return nil, 0, nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("Discount on product %s exceeds its price", item.ProductID))
+		}
+
+		price := productPrice(product, sale.OrderType)
+		if price != item.Price && !product.UpdatedAt.After(record.SaleDate) {
+			difference := (item.Price - price).Mul(item.Quantity)
+			overridden += max(difference, -difference)
+		}
+
+		if price != item.Price {
+			conflicts = append(conflicts, response.SyncConflict{
+				Code: config.SyncConflictPriceChanged,
+				Message: fmt.Sprintf("Product %s was sold at %s, the price is now %s",
+					item.ProductID, item.Price, price),
+			})
+		}
+
+		sale.SaleItems = append(sale.SaleItems, model.SaleItem{
+			ProductID:  uuid.MustParse(item.ProductID),
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			Discount:   item.Discount,
+			Total:      gross - item.Discount,
+			SeatNumber: item.SeatNumber,
+			Portion:    1,
+		})
+	}
+
+	return sale, overridden, conflicts, repriceSale(tx, sale)
+}
+
+// syncPayments records the payments taken offline in the order they were made and settles the
+// sale when they cover it. Money the terminal took beyond the balance is kept and reported.
+func syncPayments(
+	tx *gorm.DB, sale *model.Sale, records []validation.SyncSalePayment,
+) ([]response.SyncConflict, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var conflicts []response.SyncConflict
+	payments := make([]model.SalePayment, 0, len(records))
+	var paid money.Amount
+
+	for _, record := range records {
+		paymentMethod := new(model.PaymentMethod)
+		result := tx.Where("id = ? AND outlet_id = ?", record.PaymentMethodID, sale.OutletID).First(paymentMethod)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Payment method is not available for this outlet")
+		}
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		isCash := paymentMethod.Type == config.PaymentTypeCash
+		if isCash && sale.GrandTotal > paid {
+			if err := applyCashRounding(tx, sale, sale.GrandTotal-paid, record.Amount); err != nil {
+				return nil, err
+			}
+		}
+
+		applied := min(record.Amount, max(sale.GrandTotal-paid, 0))
+		var change money.Amount
+		switch {
+		case record.Amount > applied && isCash:
+			change = record.Amount - applied
+		case record.Amount > applied:
+			conflicts = append(conflicts, response.SyncConflict{
+				Code:    config.SyncConflictOverpaid,
+				Message: fmt.Sprintf("Payment of %s exceeds the balance of %s", record.Amount, applied),
+			})
+		}
+
+		// Nothing was left to pay, there is no part of the sale to record the payment against
+		if applied == 0 {
+			continue
+		}
+
+		paidAt := record.PaidAt
+		payment := model.SalePayment{
+			SaleID:          sale.ID,
+			PaymentMethodID: paymentMethod.ID,
+			Amount:          applied,
+			Tendered:        record.Amount,
+			Change:          change,
+			Tip:             record.Tip,
+			OutletStaffID:   &sale.OutletStaffID,
+			Status:          config.PaymentStatusPaid,
+			PaidAt:          &paidAt,
+		}
+		if record.Reference != "" {
+			payment.Reference = &record.Reference
+		}
+
+		if err := tx.Create(&payment).Error; err != nil {
+			return nil, err
+		}
+
+		paid += applied
+		payments = append(payments, payment)
+	}
+
+	summary, err := settleSale(tx, sale, payments)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Remaining > 0 {
+		conflicts = append(conflicts, response.SyncConflict{
+			Code:    config.SyncConflictUnderpaid,
+			Message: fmt.Sprintf("Sale stays unpaid, %s is still due", summary.Remaining),
+		})
+	}
+
+	return conflicts, nil
+}
+
+func findOutlet(db *gorm.DB, outletID string) (*model.Outlet, error) {
+	outlet := new(model.Outlet)
+
+	result := db.First(outlet, "id = ?", outletID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Outlet not found")
+	}
+
+	return outlet, result.Error
+}
+
+// syncCursor is the highest version in the changes, kept below the horizon of the snapshot, or
+// the cursor the terminal sent when nothing changed. Changes sent again are just applied again.
+func syncCursor(changes *response.SyncChanges, horizon int64) int64 {
+	var cursor int64
+	versions := make([]int64, 0, len(changes.Products)+len(changes.Taxes)+len(changes.Deleted))
+
+	for _, product := range changes.Products {
+		versions = append(versions, product.SyncVersion)
+	}
+	for _, category := range changes.ProductCategories {
+		versions = append(versions, category.SyncVersion)
+	}
+	for _, tax := range changes.Taxes {
+		versions = append(versions, tax.SyncVersion)
+	}
+	for _, staff := range changes.Staff {
+		versions = append(versions, staff.SyncVersion)
+	}
+	for _, paymentMethod := range changes.PaymentMethods {
+		versions = append(versions, paymentMethod.SyncVersion)
+	}
+	for _, tombstone := range changes.Deleted {
+		versions = append(versions, tombstone.SyncVersion)
+	}
+
+	for _, version := range versions {
+		cursor = max(cursor, version)
+	}
+
+	// Versions from the horizon on may still be taken by transactions that commit later
+	return max(changes.Cursor, min(cursor, horizon-1))
+}
+
+// validationMessage flattens validation errors into one sentence for a per record result.
+func validationMessage(err error) string {
+	errorsMap := validation.CustomErrorMessages(err)
+	if len(errorsMap) == 0 {
+		return err.Error()
+	}
+
+	messages := make([]string, 0, len(errorsMap))
+	for _, message := range errorsMap {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,1191 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TableService interface {
+	GetFloorAreas(c *fiber.Ctx, outletID string) ([]model.FloorArea, error)
+	CreateFloorArea(c *fiber.Ctx, outletID string, req *validation.CreateFloorArea) (*model.FloorArea, error)
+	UpdateFloorArea(c *fiber.Ctx, id string, req *validation.UpdateFloorArea) (*model.FloorArea, error)
+	DeleteFloorArea(c *fiber.Ctx, id string) error
+	GetTables(c *fiber.Ctx, outletID string, params *validation.QueryTable) ([]model.Table, error)
+	GetTableByID(c *fiber.Ctx, id string) (*model.Table, error)
+	CreateTable(c *fiber.Ctx, outletID string, req *validation.CreateTable) (*model.Table, error)
+	UpdateTable(c *fiber.Ctx, id string, req *validation.UpdateTable) (*model.Table, error)
+	UpdateTableStatus(c *fiber.Ctx, id string, req *validation.UpdateTableStatus) (*model.Table, error)
+	DeleteTable(c *fiber.Ctx, id string) error
+	GetTableStatuses(c *fiber.Ctx, outletID string) ([]response.TableStatus, error)
+	MoveSale(c *fiber.Ctx, saleID string, user *model.User, req *validation.MoveSale) (*model.Sale, error)
+	MergeTables(
+		c *fiber.Ctx, id string, user *model.User, req *validation.MergeTables,
+	) ([]model.Table, *model.Sale, error)
+	UnmergeTables(c *fiber.Ctx, id string, user *model.User, req *validation.UnmergeTables) ([]model.Table, error)
+	GetTableMoves(c *fiber.Ctx, outletID string, params *validation.QueryTableMove) ([]model.TableMove, int64, error)
+}
+
+type tableService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewTableService(db *gorm.DB, validate *validator.Validate) TableService {
+	return &tableService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *tableService) GetFloorAreas(c *fiber.Ctx, outletID string) ([]model.FloorArea, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	var areas []model.FloorArea
+
+	result := s.DB.WithContext(c.Context()).
+		Preload("Tables", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name asc")
+		}).
+		Where("outlet_id = ?", outletID).
+		Order("sort_order asc, name asc").
+		Find(&areas)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get floor areas: %+v", result.Error)
+	}
+
+	return areas, result.Error
+}
+
+func (s *tableService) CreateFloorArea(
+	c *fiber.Ctx, outletID string, req *validation.CreateFloorArea,
+) (*model.FloorArea, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	area := &model.FloorArea{
+		OutletID:  uuid.MustParse(outletID),
+		Name:      req.Name,
+		SortOrder: req.SortOrder,
+		Width:     req.Width,
+		Height:    req.Height,
+	}
+
+	if area.Width == 0 {
+		area.Width = 1000
+	}
+	if area.Height == 0 {
+		area.Height = 1000
+	}
+
+	db := s.DB.WithContext(c.Context())
+	if _, err := findOutlet(db, outletID); err != nil {
+		return nil, err
+	}
+
+	result := db.Create(area)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Floor area name is already in use")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed to create floor area: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	area.Tables = []model.Table{}
+	return area, nil
+}
+
+func (s *tableService) UpdateFloorArea(
+	c *fiber.Ctx, id string, req *validation.UpdateFloorArea,
+) (*model.FloorArea, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	area := new(model.FloorArea)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(area, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Floor area not found")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed get floor area by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, area.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		area.Name = req.Name
+	}
+	if req.SortOrder != nil {
+		area.SortOrder = *req.SortOrder
+	}
+	if req.Width != 0 {
+		area.Width = req.Width
+	}
+	if req.Height != 0 {
+		area.Height = req.Height
+	}
+
+	result = db.Model(area).Updates(map[string]interface{}{
+		"name":       area.Name,
+		"sort_order": area.SortOrder,
+		"width":      area.Width,
+		"height":     area.Height,
+	})
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Floor area name is already in use")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed to update floor area: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	return area, nil
+}
+
+// DeleteFloorArea removes the area, its tables stay with the outlet without a place on the floor plan.
+func (s *tableService) DeleteFloorArea(c *fiber.Ctx, id string) error {
+	area := new(model.FloorArea)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(area, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Floor area not found")
+	}
+	if result.Error != nil {
+		s.Log.Errorf("Failed get floor area by id: %+v", result.Error)
+		return result.Error
+	}
+
+	if err := checkOutletAccess(c, db, area.OutletID.String()); err != nil {
+		return err
+	}
+
+	if err := db.Delete(area).Error; err != nil {
+		s.Log.Errorf("Failed to delete floor area: %+v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *tableService) GetTables(c *fiber.Ctx, outletID string, params *validation.QueryTable) ([]model.Table, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, err
+	}
+
+	var tables []model.Table
+	query := s.DB.WithContext(c.Context()).Where("outlet_id = ?", outletID)
+
+	if params.FloorAreaID != "" {
+		query = query.Where("floor_area_id = ?", params.FloorAreaID)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	result := query.Order("name asc").Find(&tables)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get tables: %+v", result.Error)
+	}
+
+	return tables, result.Error
+}
+
+func (s *tableService) GetTableByID(c *fiber.Ctx, id string) (*model.Table, error) {
+	table := new(model.Table)
+	db := s.DB.WithContext(c.Context())
+
+	result := db.First(table, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Table not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get table by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkOutletAccess(c, db, table.OutletID.String()); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (s *tableService) CreateTable(c *fiber.Ctx, outletID string, req *validation.CreateTable) (*model.Table, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	table := &model.Table{
+		OutletID: uuid.MustParse(outletID),
+		Name:     req.Name,
+		Status:   config.TableStatusAvailable,
+		Capacity: req.Capacity,
+		Shape:    req.Shape,
+		PosX:     req.PosX,
+		PosY:     req.PosY,
+		Width:    req.Width,
+		Height:   req.Height,
+		Rotation: req.Rotation,
+	}
+
+	if req.Location != "" {
+		table.Location = &req.Location
+	}
+	if table.Shape == "" {
+		table.Shape = "square"
+	}
+	if table.Width == 0 {
+		table.Width = 100
+	}
+	if table.Height == 0 {
+		table.Height = 100
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if _, err := findOutlet(tx, outletID); err != nil {
+			return err
+		}
+
+		if req.FloorAreaID != "" {
+			areaID := uuid.MustParse(req.FloorAreaID)
+			if err := checkOutletFloorArea(tx, table.OutletID, areaID); err != nil {
+				return err
+			}
+			table.FloorAreaID = &areaID
+		}
+
+		return tx.Create(table).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create table: %+v", err)
+		}
+		return nil, err
+	}
+
+	return table, nil
+}
+
+func (s *tableService) UpdateTable(c *fiber.Ctx, id string, req *validation.UpdateTable) (*model.Table, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	table := new(model.Table)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(table, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, table.OutletID.String()); err != nil {
+			return err
+		}
+
+		if req.FloorAreaID != nil {
+			table.FloorAreaID = nil
+			if *req.FloorAreaID != "" {
+				areaID := uuid.MustParse(*req.FloorAreaID)
+				if err := checkOutletFloorArea(tx, table.OutletID, areaID); err != nil {
+					return err
+				}
+				table.FloorAreaID = &areaID
+			}
+		}
+
+		applyTableLayout(table, req)
+
+		return tx.Model(table).Updates(map[string]interface{}{
+			"floor_area_id": table.FloorAreaID,
+			"name":          table.Name,
+			"location":      table.Location,
+			"capacity":      table.Capacity,
+			"shape":         table.Shape,
+			"pos_x":         table.PosX,
+			"pos_y":         table.PosY,
+			"width":         table.Width,
+			"height":        table.Height,
+			"rotation":      table.Rotation,
+		}).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update table: %+v", err)
+		}
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// UpdateTableStatus lets staff mark a table as cleaned, needing cleaning or reserved. Occupied
+// tables are released by closing their sales. The tables merged into it take the same status.
+func (s *tableService) UpdateTableStatus(
+	c *fiber.Ctx, id string, req *validation.UpdateTableStatus,
+) (*model.Table, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	table := new(model.Table)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(table, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, table.OutletID.String()); err != nil {
+			return err
+		}
+		if table.MergedIntoID != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Table is merged into another table")
+		}
+
+		open, err := countOpenSales(tx, table.ID)
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Table has open sales")
+		}
+
+		now := time.Now()
+		table.Status = req.Status
+		table.StatusChangedAt = &now
+
+		return tx.Model(&model.Table{}).
+			Where("id = ? OR merged_into_id = ?", table.ID, table.ID).
+			Updates(map[string]interface{}{
+				"status":            table.Status,
+				"status_changed_at": table.StatusChangedAt,
+			}).Error
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update table status: %+v", err)
+		}
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// DeleteTable removes a table that was never sold at. Dine-in sales need their table, so tables
+// with sales history stay.
+func (s *tableService) DeleteTable(c *fiber.Ctx, id string) error {
+	table := new(model.Table)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.First(table, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, table.OutletID.String()); err != nil {
+			return err
+		}
+
+		open, err := countOpenSales(tx, table.ID)
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Table has open sales")
+		}
+
+		err = tx.Delete(table).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fiber.NewError(fiber.StatusConflict, "Table has sales history and cannot be deleted")
+		}
+
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to delete table: %+v", err)
+		}
+	}
+
+	return err
+}
+
+// GetTableStatuses is the live floor plan of an outlet, polled by the front of house.
+func (s *tableService) GetTableStatuses(c *fiber.Ctx, outletID string) ([]response.TableStatus, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, err
+	}
+
+	statuses := []response.TableStatus{}
+
+	err := s.DB.WithContext(c.Context()).
+		Model(&model.Table{}).
+		Select(`tables.id AS table_id, tables.floor_area_id, tables.merged_into_id, tables.name, tables.capacity,
+			tables.status, tables.status_changed_at, COUNT(sales.id) AS open_sales,
+			COALESCE(SUM(sales.grand_total), 0) AS open_total, MIN(sales.sale_date) AS occupied_since,
+			(SELECT COUNT(*) FROM table_orders WHERE table_orders.table_id = tables.id
+				AND table_orders.status = ?) AS pending_orders`, config.TableOrderStatusPending).
+		Joins("LEFT JOIN sales ON sales.table_id = tables.id AND sales.status IN ?",
+			[]string{config.SaleStatusUnpaid, config.SaleStatusHold}).
+		Where("tables.outlet_id = ?", outletID).
+		Group("tables.id").
+		Order("tables.name asc").
+		Scan(&statuses).Error
+
+	if err != nil {
+		s.Log.Errorf("Failed to get table statuses: %+v", err)
+	}
+
+	return statuses, err
+}
+
+// MoveSale moves an open sale to another table of its outlet. The table it leaves needs cleaning
+// once nothing else is open at it.
+func (s *tableService) MoveSale(
+	c *fiber.Ctx, saleID string, user *model.User, req *validation.MoveSale,
+) (*model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var sale *model.Sale
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if sale, err = lockOpenSale(tx, saleID); err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, sale.OutletID.String()); err != nil {
+			return err
+		}
+
+		table, err := lockTable(tx, sale.OutletID, uuid.MustParse(req.TableID))
+		if err != nil {
+			return err
+		}
+		if sale.TableID != nil && *sale.TableID == table.ID {
+			return fiber.NewError(fiber.StatusBadRequest, "Sale is already at this table")
+		}
+
+		from := sale.TableID
+		if err = moveSaleToTable(tx, sale, table); err != nil {
+			return err
+		}
+
+		if err = releaseTable(tx, from, config.TableStatusNeedsCleaning); err != nil {
+			return err
+		}
+
+		if err = tx.Where("sale_id = ?", sale.ID).Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+			return err
+		}
+
+		return recordTableMove(tx, &model.TableMove{
+			OutletID:    sale.OutletID,
+			Action:      config.TableMoveMove,
+			SaleID:      &sale.ID,
+			FromTableID: from,
+			ToTableID:   &table.ID,
+		}, user, req.Reason)
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to move sale: %+v", err)
+		}
+		return nil, err
+	}
+
+	return sale, nil
+}
+
+// MergeTables joins tables to the table with id for a group. The open sales of the joined tables are
+// merged into the oldest open sale of the host table, or the first of them moves to the host table
+// when it has none. The joined tables follow the host's status until they are unmerged.
+func (s *tableService) MergeTables(
+	c *fiber.Ctx, id string, user *model.User, req *validation.MergeTables,
+) ([]model.Table, *model.Sale, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	var tables []model.Table
+	var target *model.Sale
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		host, joined, err := lockMergeTables(tx, id, req.TableIDs)
+		if err != nil {
+			return err
+		}
+
+		if err = checkOutletAccess(c, tx, host.OutletID.String()); err != nil {
+			return err
+		}
+
+		var moves []model.TableMove
+		if target, moves, err = mergeTableSales(tx, host, joined); err != nil {
+			return err
+		}
+
+		status := host.Status
+		if target != nil {
+			status = config.TableStatusOccupied
+		}
+
+		joinedIDs := make([]uuid.UUID, len(joined))
+		for i := range joined {
+			joinedIDs[i] = joined[i].ID
+		}
+
+		if err = tx.Model(&model.Table{}).Where("id IN ?", joinedIDs).Updates(map[string]interface{}{
+			"merged_into_id":    host.ID,
+			"status":            status,
+			"status_changed_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		if target != nil {
+			if err = occupyTable(tx, &host.ID); err != nil {
+				return err
+			}
+		}
+
+		for i := range moves {
+			if err = recordTableMove(tx, &moves[i], user, req.Reason); err != nil {
+				return err
+			}
+		}
+
+		if err = tx.First(host, "id = ?", host.ID).Error; err != nil {
+			return err
+		}
+		if err = tx.Where("merged_into_id = ?", host.ID).Order("name asc").Find(&tables).Error; err != nil {
+			return err
+		}
+
+		tables = append([]model.Table{*host}, tables...)
+		return nil
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to merge tables: %+v", err)
+		}
+		return nil, nil, err
+	}
+
+	return tables, target, nil
+}
+
+// UnmergeTables splits the tables merged into the table with id apart again. Sales merged in from
+// those tables get their items back and reopen at their table, the rest of the open sale stays at
+// the host table. Tables the group sat at need cleaning.
+func (s *tableService) UnmergeTables(
+	c *fiber.Ctx, id string, user *model.User, req *validation.UnmergeTables,
+) ([]model.Table, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var tables []model.Table
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		host := new(model.Table)
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(host, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkOutletAccess(c, tx, host.OutletID.String()); err != nil {
+			return err
+		}
+
+		if err := tx.Where("merged_into_id = ?", host.ID).Order("name asc").Find(&tables).Error; err != nil {
+			return err
+		}
+		if len(tables) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "No tables are merged into this table")
+		}
+
+		restored, err := unmergeTableSales(tx, host, tables)
+		if err != nil {
+			return err
+		}
+
+		status := config.TableStatusAvailable
+		if host.Status == config.TableStatusOccupied || host.Status == config.TableStatusNeedsCleaning {
+			status = config.TableStatusNeedsCleaning
+		}
+
+		now := time.Now()
+		for i := range tables {
+			tables[i].MergedIntoID = nil
+			tables[i].Status = status
+			tables[i].StatusChangedAt = &now
+		}
+
+		if err := tx.Model(&model.Table{}).Where("merged_into_id = ?", host.ID).Updates(map[string]interface{}{
+			"merged_into_id":    nil,
+			"status":            status,
+			"status_changed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		for i := range tables {
+			move := &model.TableMove{
+				OutletID:    host.OutletID,
+				Action:      config.TableMoveUnmerge,
+				FromTableID: &host.ID,
+				ToTableID:   &tables[i].ID,
+			}
+			if sale, ok := restored[tables[i].ID]; ok {
+				move.SaleID = &sale.ID
+				if err = occupyTable(tx, &tables[i].ID); err != nil {
+					return err
+				}
+				tables[i].Status = config.TableStatusOccupied
+			}
+
+			if err = recordTableMove(tx, move, user, req.Reason); err != nil {
+				return err
+			}
+		}
+
+		tables = append([]model.Table{*host}, tables...)
+		return nil
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to unmerge tables: %+v", err)
+		}
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func (s *tableService) GetTableMoves(
+	c *fiber.Ctx, outletID string, params *validation.QueryTableMove,
+) ([]model.TableMove, int64, error) {
+	if err := checkOutletAccess(c, s.DB, outletID); err != nil {
+		return nil, 0, err
+	}
+
+	var moves []model.TableMove
+	var totalResults int64
+
+	if err := s.Validate.Struct(params); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (params.Page - 1) * params.Limit
+	query := s.DB.WithContext(c.Context()).
+		Model(&model.TableMove{}).
+		Where("outlet_id = ?", outletID).
+		Order("created_at desc")
+
+	if params.TableID != "" {
+		query = query.Where("from_table_id = ? OR to_table_id = ?", params.TableID, params.TableID)
+	}
+
+	if params.SaleID != "" {
+		query = query.Where("sale_id = ? OR merged_sale_id = ?", params.SaleID, params.SaleID)
+	}
+
+	if err := query.Count(&totalResults).Error; err != nil {
+		s.Log.Errorf("Failed to count table moves: %+v", err)
+		return nil, 0, err
+	}
+
+	if err := query.Limit(params.Limit).Offset(offset).Find(&moves).Error; err != nil {
+		s.Log.Errorf("Failed to get table moves: %+v", err)
+		return nil, 0, err
+	}
+
+	return moves, totalResults, nil
+}
+
+func applyTableLayout(table *model.Table, req *validation.UpdateTable) {
+	if req.Name != "" {
+		table.Name = req.Name
+	}
+	if req.Location != nil {
+		table.Location = req.Location
+		if *req.Location == "" {
+			table.Location = nil
+		}
+	}
+	if req.Capacity != 0 {
+		table.Capacity = req.Capacity
+	}
+	if req.Shape != "" {
+		table.Shape = req.Shape
+	}
+	if req.PosX != nil {
+		table.PosX = *req.PosX
+	}
+	if req.PosY != nil {
+		table.PosY = *req.PosY
+	}
+	if req.Width != 0 {
+		table.Width = req.Width
+	}
+	if req.Height != 0 {
+		table.Height = req.Height
+	}
+	if req.Rotation != nil {
+		table.Rotation = *req.Rotation
+	}
+}
+
+func checkOutletFloorArea(tx *gorm.DB, outletID, areaID uuid.UUID) error {
+	var areas int64
+	if err := tx.Model(&model.FloorArea{}).
+		Where("id = ? AND outlet_id = ?", areaID, outletID).
+		Count(&areas).Error; err != nil {
+		return err
+	}
+
+	if areas == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Floor area does not belong to this outlet")
+	}
+
+	return nil
+}
+
+func countOpenSales(tx *gorm.DB, tableID uuid.UUID) (int64, error) {
+	var open int64
+	err := tx.Model(&model.Sale{}).
+		Where("table_id = ? AND status IN ?", tableID, []string{config.SaleStatusUnpaid, config.SaleStatusHold}).
+		Count(&open).Error
+
+	return open, err
+}
+
+// occupyTable marks the table of an open sale, and the tables merged into it, as occupied.
+func occupyTable(tx *gorm.DB, tableID *uuid.UUID) error {
+	if tableID == nil {
+		return nil
+	}
+
+	return tx.Model(&model.Table{}).
+		Where("(id = ? OR merged_into_id = ?) AND status <> ?", tableID, tableID, config.TableStatusOccupied).
+		Updates(map[string]interface{}{
+			"status":            config.TableStatusOccupied,
+			"status_changed_at": time.Now(),
+		}).Error
+}
+
+// releaseTable moves an occupied table to status once the last open sale at it is closed, to
+// needs_cleaning when it was paid and back to available when it was voided.
+func releaseTable(tx *gorm.DB, tableID *uuid.UUID, status string) error {
+	if tableID == nil {
+		return nil
+	}
+
+	open, err := countOpenSales(tx, *tableID)
+	if err != nil || open > 0 {
+		return err
+	}
+
+	return tx.Model(&model.Table{}).
+		Where("(id = ? OR merged_into_id = ?) AND status = ?", tableID, tableID, config.TableStatusOccupied).
+		Updates(map[string]interface{}{
+			"status":            status,
+			"status_changed_at": time.Now(),
+		}).Error
+}
+
+// lockTable locks a table of the outlet that sales can be opened at.
+func lockTable(tx *gorm.DB, outletID, tableID uuid.UUID) (*model.Table, error) {
+	table := new(model.Table)
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(table, "id = ? AND outlet_id = ?", tableID, outletID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Table does not belong to this outlet")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if table.MergedIntoID != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Table is merged into another table")
+	}
+
+	return table, nil
+}
+
+// lockMergeTables locks the host table and the tables to join to it. Tables that are merged already,
+// or have tables merged into them, cannot be joined.
+func lockMergeTables(tx *gorm.DB, id string, tableIDs []string) (*model.Table, []model.Table, error) {
+	host := new(model.Table)
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(host, "id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "Table not found")
+	}
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if host.MergedIntoID != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Table is merged into another table")
+	}
+
+	joined := make([]model.Table, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		table, err := lockTable(tx, host.OutletID, uuid.MustParse(tableID))
+		if err != nil {
+			return nil, nil, err
+		}
+		if table.ID == host.ID {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "A table cannot be merged into itself")
+		}
+
+		var hosted int64
+		if err = tx.Model(&model.Table{}).Where("merged_into_id = ?", table.ID).Count(&hosted).Error; err != nil {
+			return nil, nil, err
+		}
+		if hosted > 0 {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Unmerge "+table.Name+" before merging it")
+		}
+
+		joined = append(joined, *table)
+	}
+
+	return host, joined, nil
+}
+
+// mergeTableSales merges the open sales of the joined tables into the host table's sale and returns
+// that sale, nil when none of the tables has an open sale, with the moves to record.
+func mergeTableSales(
+	tx *gorm.DB, host *model.Table, joined []model.Table,
+) (*model.Sale, []model.TableMove, error) {
+	target, err := lockTableSale(tx, host.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moves := make([]model.TableMove, 0, len(joined))
+	merged := false
+
+	for i := range joined {
+		var sales []model.Sale
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("table_id = ? AND status IN ?", joined[i].ID,
+				[]string{config.SaleStatusUnpaid, config.SaleStatusHold}).
+			Order("sale_date asc").
+			Find(&sales).Error; err != nil {
+			return nil, nil, err
+		}
+
+		move := model.TableMove{
+			OutletID:    host.OutletID,
+			Action:      config.TableMoveMerge,
+			FromTableID: &joined[i].ID,
+			ToTableID:   &host.ID,
+		}
+
+		if len(sales) == 0 {
+			if target != nil {
+				move.SaleID = &target.ID
+			}
+			moves = append(moves, move)
+			continue
+		}
+
+		for j := range sales {
+			move.MergedSaleID = nil
+
+			if target == nil {
+				target = &sales[j]
+				if err = moveSaleToTable(tx, target, host); err != nil {
+					return nil, nil, err
+				}
+			} else {
+				if err = mergeSale(tx, target, &sales[j], host); err != nil {
+					return nil, nil, err
+				}
+				move.MergedSaleID = &sales[j].ID
+				merged = true
+			}
+
+			move.SaleID = &target.ID
+			moves = append(moves, move)
+		}
+	}
+
+	if target == nil {
+		return nil, moves, nil
+	}
+
+	if err = tx.Where("sale_id = ?", target.ID).Order("created_at asc").Find(&target.SaleItems).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if merged {
+		err = repriceMergedSale(tx, target)
+	}
+
+	return target, moves, err
+}
+
+// lockTableSale locks the oldest open sale at the table, nil when there is none.
+func lockTableSale(tx *gorm.DB, tableID uuid.UUID) (*model.Sale, error) {
+	var sales []model.Sale
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("table_id = ? AND status IN ?", tableID, []string{config.SaleStatusUnpaid, config.SaleStatusHold}).
+		Order("sale_date asc").
+		Limit(1).
+		Find(&sales).Error
+	if err != nil || len(sales) == 0 {
+		return nil, err
+	}
+
+	return &sales[0], nil
+}
+
+// moveSaleToTable seats an open sale at the table, its kitchen tickets still being prepared follow it.
+func moveSaleToTable(tx *gorm.DB, sale *model.Sale, table *model.Table) error {
+	sale.TableID = &table.ID
+	if err := tx.Model(sale).Update("table_id", sale.TableID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.KitchenItem{}).
+		Where("sale_id = ? AND status IN ?", sale.ID,
+			[]string{config.KitchenStatusNew, config.KitchenStatusInProgress, config.KitchenStatusReady}).
+		Update("table_name", table.Name).Error; err != nil {
+		return err
+	}
+
+	return occupyTable(tx, sale.TableID)
+}
+
+// mergeSale moves the items and kitchen tickets of source to target and closes source as merged.
+func mergeSale(tx *gorm.DB, target, source *model.Sale, host *model.Table) error {
+	var payments int64
+	if err := tx.Model(&model.SalePayment{}).
+		Where("sale_id = ? AND status IN ?", source.ID,
+			[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+		Count(&payments).Error; err != nil {
+		return err
+	}
+	if payments > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Sales with payments cannot be merged")
+	}
+
+	if err := tx.Model(&model.SaleItem{}).Where("sale_id = ?", source.ID).Updates(map[string]interface{}{
+		"sale_id":             target.ID,
+		"merged_from_sale_id": source.ID,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.KitchenItem{}).Where("sale_id = ?", source.ID).Updates(map[string]interface{}{
+		"sale_id":        target.ID,
+		"invoice_number": target.InvoiceNumber,
+		"table_name":     host.Name,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := moveSaleCoupons(tx, source, target); err != nil {
+		return err
+	}
+
+	source.Status = config.SaleStatusMerged
+	return tx.Model(source).Update("status", source.Status).Error
+}
+
+// unmergeTableSales gives the sales merged into the open sale of the host back the items they
+// brought, at the tables being unmerged. They are open again, held if they were held before the
+// merge, and repriced, and so is the host's sale. Coupons moved over with them stay on the host's sale. It returns the sales by table.
+func unmergeTableSales(tx *gorm.DB, host *model.Table, tables []model.Table) (map[uuid.UUID]*model.Sale, error) {
+	restored := make(map[uuid.UUID]*model.Sale)
+
+	target, err := lockTableSale(tx, host.ID)
+	if err != nil || target == nil {
+		return restored, err
+	}
+
+	tableIDs := make([]uuid.UUID, len(tables))
+	names := make(map[uuid.UUID]string, len(tables))
+	for i := range tables {
+		tableIDs[i] = tables[i].ID
+		names[tables[i].ID] = tables[i].Name
+	}
+
+	var sales []model.Sale
+	if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ? AND table_id IN ?", config.SaleStatusMerged, tableIDs).
+		Where("id IN (?)", tx.Model(&model.SaleItem{}).Select("merged_from_sale_id").
+			Where("sale_id = ?", target.ID)).
+		Order("sale_date asc").
+		Find(&sales).Error; err != nil {
+		return nil, err
+	}
+	if len(sales) == 0 {
+		return restored, nil
+	}
+
+	var payments int64
+	if err = tx.Model(&model.SalePayment{}).
+		Where("sale_id = ? AND status IN ?", target.ID,
+			[]string{config.PaymentStatusPaid, config.PaymentStatusPending}).
+		Count(&payments).Error; err != nil {
+		return nil, err
+	}
+	if payments > 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Void the payments before unmerging the sales")
+	}
+
+	for i := range sales {
+		sale := &sales[i]
+		if err = tx.Where("sale_id = ? AND merged_from_sale_id = ?", target.ID, sale.ID).
+			Order("created_at asc").Find(&sale.SaleItems).Error; err != nil {
+			return nil, err
+		}
+
+		if err = tx.Model(&model.SaleItem{}).Where("sale_id = ? AND merged_from_sale_id = ?", target.ID, sale.ID).
+			Updates(map[string]interface{}{"sale_id": sale.ID, "merged_from_sale_id": nil}).Error; err != nil {
+			return nil, err
+		}
+
+		name := names[*sale.TableID]
+		if err = tx.Model(&model.KitchenItem{}).Where("sale_id = ? AND sale_item_id IN (?)", target.ID,
+			tx.Model(&model.SaleItem{}).Select("id").Where("sale_id = ?", sale.ID),
+		).Updates(map[string]interface{}{
+			"sale_id":        sale.ID,
+			"invoice_number": sale.InvoiceNumber,
+			"table_name":     name,
+		}).Error; err != nil {
+			return nil, err
+		}
+
+		// Merging leaves held_at alone, it tells whether the sale was on hold
+		sale.Status = config.SaleStatusUnpaid
+		if sale.HeldAt != nil {
+			sale.Status = config.SaleStatusHold
+		}
+		if err = tx.Model(sale).Update("status", sale.Status).Error; err != nil {
+			return nil, err
+		}
+
+		for j := range sale.SaleItems {
+			sale.SaleItems[j].SaleID = sale.ID
+			sale.SaleItems[j].MergedFromID = nil
+		}
+		if err = repriceMergedSale(tx, sale); err != nil {
+			return nil, err
+		}
+
+		restored[*sale.TableID] = sale
+	}
+
+	if err = tx.Where("sale_id = ?", target.ID).Order("created_at asc").Find(&target.SaleItems).Error; err != nil {
+		return nil, err
+	}
+
+	return restored, repriceMergedSale(tx, target)
+}
+
+// repriceMergedSale recalculates the totals of a sale that other sales were merged into.
+func repriceMergedSale(tx *gorm.DB, sale *model.Sale) error {
+	if err := repriceSale(tx, sale); err != nil {
+		return err
+	}
+
+	if err := saveSaleItemTaxes(tx, sale.SaleItems); err != nil {
+		return err
+	}
+
+	if err := saveSalePromotions(tx, sale); err != nil {
+		return err
+	}
+
+	return tx.Model(sale).Select(
+		"total", "discount", "service_charge", "tax", "tax_included", "grand_total",
+	).Updates(sale).Error
+}
+
+// recordTableMove saves the move with the user who made it.
+func recordTableMove(tx *gorm.DB, move *model.TableMove, user *model.User, reason string) error {
+	if user != nil {
+		move.UserID = &user.ID
+	}
+	if reason != "" {
+		move.Reason = &reason
+	}
+
+	return tx.Create(move).Error
+}
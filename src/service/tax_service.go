@@ -0,0 +1,342 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TaxService interface {
+	GetTaxes(c *fiber.Ctx, businessID string) ([]model.Tax, error)
+	GetTaxByID(c *fiber.Ctx, id string) (*model.Tax, error)
+	CreateTax(c *fiber.Ctx, businessID string, req *validation.CreateTax) (*model.Tax, error)
+	UpdateTax(c *fiber.Ctx, id string, req *validation.UpdateTax) (*model.Tax, error)
+	DeleteTax(c *fiber.Ctx, id string) error
+}
+
+type taxService struct {
+	Log      *logrus.Logger
+	DB       *gorm.DB
+	Validate *validator.Validate
+}
+
+func NewTaxService(db *gorm.DB, validate *validator.Validate) TaxService {
+	return &taxService{
+		Log:      utils.Log,
+		DB:       db,
+		Validate: validate,
+	}
+}
+
+func (s *taxService) GetTaxes(c *fiber.Ctx, businessID string) ([]model.Tax, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	var taxes []model.Tax
+
+	result := s.DB.WithContext(c.Context()).
+		Preload("TaxExemptions").
+		Where("business_id = ?", businessID).
+		Order("type asc, created_at asc").
+		Find(&taxes)
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed to get taxes: %+v", result.Error)
+	}
+
+	return taxes, result.Error
+}
+
+func (s *taxService) GetTaxByID(c *fiber.Ctx, id string) (*model.Tax, error) {
+	tax := new(model.Tax)
+
+	result := s.DB.WithContext(c.Context()).Preload("TaxExemptions").First(tax, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Tax not found")
+	}
+
+	if result.Error != nil {
+		s.Log.Errorf("Failed get tax by id: %+v", result.Error)
+		return nil, result.Error
+	}
+
+	if err := checkBusinessAccess(c, s.DB, tax.BusinessID.String()); err != nil {
+		return nil, err
+	}
+
+	return tax, nil
+}
+
+func (s *taxService) CreateTax(c *fiber.Ctx, businessID string, req *validation.CreateTax) (*model.Tax, error) {
+	if err := checkBusinessAccess(c, s.DB, businessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	tax := &model.Tax{
+		BusinessID: uuid.MustParse(businessID),
+		Name:       req.Name,
+		Type:       req.Type,
+		Rate:       req.Rate,
+		Inclusive:  req.Inclusive,
+		Taxable:    req.Taxable,
+		IsActive:   true,
+		OrderTypes: req.OrderTypes,
+	}
+
+	if err := checkTaxFlags(tax); err != nil {
+		return nil, err
+	}
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		var businesses int64
+		if err := tx.Model(&model.Business{}).Where("id = ?", tax.BusinessID).Count(&businesses).Error; err != nil {
+			return err
+		}
+		if businesses == 0 {
+			return fiber.NewError(fiber.StatusNotFound, "Business not found")
+		}
+
+		if req.OutletID != "" {
+			outletID := uuid.MustParse(req.OutletID)
+
+			var outlets int64
+			if err := tx.Model(&model.Outlet{}).
+				Where("id = ? AND business_id = ?", outletID, tax.BusinessID).
+				Count(&outlets).Error; err != nil {
+				return err
+			}
+			if outlets == 0 {
+				return fiber.NewError(fiber.StatusBadRequest, "Outlet does not belong to this business")
+			}
+
+			tax.OutletID = &outletID
+		}
+
+		if err := tx.Create(tax).Error; err != nil {
+			return err
+		}
+
+		exemptions, err := replaceTaxExemptions(tx, tax, req.ExemptCategoryIDs)
+		tax.TaxExemptions = exemptions
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to create tax: %+v", err)
+		}
+		return nil, err
+	}
+
+	return tax, nil
+}
+
+func (s *taxService) UpdateTax(c *fiber.Ctx, id string, req *validation.UpdateTax) (*model.Tax, error) {
+	if err := s.Validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	tax := new(model.Tax)
+
+	err := s.DB.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("TaxExemptions").First(tax, "id = ?", id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Tax not found")
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := checkBusinessAccess(c, tx, tax.BusinessID.String()); err != nil {
+			return err
+		}
+
+		if req.Name != "" {
+			tax.Name = req.Name
+		}
+		if req.Rate != 0 {
+			tax.Rate = req.Rate
+		}
+		if req.Inclusive != nil {
+			tax.Inclusive = *req.Inclusive
+		}
+		if req.Taxable != nil {
+			tax.Taxable = *req.Taxable
+		}
+		if req.IsActive != nil {
+			tax.IsActive = *req.IsActive
+		}
+
+		if err := checkTaxFlags(tax); err != nil {
+			return err
+		}
+
+		if err := tx.Model(tax).Updates(map[string]interface{}{
+			"name":      tax.Name,
+			"rate":      tax.Rate,
+			"inclusive": tax.Inclusive,
+			"taxable":   tax.Taxable,
+			"is_active": tax.IsActive,
+		}).Error; err != nil {
+			return err
+		}
+
+		if req.OrderTypes != nil {
+			tax.OrderTypes = *req.OrderTypes
+			if err := tx.Model(tax).Select("order_types").Updates(tax).Error; err != nil {
+				return err
+			}
+		}
+
+		if req.ExemptCategoryIDs == nil {
+			return nil
+		}
+
+		exemptions, err := replaceTaxExemptions(tx, tax, *req.ExemptCategoryIDs)
+		tax.TaxExemptions = exemptions
+		return err
+	})
+
+	if err != nil {
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) {
+			s.Log.Errorf("Failed to update tax: %+v", err)
+		}
+		return nil, err
+	}
+
+	return tax, nil
+}
+
+func (s *taxService) DeleteTax(c *fiber.Ctx, id string) error {
+	tax, err := s.GetTaxByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DB.WithContext(c.Context()).Delete(tax).Error; err != nil {
+		s.Log.Errorf("Failed to delete tax: %+v", err)
+		return err
+	}
+
+	return nil
+}
+
+func checkTaxFlags(tax *model.Tax) error {
+	if tax.Type == config.TaxTypeServiceCharge && tax.Inclusive {
+		return fiber.NewError(fiber.StatusBadRequest, "Service charges cannot be inclusive")
+	}
+
+	if tax.Type == config.TaxTypeTax && tax.Taxable {
+		return fiber.NewError(fiber.StatusBadRequest, "Only service charges can be taxable")
+	}
+
+	return nil
+}
+
+// replaceTaxExemptions swaps the exempted product categories of a tax for the given ones.
+func replaceTaxExemptions(tx *gorm.DB, tax *model.Tax, categoryIDs []string) ([]model.TaxExemption, error) {
+	if err := tx.Where("tax_id = ?", tax.ID).Delete(&model.TaxExemption{}).Error; err != nil {
+		return nil, err
+	}
+
+	unique := make(map[string]bool, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		unique[categoryID] = true
+	}
+	if len(unique) == 0 {
+		return []model.TaxExemption{}, nil
+	}
+
+	ids := make([]string, 0, len(unique))
+	for categoryID := range unique {
+		ids = append(ids, categoryID)
+	}
+
+	var categories int64
+	if err := tx.Model(&model.ProductCategory{}).
+		Where("id IN ? AND business_id = ?", ids, tax.BusinessID).
+		Count(&categories).Error; err != nil {
+		return nil, err
+	}
+	if int(categories) != len(ids) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Exempt categories must belong to the business")
+	}
+
+	exemptions := make([]model.TaxExemption, 0, len(ids))
+	for _, categoryID := range ids {
+		exemptions = append(exemptions, model.TaxExemption{
+			TaxID:             tax.ID,
+			ProductCategoryID: uuid.MustParse(categoryID),
+		})
+	}
+
+	return exemptions, tx.Create(&exemptions).Error
+}
+
+// outletTaxRules loads the active taxes and service charges of an outlet for an order type. Rules
+// defined on the outlet replace the business wide rules of the same type.
+func outletTaxRules(db *gorm.DB, outletID uuid.UUID, orderType string) ([]utils.TaxRule, error) {
+	var taxes []model.Tax
+
+	err := db.Preload("TaxExemptions").
+		Where("is_active = ?", true).
+		Where("outlet_id = ? OR (outlet_id IS NULL AND business_id = (?))", outletID,
+			db.Model(&model.Outlet{}).Select("business_id").Where("id = ?", outletID)).
+		Order("created_at asc").
+		Find(&taxes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	taxes = slices.DeleteFunc(taxes, func(tax model.Tax) bool {
+		return len(tax.OrderTypes) > 0 && !slices.Contains(tax.OrderTypes, orderType)
+	})
+
+	overridden := make(map[string]bool)
+	for _, tax := range taxes {
+		if tax.OutletID != nil {
+			overridden[tax.Type] = true
+		}
+	}
+
+	rules := make([]utils.TaxRule, 0, len(taxes))
+	for _, tax := range taxes {
+		if tax.OutletID == nil && overridden[tax.Type] {
+			continue
+		}
+
+		exempt := make(map[uuid.UUID]bool, len(tax.TaxExemptions))
+		for _, exemption := range tax.TaxExemptions {
+			exempt[exemption.ProductCategoryID] = true
+		}
+
+		rules = append(rules, utils.TaxRule{
+			ID:                tax.ID,
+			Name:              tax.Name,
+			ServiceCharge:     tax.Type == config.TaxTypeServiceCharge,
+			Rate:              tax.Rate,
+			Inclusive:         tax.Inclusive,
+			Taxable:           tax.Taxable,
+			ExemptCategoryIDs: exempt,
+		})
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "time"
+
+// Backoff is the delay before retrying after a number of failed attempts, doubling from base
+// on every attempt up to limit.
+func Backoff(attempts int, base, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+
+	return min(delay, limit)
+}
@@ -0,0 +1,62 @@
+package utils
+
+import "sync"
+
+// Broker fans messages published on a topic out to its subscribers, within this process.
+// A subscriber that falls behind by more than its buffer misses messages instead of blocking
+// the publisher.
+type Broker struct {
+	mu     sync.RWMutex
+	topics map[string]map[chan []byte]struct{}
+	buffer int
+}
+
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		topics: make(map[string]map[chan []byte]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe returns the messages of a topic and a function to stop receiving them, which closes
+// the channel.
+func (b *Broker) Subscribe(topic string) (<-chan []byte, func()) {
+	ch := make(chan []byte, b.buffer)
+
+	b.mu.Lock()
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[chan []byte]struct{})
+	}
+	b.topics[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.topics[topic], ch)
+			if len(b.topics[topic]) == 0 {
+				delete(b.topics, topic)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends a message to the current subscribers of a topic and returns how many got it.
+func (b *Broker) Publish(topic string, message []byte) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	sent := 0
+	for ch := range b.topics[topic] {
+		select {
+		case ch <- message:
+			sent++
+		default:
+		}
+	}
+
+	return sent
+}
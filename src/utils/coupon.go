@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"app/src/money"
+	"crypto/rand"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CouponTerms are the limits of a coupon as seen by CheckCoupon. MaxUses of 0 is unlimited.
+type CouponTerms struct {
+	IsActive  bool
+	StartDate time.Time
+	EndDate   time.Time
+	MaxUses   int
+	UsedCount int
+}
+
+// CouponRejection is why a coupon code cannot be used, Reason is stable for clients to branch on.
+type CouponRejection struct {
+	Reason  string
+	Message string
+}
+
+func (r *CouponRejection) Error() string {
+	return r.Message
+}
+
+var (
+	ErrCouponNotFound   = &CouponRejection{Reason: "not_found", Message: "Coupon not found"}
+	ErrCouponInactive   = &CouponRejection{Reason: "inactive", Message: "Coupon is not active"}
+	ErrCouponNotStarted = &CouponRejection{Reason: "not_started", Message: "Coupon cannot be used yet"}
+	ErrCouponExpired    = &CouponRejection{Reason: "expired", Message: "Coupon has expired"}
+	ErrCouponUsedUp     = &CouponRejection{Reason: "used_up", Message: "Coupon has been used up"}
+	ErrCouponApplied    = &CouponRejection{Reason: "already_applied", Message: "Coupon is already applied to this sale"}
+	ErrCouponOutlet     = &CouponRejection{Reason: "not_at_outlet", Message: "Coupon cannot be used at this outlet"}
+
+	ErrCouponNoCustomer     = &CouponRejection{Reason: "customer_required", Message: "Coupon needs a customer on the sale"}
+	ErrCouponNotEligible    = &CouponRejection{Reason: "not_eligible", Message: "Coupon is not for this customer"}
+	ErrCouponFirstOrder     = &CouponRejection{Reason: "not_first_order", Message: "Coupon is only for a first order"}
+	ErrCouponCustomerUsedUp = &CouponRejection{Reason: "customer_used_up", Message: "Customer has used up this coupon"}
+)
+
+// CouponAudience is who a coupon is for. A coupon listing customers or tiers is for the listed
+// customers and the customers of those tiers. MaxUsesPerCustomer of 0 is unlimited.
+type CouponAudience struct {
+	CustomerIDs        []uuid.UUID
+	CustomerTiers      []string
+	FirstOrderOnly     bool
+	MaxUsesPerCustomer int
+}
+
+// NeedsCustomer tells whether the coupon can only be redeemed by a known customer.
+func (a CouponAudience) NeedsCustomer() bool {
+	return len(a.CustomerIDs) > 0 || len(a.CustomerTiers) > 0 || a.FirstOrderOnly || a.MaxUsesPerCustomer > 0
+}
+
+// CouponCustomer is the customer redeeming a coupon. Uses are the sales of the customer the coupon
+// is already redeemed on and Orders the other sales of the customer.
+type CouponCustomer struct {
+	ID     uuid.UUID
+	Tier   string
+	Uses   int
+	Orders int
+}
+
+// CheckCoupon returns the rejection of a coupon that cannot be redeemed at now, nil when it can.
+func CheckCoupon(terms CouponTerms, now time.Time) error {
+	switch {
+	case !terms.IsActive:
+		return ErrCouponInactive
+	case now.Before(terms.StartDate):
+		return ErrCouponNotStarted
+	case now.After(terms.EndDate):
+		return ErrCouponExpired
+	case terms.MaxUses > 0 && terms.UsedCount >= terms.MaxUses:
+		return ErrCouponUsedUp
+	}
+
+	return nil
+}
+
+// CheckCouponOutlet returns ErrCouponOutlet when the coupon is only for other outlets. A coupon
+// without outletIDs can be redeemed at every outlet of its business.
+func CheckCouponOutlet(outletIDs []uuid.UUID, outletID uuid.UUID) error {
+	if len(outletIDs) > 0 && !slices.Contains(outletIDs, outletID) {
+		return ErrCouponOutlet
+	}
+
+	return nil
+}
+
+// CheckCouponCustomer returns the rejection of a coupon the customer cannot redeem, nil when they
+// can. customer is nil when the sale has none.
+func CheckCouponCustomer(audience CouponAudience, customer *CouponCustomer) error {
+	if !audience.NeedsCustomer() {
+		return nil
+	}
+	if customer == nil {
+		return ErrCouponNoCustomer
+	}
+
+	targeted := len(audience.CustomerIDs) > 0 || len(audience.CustomerTiers) > 0
+	if targeted && !slices.Contains(audience.CustomerIDs, customer.ID) &&
+		(customer.Tier == "" || !slices.Contains(audience.CustomerTiers, customer.Tier)) {
+		return ErrCouponNotEligible
+	}
+
+	if audience.FirstOrderOnly && customer.Orders > 0 {
+		return ErrCouponFirstOrder
+	}
+
+	if audience.MaxUsesPerCustomer > 0 && customer.Uses >= audience.MaxUsesPerCustomer {
+		return ErrCouponCustomerUsedUp
+	}
+
+	return nil
+}
+
+// couponAlphabet leaves out 0, O, 1 and I, which are easily mixed up when a code is typed in.
+const couponAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// GenerateCouponCode returns prefix followed by length random characters.
+func GenerateCouponCode(prefix string, length int) (string, error) {
+	code := make([]byte, 0, len(prefix)+length)
+	code = append(code, prefix...)
+
+	limit := big.NewInt(int64(len(couponAlphabet)))
+	for range length {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, couponAlphabet[n.Int64()])
+	}
+
+	return string(code), nil
+}
+
+// CouponDiscount is what a coupon takes off total, value percent of it or value itself, never more
+// than total.
+func CouponDiscount(percentage bool, value, total money.Amount, currency money.Currency) money.Amount {
+	discount := value
+	if percentage {
+		discount = currency.Percent(total, value.Float64())
+	}
+
+	return max(min(discount, total), 0)
+}
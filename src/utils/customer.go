@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PhoneDigits keeps the digits of a phone number, so numbers written with spaces, dashes or
+// brackets compare equal.
+func PhoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// CustomerNameKey is a name in lower case with its words separated by single spaces.
+func CustomerNameKey(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), unicode.IsSpace), " ")
+}
+
+// DuplicateGroups returns the indexes of keys that appear more than once, grouped by key in the
+// order the keys first appear. Empty keys are never duplicates.
+func DuplicateGroups(keys []string) [][]int {
+	indexes := make(map[string][]int, len(keys))
+	var order []string
+
+	for i, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, ok := indexes[key]; !ok {
+			order = append(order, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+
+	groups := [][]int{}
+	for _, key := range order {
+		if len(indexes[key]) > 1 {
+			groups = append(groups, indexes[key])
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,59 @@
+package utils
+
+import "github.com/google/uuid"
+
+// KitchenRule sends the lines of a product category, or of a single product, to a printer.
+// Exactly one of CategoryID and ProductID is set.
+type KitchenRule struct {
+	PrinterID  uuid.UUID
+	CategoryID uuid.UUID
+	ProductID  uuid.UUID
+}
+
+// KitchenLine is a sale line as seen by RouteKitchenLines.
+type KitchenLine struct {
+	ProductID  uuid.UUID
+	CategoryID uuid.UUID
+}
+
+// KitchenStation is a printer and the indexes of the lines it prepares.
+type KitchenStation struct {
+	PrinterID uuid.UUID
+	Lines     []int
+}
+
+// RouteKitchenLines sends every line to the printers routed for its product, or for its category
+// when the product has no route of its own. Lines without a route are left out. Stations come in
+// the order they are first used and keep the order of the lines.
+func RouteKitchenLines(lines []KitchenLine, rules []KitchenRule) []KitchenStation {
+	byProduct := make(map[uuid.UUID][]uuid.UUID)
+	byCategory := make(map[uuid.UUID][]uuid.UUID)
+	for _, rule := range rules {
+		if rule.ProductID != uuid.Nil {
+			byProduct[rule.ProductID] = append(byProduct[rule.ProductID], rule.PrinterID)
+		} else {
+			byCategory[rule.CategoryID] = append(byCategory[rule.CategoryID], rule.PrinterID)
+		}
+	}
+
+	var stations []KitchenStation
+	index := make(map[uuid.UUID]int)
+	for i, line := range lines {
+		printers, ok := byProduct[line.ProductID]
+		if !ok {
+			printers = byCategory[line.CategoryID]
+		}
+
+		for _, printerID := range printers {
+			station, seen := index[printerID]
+			if !seen {
+				station = len(stations)
+				index[printerID] = station
+				stations = append(stations, KitchenStation{PrinterID: printerID})
+			}
+			stations[station].Lines = append(stations[station].Lines, i)
+		}
+	}
+
+	return stations
+}
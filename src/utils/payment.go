@@ -0,0 +1,56 @@
+package utils
+
+import "app/src/money"
+
+// CashRounding is how much a cash tender rounds the balance of a sale by, to the outlet's
+// increment. Nothing is rounded without an increment, when the balance is already a multiple of
+// it or when the tender doesn't cover the rounded balance, so partial cash payments stay exact.
+func CashRounding(remaining, tendered, increment money.Amount, mode money.RoundingMode) money.Amount {
+	if increment <= 0 {
+		return 0
+	}
+
+	rounded := remaining.RoundTo(increment, mode)
+	if rounded <= 0 || rounded == remaining || tendered < rounded {
+		return 0
+	}
+
+	return rounded - remaining
+}
+
+// SplitTender splits a tendered amount into the part applied to the balance and the change due.
+// ok is false when a tender that gives no change, anything but cash, pays more than the balance.
+func SplitTender(remaining, tendered money.Amount, givesChange bool) (applied, change money.Amount, ok bool) {
+	if tendered <= remaining {
+		return tendered, 0, true
+	}
+
+	if !givesChange {
+		return 0, 0, false
+	}
+
+	return remaining, tendered - remaining, true
+}
+
+// SaleSettlement is how the status of a sale moves after its payments changed.
+type SaleSettlement int
+
+const (
+	SettlementNone     SaleSettlement = iota
+	SettlementPaid                    // an open sale is covered by its payments
+	SettlementReopened                // a paid sale no longer is, after a payment was voided
+)
+
+// SettleSale compares what was paid for a sale, open or paid, with its grand total.
+func SettleSale(isOpen, isPaid bool, paid, grandTotal money.Amount) SaleSettlement {
+	covered := paid >= grandTotal
+
+	switch {
+	case covered && isOpen:
+		return SettlementPaid
+	case !covered && isPaid:
+		return SettlementReopened
+	}
+
+	return SettlementNone
+}
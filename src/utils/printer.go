@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+)
+
+var ErrPrinterAddress = errors.New("printer address must be a private IP address on the printer port")
+
+// PrinterAddress is the host and port to reach a network printer at, port when the address has
+// none. Only private IP addresses on port are allowed, so printers can't be pointed at the
+// server itself or at hosts on the internet. Host names are refused, they could resolve anywhere.
+func PrinterAddress(address, port string) (string, error) {
+	host, hostPort, err := net.SplitHostPort(address)
+	if err != nil {
+		host, hostPort = address, port
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil || hostPort != port {
+		return "", ErrPrinterAddress
+	}
+
+	ip = ip.Unmap()
+	if !ip.IsPrivate() && !ip.IsLinkLocalUnicast() {
+		return "", ErrPrinterAddress
+	}
+
+	return net.JoinHostPort(ip.String(), port), nil
+}
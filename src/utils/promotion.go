@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"app/src/money"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Promotion actions, Value is the percent off for percent_off, the amount off for amount_off and
+// the price of one bundle for bundle_price.
+const (
+	PromotionPercentOff   = "percent_off"
+	PromotionAmountOff    = "amount_off"
+	PromotionBuyXGetY     = "buy_x_get_y"
+	PromotionCheapestFree = "cheapest_free"
+	PromotionBundlePrice  = "bundle_price"
+)
+
+// PromotionSchedule is when a promotion runs. Every bound is optional, Days are time.Weekday values
+// and StartTime and EndTime are a daily "15:04" window that may run past midnight.
+type PromotionSchedule struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	Days      []int
+	StartTime string
+	EndTime   string
+}
+
+// Runs tells whether the schedule covers at. Days and the daily window are read in the location
+// of at, callers pass it in the zone the schedule was set in.
+func (s PromotionSchedule) Runs(at time.Time) bool {
+	if s.StartDate != nil && at.Before(*s.StartDate) {
+		return false
+	}
+	if s.EndDate != nil && at.After(*s.EndDate) {
+		return false
+	}
+
+	if s.StartTime == "" || s.EndTime == "" {
+		return len(s.Days) == 0 || slices.Contains(s.Days, int(at.Weekday()))
+	}
+
+	clock := at.Format("15:04")
+	if s.StartTime <= s.EndTime {
+		return s.runsOn(at) && clock >= s.StartTime && clock < s.EndTime
+	}
+
+	// A window past midnight belongs to the day it started on
+	if clock >= s.StartTime {
+		return s.runsOn(at)
+	}
+	return clock < s.EndTime && s.runsOn(at.AddDate(0, 0, -1))
+}
+
+func (s PromotionSchedule) runsOn(day time.Time) bool {
+	return len(s.Days) == 0 || slices.Contains(s.Days, int(day.Weekday()))
+}
+
+// PromotionRule is a promotion as seen by ApplyPromotions. It only looks at the lines of
+// ProductIDs and CategoryIDs, every line when both are empty, and MinSpend is what those lines
+// must add up to. CustomerTiers limits it to customers of those tiers.
+type PromotionRule struct {
+	ID            uuid.UUID
+	Name          string
+	Priority      int
+	Stackable     bool
+	Schedule      PromotionSchedule
+	CustomerTiers []string
+	MinSpend      money.Amount
+	ProductIDs    []uuid.UUID
+	CategoryIDs   []uuid.UUID
+	Action        string
+	Value         money.Amount
+	BuyQuantity   int
+	GetQuantity   int
+}
+
+// PromotionLine is a sale line with what is charged for it after its own discount.
+type PromotionLine struct {
+	ProductID  uuid.UUID
+	CategoryID uuid.UUID
+	Quantity   int
+	Amount     money.Amount
+}
+
+// PromotionSale is what the rules are checked against besides the lines.
+type PromotionSale struct {
+	At           time.Time
+	CustomerTier string
+}
+
+type PromotionDiscount struct {
+	RuleID   uuid.UUID
+	Name     string
+	Discount money.Amount
+}
+
+// ApplyPromotions applies the rules from the highest priority down, rules of the same priority
+// in the given order. A rule that is not stackable is only applied on its own: it is skipped once
+// another rule was applied and no rule is applied after it. Together the discounts never take off
+// more than the lines add up to.
+func ApplyPromotions(
+	lines []PromotionLine, rules []PromotionRule, sale PromotionSale, currency money.Currency,
+) []PromotionDiscount {
+	ordered := slices.Clone(rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+
+	var remaining money.Amount
+	for _, line := range lines {
+		remaining += line.Amount
+	}
+
+	discounts := make([]PromotionDiscount, 0)
+	for _, rule := range ordered {
+		if !rule.Stackable && len(discounts) > 0 {
+			continue
+		}
+		if !rule.Schedule.Runs(sale.At) {
+			continue
+		}
+		if len(rule.CustomerTiers) > 0 && !slices.Contains(rule.CustomerTiers, sale.CustomerTier) {
+			continue
+		}
+
+		discount := min(rule.discount(lines, currency), remaining)
+		if discount <= 0 {
+			continue
+		}
+
+		discounts = append(discounts, PromotionDiscount{RuleID: rule.ID, Name: rule.Name, Discount: discount})
+		remaining -= discount
+
+		if !rule.Stackable {
+			break
+		}
+	}
+
+	return discounts
+}
+
+// discount is what the rule takes off the lines it looks at, zero when they do not qualify.
+func (rule *PromotionRule) discount(lines []PromotionLine, currency money.Currency) money.Amount {
+	var eligible []PromotionLine
+	var subtotal money.Amount
+	for _, line := range lines {
+		if rule.covers(line) {
+			eligible = append(eligible, line)
+			subtotal += line.Amount
+		}
+	}
+
+	if len(eligible) == 0 || subtotal < rule.MinSpend {
+		return 0
+	}
+
+	switch rule.Action {
+	case PromotionPercentOff:
+		return currency.Percent(subtotal, rule.Value.Float64())
+	case PromotionAmountOff:
+		return min(rule.Value, subtotal)
+	case PromotionBuyXGetY:
+		return buyXGetY(unitPrices(eligible, currency), rule.BuyQuantity, rule.GetQuantity)
+	case PromotionCheapestFree:
+		units := unitPrices(eligible, currency)
+		if len(units) < max(rule.BuyQuantity, 2) {
+			return 0
+		}
+		return units[len(units)-1]
+	case PromotionBundlePrice:
+		return bundleDiscount(eligible, rule.ProductIDs, rule.Value, currency)
+	}
+
+	return 0
+}
+
+func (rule *PromotionRule) covers(line PromotionLine) bool {
+	if len(rule.ProductIDs) == 0 && len(rule.CategoryIDs) == 0 {
+		return true
+	}
+
+	return slices.Contains(rule.ProductIDs, line.ProductID) || slices.Contains(rule.CategoryIDs, line.CategoryID)
+}
+
+// unitPrices splits the lines into the price of every unit, most expensive first.
+func unitPrices(lines []PromotionLine, currency money.Currency) []money.Amount {
+	var units []money.Amount
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			continue
+		}
+
+		weights := make([]money.Amount, line.Quantity)
+		for i := range weights {
+			weights[i] = 1
+		}
+		units = append(units, currency.Allocate(line.Amount, weights)...)
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		return units[i] > units[j]
+	})
+
+	return units
+}
+
+// buyXGetY groups the units from the most expensive down into sets of buy plus get units, the
+// cheapest get units of every complete set are free.
+func buyXGetY(units []money.Amount, buy, get int) money.Amount {
+	if buy <= 0 || get <= 0 {
+		return 0
+	}
+
+	var discount money.Amount
+	size := buy + get
+	for start := 0; start+size <= len(units); start += size {
+		for _, unit := range units[start+buy : start+size] {
+			discount += unit
+		}
+	}
+
+	return discount
+}
+
+// bundleDiscount takes off what one unit of each bundled product costs over the bundle price, for
+// as many complete bundles as the lines make up. The most expensive units are bundled first.
+func bundleDiscount(
+	lines []PromotionLine, productIDs []uuid.UUID, price money.Amount, currency money.Currency,
+) money.Amount {
+	if len(productIDs) == 0 {
+		return 0
+	}
+
+	bundles := -1
+	units := make([][]money.Amount, len(productIDs))
+	for i, productID := range productIDs {
+		var productLines []PromotionLine
+		for _, line := range lines {
+			if line.ProductID == productID {
+				productLines = append(productLines, line)
+			}
+		}
+
+		units[i] = unitPrices(productLines, currency)
+		if bundles < 0 || len(units[i]) < bundles {
+			bundles = len(units[i])
+		}
+	}
+
+	var discount money.Amount
+	for b := range bundles {
+		var regular money.Amount
+		for i := range units {
+			regular += units[i][b]
+		}
+		discount += max(regular-price, 0)
+	}
+
+	return discount
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidTableToken = errors.New("invalid table token")
+
+// SignTableToken signs the token of a table's ordering link as "<table id>.<version>.<signature>".
+// Bumping version revokes the tokens signed with the previous one.
+func SignTableToken(secret string, tableID uuid.UUID, version int) string {
+	payload := tableID.String() + "." + strconv.Itoa(version)
+	return payload + "." + tableSignature(secret, payload)
+}
+
+// ParseTableToken checks the signature of a table token and returns its table and version.
+func ParseTableToken(secret, token string) (uuid.UUID, int, error) {
+	separator := strings.LastIndexByte(token, '.')
+	if separator < 0 {
+		return uuid.Nil, 0, ErrInvalidTableToken
+	}
+
+	payload := token[:separator]
+	if !hmac.Equal([]byte(token[separator+1:]), []byte(tableSignature(secret, payload))) {
+		return uuid.Nil, 0, ErrInvalidTableToken
+	}
+
+	tableID, versionStr, _ := strings.Cut(payload, ".")
+	id, err := uuid.Parse(tableID)
+	if err != nil {
+		return uuid.Nil, 0, ErrInvalidTableToken
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return uuid.Nil, 0, ErrInvalidTableToken
+	}
+
+	return id, version, nil
+}
+
+// TableTokenKey is the table of a token signed with secret, or empty when the signature is wrong.
+// Tokens of an older version of the link still give their table, requests are rate limited and
+// kept apart by it before the version is checked.
+func TableTokenKey(secret, token string) string {
+	tableID, _, err := ParseTableToken(secret, token)
+	if err != nil {
+		return ""
+	}
+
+	return tableID.String()
+}
+
+func tableSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"app/src/money"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// TaxRule is a tax or service charge as seen by CalculateTaxes. Rate is a percentage.
+type TaxRule struct {
+	ID            uuid.UUID
+	Name          string
+	ServiceCharge bool
+	Rate          float64
+	// Inclusive taxes are already part of the line amounts. Service charges are always added on top.
+	Inclusive bool
+	// Taxable marks a service charge that the taxes are charged on as well.
+	Taxable           bool
+	ExemptCategoryIDs map[uuid.UUID]bool
+}
+
+// TaxableLine is a sale line with the amount charged for it after discounts.
+type TaxableLine struct {
+	CategoryID uuid.UUID
+	Amount     money.Amount
+}
+
+// TaxCharge is the share of one rule on one line. Included is the part of Amount that is
+// already in the line amount because the tax is inclusive.
+type TaxCharge struct {
+	RuleID        uuid.UUID
+	Name          string
+	ServiceCharge bool
+	Rate          float64
+	Inclusive     bool
+	Base          money.Amount
+	Amount        money.Amount
+	Included      money.Amount
+}
+
+type TaxedLine struct {
+	ServiceCharge money.Amount
+	Tax           money.Amount
+	TaxIncluded   money.Amount
+	Charges       []TaxCharge
+}
+
+type TaxResult struct {
+	Lines         []TaxedLine
+	ServiceCharge money.Amount
+	Tax           money.Amount
+	TaxIncluded   money.Amount
+}
+
+// CalculateTaxes applies the service charges and then the taxes to the lines. Every rule is
+// rounded once over the whole sale with the currency rounding and that total is spread back
+// over the lines, so the line breakdown always adds up to the sale totals.
+func CalculateTaxes(lines []TaxableLine, rules []TaxRule, currency money.Currency) TaxResult {
+	result := TaxResult{Lines: make([]TaxedLine, len(lines))}
+	cents := money.Currency{Code: currency.Code, Decimals: 2, Rounding: currency.Rounding}
+
+	// Service charges and exclusive taxes are charged on the amount without the inclusive taxes
+	net := make([]money.Amount, len(lines))
+	for i, line := range lines {
+		inclusiveRate := 0.0
+		for _, rule := range rules {
+			if !rule.ServiceCharge && rule.Inclusive && !rule.ExemptCategoryIDs[line.CategoryID] {
+				inclusiveRate += rule.Rate
+			}
+		}
+		net[i] = cents.Ratio(line.Amount, 100000, 100000+int64(math.Round(inclusiveRate*1000)))
+	}
+
+	taxableCharges := make([]money.Amount, len(lines))
+	for _, rule := range rules {
+		if !rule.ServiceCharge {
+			continue
+		}
+
+		bases := make([]money.Amount, len(lines))
+		for i, line := range lines {
+			if !rule.ExemptCategoryIDs[line.CategoryID] {
+				bases[i] = net[i]
+			}
+		}
+
+		amounts := allocateRate(bases, rule.Rate, currency)
+		for i := range lines {
+			if bases[i] == 0 {
+				continue
+			}
+
+			if rule.Taxable {
+				taxableCharges[i] += amounts[i]
+			}
+			result.Lines[i].ServiceCharge += amounts[i]
+			result.Lines[i].Charges = append(result.Lines[i].Charges, newTaxCharge(rule, bases[i], amounts[i], 0))
+		}
+	}
+
+	for _, rule := range rules {
+		if rule.ServiceCharge {
+			continue
+		}
+
+		onItems := make([]money.Amount, len(lines))
+		onCharges := make([]money.Amount, len(lines))
+		for i, line := range lines {
+			if !rule.ExemptCategoryIDs[line.CategoryID] {
+				onItems[i] = net[i]
+				onCharges[i] = taxableCharges[i]
+			}
+		}
+
+		// A service charge is never part of the price, so tax on it is added even for inclusive taxes
+		itemTaxes := allocateRate(onItems, rule.Rate, currency)
+		chargeTaxes := allocateRate(onCharges, rule.Rate, currency)
+		for i := range lines {
+			if onItems[i] == 0 && onCharges[i] == 0 {
+				continue
+			}
+
+			var included money.Amount
+			if rule.Inclusive {
+				included = itemTaxes[i]
+			}
+
+			amount := itemTaxes[i] + chargeTaxes[i]
+			result.Lines[i].Tax += amount
+			result.Lines[i].TaxIncluded += included
+			result.Lines[i].Charges = append(result.Lines[i].Charges,
+				newTaxCharge(rule, onItems[i]+onCharges[i], amount, included))
+		}
+	}
+
+	for _, line := range result.Lines {
+		result.ServiceCharge += line.ServiceCharge
+		result.Tax += line.Tax
+		result.TaxIncluded += line.TaxIncluded
+	}
+
+	return result
+}
+
+// allocateRate charges rate over the bases, rounding the total once. Lines without a base
+// never receive a rounding remainder.
+func allocateRate(bases []money.Amount, rate float64, currency money.Currency) []money.Amount {
+	var total money.Amount
+	for _, base := range bases {
+		total += base
+	}
+
+	return currency.Allocate(currency.Percent(total, rate), bases)
+}
+
+func newTaxCharge(rule TaxRule, base, amount, included money.Amount) TaxCharge {
+	return TaxCharge{
+		RuleID:        rule.ID,
+		Name:          rule.Name,
+		ServiceCharge: rule.ServiceCharge,
+		Rate:          rule.Rate,
+		Inclusive:     rule.Inclusive && !rule.ServiceCharge,
+		Base:          base,
+		Amount:        amount,
+		Included:      included,
+	}
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"app/src/money"
+	"math"
+)
+
+// DistributeTips splits a tip pool proportionally to the weights, rounded to the currency so the
+// shares always add up to the pool. Nothing is distributed when no one has a weight.
+func DistributeTips(pool money.Amount, weights []float64, currency money.Currency) []money.Amount {
+	// Weights are exact to three decimals, which is plenty for hours and role weights
+	units := make([]money.Amount, len(weights))
+	var total money.Amount
+	for i, weight := range weights {
+		if weight > 0 {
+			units[i] = money.Amount(math.Round(weight * 1000))
+		}
+		total += units[i]
+	}
+
+	if pool <= 0 || total == 0 {
+		return make([]money.Amount, len(weights))
+	}
+
+	return currency.Allocate(pool, units)
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"slices"
+	"time"
+)
+
+// EstimateWait is how long a party waits for a table when ahead parties are in line before it. freeAt
+// holds when each table that fits the party is expected to be free. The parties ahead take the tables
+// in the order they free up and keep them for turn. Without any table the wait is a turn per party.
+func EstimateWait(freeAt []time.Time, ahead int, turn time.Duration, now time.Time) time.Duration {
+	if len(freeAt) == 0 {
+		return turn * time.Duration(ahead+1)
+	}
+
+	free := slices.Clone(freeAt)
+	for i := range free {
+		if free[i].Before(now) {
+			free[i] = now
+		}
+	}
+	slices.SortFunc(free, func(a, b time.Time) int { return a.Compare(b) })
+
+	for range ahead {
+		free[0] = free[0].Add(turn)
+		slices.SortFunc(free, func(a, b time.Time) int { return a.Compare(b) })
+	}
+
+	return free[0].Sub(now)
+}
@@ -0,0 +1,28 @@
+package validation
+
+import "app/src/money"
+
+// CreateApproval asks a manager for an approval. With the approver and their PIN it is granted
+// right away, without them it waits for the manager to answer from their device.
+type CreateApproval struct {
+	Action      string       `json:"action" validate:"required,oneof=void_sale discount price_override void_payment"`
+	SaleID      string       `json:"sale_id" validate:"omitempty,uuid"`
+	Amount      money.Amount `json:"amount" validate:"min=0" swaggertype:"number" example:"10000"`
+	Reason      string       `json:"reason" validate:"required,max=500"`
+	RequestedBy string       `json:"requested_by" validate:"required,uuid"`
+	ApproverID  string       `json:"approver_id" validate:"required_with=Pin,omitempty,uuid"`
+	Pin         string       `json:"pin" validate:"required_with=ApproverID,omitempty,numeric,min=4,max=8" example:"1234"`
+}
+
+type QueryApproval struct {
+	Page   int    `validate:"omitempty,number,max=50"`
+	Limit  int    `validate:"omitempty,number,max=50"`
+	Status string `validate:"omitempty,oneof=pending approved rejected used"`
+}
+
+// UpdateStaffPin sets the PIN of a staff member. Owners and admins of the business can set any
+// PIN, other users need the current one.
+type UpdateStaffPin struct {
+	Pin        string `json:"pin" validate:"required,numeric,min=4,max=8" example:"1234"`
+	CurrentPin string `json:"current_pin" validate:"omitempty,numeric,min=4,max=8" example:"4321"`
+}
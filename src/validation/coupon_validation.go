@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"app/src/money"
+	"time"
+)
+
+// CouponOffer is what a coupon takes off and who can redeem it. A percentage discount_value is the
+// percent taken off, 10 is 10%. Coupons listing outlets are only redeemed there, coupons listing
+// customers or tiers are only for those customers.
+type CouponOffer struct {
+	OutletIDs          []string     `json:"outlet_ids" validate:"omitempty,max=100,dive,uuid"`
+	Description        string       `json:"description" validate:"omitempty,max=500" example:"10% off"`
+	DiscountType       string       `json:"discount_type" validate:"required,oneof=percentage fixed" example:"percentage"`
+	DiscountValue      money.Amount `json:"discount_value" validate:"required,gt=0" swaggertype:"number" example:"10"`
+	MaxUsesPerCustomer int          `json:"max_uses_per_customer" validate:"omitempty,min=0,max=1000" example:"1"`
+	FirstOrderOnly     bool         `json:"first_order_only" example:"false"`
+	CustomerIDs        []string     `json:"customer_ids" validate:"omitempty,max=500,dive,uuid"`
+	CustomerTiers      []string     `json:"customer_tiers" validate:"omitempty,max=20,dive,required,max=50"`
+	StartDate          time.Time    `json:"start_date" validate:"required" example:"2025-11-01T00:00:00Z"`
+	EndDate            time.Time    `json:"end_date" validate:"required,gtfield=StartDate" example:"2025-11-30T23:59:59Z"`
+}
+
+// CreateCoupon creates a single code, max_uses of 0 is unlimited.
+type CreateCoupon struct {
+	CouponOffer
+	Code    string `json:"code" validate:"required,min=3,max=50,alphanum" example:"WELCOME10"`
+	MaxUses int    `json:"max_uses" validate:"min=0,max=1000000" example:"100"`
+}
+
+// GenerateCoupons creates count unique single use codes for a campaign, each is prefix followed by
+// random characters.
+type GenerateCoupons struct {
+	CouponOffer
+	Campaign string `json:"campaign" validate:"required,max=100" example:"Newsletter November"`
+	Prefix   string `json:"prefix" validate:"omitempty,max=20,alphanum" example:"NOV"`
+	Count    int    `json:"count" validate:"required,min=1,max=5000" example:"500"`
+}
+
+type UpdateCoupon struct {
+	Description        *string       `json:"description" validate:"omitempty,max=500" example:"10% off"`
+	DiscountValue      *money.Amount `json:"discount_value" validate:"omitempty,gt=0" swaggertype:"number" example:"10"`
+	MaxUses            *int          `json:"max_uses" validate:"omitempty,min=0,max=1000000" example:"100"`
+	MaxUsesPerCustomer *int          `json:"max_uses_per_customer" validate:"omitempty,min=0,max=1000" example:"1"`
+	FirstOrderOnly     *bool         `json:"first_order_only" example:"false"`
+	OutletIDs          *[]string     `json:"outlet_ids" validate:"omitempty,max=100,dive,uuid"`
+	CustomerIDs        *[]string     `json:"customer_ids" validate:"omitempty,max=500,dive,uuid"`
+	CustomerTiers      *[]string     `json:"customer_tiers" validate:"omitempty,max=20,dive,required,max=50"`
+	StartDate          *time.Time    `json:"start_date" example:"2025-11-01T00:00:00+07:00"`
+	EndDate            *time.Time    `json:"end_date" example:"2025-11-30T23:59:59+07:00"`
+	IsActive           *bool         `json:"is_active" example:"true"`
+}
+
+type QueryCoupon struct {
+	Page     int    `validate:"omitempty,number,max=50"`
+	Limit    int    `validate:"omitempty,number,max=50"`
+	OutletID string `validate:"omitempty,uuid"`
+	Campaign string `validate:"omitempty,max=100"`
+	Search   string `validate:"omitempty,max=100"`
+}
+
+type ApplyCoupon struct {
+	Code string `json:"code" validate:"required,max=100" example:"WELCOME10"`
+}
+
+// ValidateCoupon checks a code before it is applied. The discount is worked out on the open sale
+// when SaleID is given, otherwise on Total for the customer.
+type ValidateCoupon struct {
+	Code       string       `json:"code" validate:"required,max=100" example:"WELCOME10"`
+	OutletID   string       `json:"outlet_id" validate:"required,uuid"`
+	SaleID     string       `json:"sale_id" validate:"omitempty,uuid"`
+	CustomerID string       `json:"customer_id" validate:"omitempty,uuid"`
+	Total      money.Amount `json:"total" validate:"omitempty,min=0" swaggertype:"number" example:"50000"`
+}
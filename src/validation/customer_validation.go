@@ -0,0 +1,37 @@
+package validation
+
+// CreateCustomer adds a customer to the business, outlet_id is the outlet they were added at.
+type CreateCustomer struct {
+	OutletID string `json:"outlet_id" validate:"omitempty,uuid"`
+	Name     string `json:"name" validate:"required,max=255" example:"Budi"`
+	Email    string `json:"email" validate:"omitempty,email,max=255" example:"budi@example.com"`
+	Phone    string `json:"phone" validate:"omitempty,max=50" example:"+628123456789"`
+	Address  string `json:"address" validate:"omitempty,max=500" example:"Jl. Sudirman 1, Jakarta"`
+	Tier     string `json:"tier" validate:"omitempty,max=50" example:"gold"`
+}
+
+type UpdateCustomer struct {
+	Name    string  `json:"name" validate:"omitempty,max=255" example:"Budi"`
+	Email   *string `json:"email" validate:"omitempty,len=0|email,max=255" example:"budi@example.com"`
+	Phone   *string `json:"phone" validate:"omitempty,max=50" example:"+628123456789"`
+	Address *string `json:"address" validate:"omitempty,max=500" example:"Jl. Sudirman 1, Jakarta"`
+	Tier    *string `json:"tier" validate:"omitempty,max=50" example:"gold"`
+}
+
+// MergeCustomers lists the duplicates to merge into a customer.
+type MergeCustomers struct {
+	CustomerIDs []string `json:"customer_ids" validate:"required,min=1,max=20,dive,uuid"`
+}
+
+type QueryCustomer struct {
+	Page   int    `validate:"omitempty,number,max=50"`
+	Limit  int    `validate:"omitempty,number,max=50"`
+	Search string `validate:"omitempty,max=100"`
+	Tier   string `validate:"omitempty,max=50"`
+}
+
+type QueryCustomerVisit struct {
+	Page     int    `validate:"omitempty,number,max=50"`
+	Limit    int    `validate:"omitempty,number,max=50"`
+	OutletID string `validate:"omitempty,uuid"`
+}
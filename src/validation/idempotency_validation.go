@@ -0,0 +1,5 @@
+package validation
+
+type IdempotencyKey struct {
+	Key string `validate:"required,max=255"`
+}
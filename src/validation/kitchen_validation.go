@@ -0,0 +1,19 @@
+package validation
+
+// CreateKitchenRoute routes either a product category or a single product to a printer.
+type CreateKitchenRoute struct {
+	PrinterID         string `json:"printer_id" validate:"required,uuid"`
+	ProductCategoryID string `json:"product_category_id" validate:"required_without=ProductID,omitempty,uuid"`
+	ProductID         string `json:"product_id" validate:"required_without=ProductCategoryID,omitempty,uuid"`
+}
+
+// QueryKitchenItem lists the items on the kitchen displays of an outlet, the ones still to be
+// served when status is empty.
+type QueryKitchenItem struct {
+	StationID string `validate:"omitempty,uuid"`
+	Status    string `validate:"omitempty,oneof=new in_progress ready served void"`
+}
+
+type UpdateKitchenItem struct {
+	Status string `json:"status" validate:"required,oneof=in_progress ready served" example:"ready"`
+}
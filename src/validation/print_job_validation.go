@@ -0,0 +1,14 @@
+package validation
+
+type QueryPrintJob struct {
+	Page   int    `validate:"omitempty,number,max=50"`
+	Limit  int    `validate:"omitempty,number,max=50"`
+	Status string `validate:"omitempty,oneof=pending printing printed failed"`
+}
+
+// PrintReceipt sends the receipt of a sale to a network printer, the outlet's default printer
+// when printer_id is empty.
+type PrintReceipt struct {
+	PrinterID string `json:"printer_id" validate:"omitempty,uuid"`
+	Language  string `json:"language" validate:"omitempty,oneof=en id" example:"en"`
+}
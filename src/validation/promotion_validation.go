@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"app/src/money"
+	"time"
+)
+
+// CreatePromotion sets up a promotion applied to sales automatically. Value is the percent off for
+// percent_off, the amount off for amount_off and the price of one bundle for bundle_price. The daily
+// window needs both a start and an end time.
+type CreatePromotion struct {
+	OutletID      string       `json:"outlet_id" validate:"omitempty,uuid"`
+	Name          string       `json:"name" validate:"required,max=100" example:"Happy hour"`
+	Description   string       `json:"description" validate:"omitempty,max=500" example:"20% off drinks"`
+	Priority      int          `json:"priority" validate:"omitempty,min=0,max=1000" example:"10"`
+	Stackable     *bool        `json:"stackable" example:"true"`
+	StartDate     *time.Time   `json:"start_date" example:"2025-11-01T00:00:00+07:00"`
+	EndDate       *time.Time   `json:"end_date" example:"2025-11-30T23:59:59+07:00"`
+	DaysOfWeek    []int        `json:"days_of_week" validate:"omitempty,max=7,dive,min=0,max=6"`
+	StartTime     string       `json:"start_time" validate:"omitempty,datetime=15:04" example:"15:00"`
+	EndTime       string       `json:"end_time" validate:"omitempty,datetime=15:04" example:"18:00"`
+	MinSpend      money.Amount `json:"min_spend" validate:"omitempty,min=0" swaggertype:"number" example:"0"`
+	ProductIDs    []string     `json:"product_ids" validate:"omitempty,max=50,dive,uuid"`
+	CategoryIDs   []string     `json:"category_ids" validate:"omitempty,max=50,dive,uuid"`
+	CustomerTiers []string     `json:"customer_tiers" validate:"omitempty,max=20,dive,required,max=50"`
+
+	Action string `json:"action" validate:"required,oneof=percent_off amount_off buy_x_get_y cheapest_free bundle_price"`
+
+	Value       money.Amount `json:"value" validate:"omitempty,min=0" swaggertype:"number" example:"20"`
+	BuyQuantity int          `json:"buy_quantity" validate:"omitempty,min=1,max=100" example:"2"`
+	GetQuantity int          `json:"get_quantity" validate:"omitempty,min=1,max=100" example:"1"`
+}
+
+// UpdatePromotion changes the fields that are sent. Empty start and end times clear the daily window.
+type UpdatePromotion struct {
+	Name          string        `json:"name" validate:"omitempty,max=100" example:"Happy hour"`
+	Description   *string       `json:"description" validate:"omitempty,max=500" example:"20% off drinks"`
+	Priority      *int          `json:"priority" validate:"omitempty,min=0,max=1000" example:"10"`
+	Stackable     *bool         `json:"stackable" example:"true"`
+	IsActive      *bool         `json:"is_active" example:"true"`
+	StartDate     *time.Time    `json:"start_date" example:"2025-11-01T00:00:00+07:00"`
+	EndDate       *time.Time    `json:"end_date" example:"2025-11-30T23:59:59+07:00"`
+	DaysOfWeek    *[]int        `json:"days_of_week" validate:"omitempty,max=7,dive,min=0,max=6"`
+	StartTime     *string       `json:"start_time" validate:"omitempty,len=0|datetime=15:04" example:"15:00"`
+	EndTime       *string       `json:"end_time" validate:"omitempty,len=0|datetime=15:04" example:"18:00"`
+	MinSpend      *money.Amount `json:"min_spend" validate:"omitempty,min=0" swaggertype:"number" example:"0"`
+	ProductIDs    *[]string     `json:"product_ids" validate:"omitempty,max=50,dive,uuid"`
+	CategoryIDs   *[]string     `json:"category_ids" validate:"omitempty,max=50,dive,uuid"`
+	CustomerTiers *[]string     `json:"customer_tiers" validate:"omitempty,max=20,dive,required,max=50"`
+	Value         *money.Amount `json:"value" validate:"omitempty,min=0" swaggertype:"number" example:"20"`
+	BuyQuantity   *int          `json:"buy_quantity" validate:"omitempty,min=1,max=100" example:"2"`
+	GetQuantity   *int          `json:"get_quantity" validate:"omitempty,min=1,max=100" example:"1"`
+}
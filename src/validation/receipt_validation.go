@@ -0,0 +1,7 @@
+package validation
+
+type QueryReceipt struct {
+	PrinterID  string `validate:"omitempty,uuid"`
+	PaperWidth int    `validate:"omitempty,oneof=58 80"`
+	Language   string `validate:"omitempty,oneof=en id"`
+}
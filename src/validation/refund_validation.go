@@ -0,0 +1,15 @@
+package validation
+
+type CreateRefund struct {
+	PaymentMethodID string             `json:"payment_method_id" validate:"required,uuid"`
+	OutletStaffID   string             `json:"outlet_staff_id" validate:"omitempty,uuid"`
+	ReasonCode      string             `json:"reason_code" validate:"required,oneof=damaged wrong_item quality_issue customer_request other"`
+	Note            string             `json:"note" validate:"omitempty,max=500"`
+	Restock         bool               `json:"restock"`
+	Items           []CreateRefundItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type CreateRefundItem struct {
+	SaleItemID string `json:"sale_item_id" validate:"required,uuid"`
+	Quantity   int    `json:"quantity" validate:"required,min=1"`
+}
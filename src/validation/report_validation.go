@@ -0,0 +1,10 @@
+package validation
+
+type QueryEndOfDay struct {
+	Date string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+type QueryTipPool struct {
+	From string `validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+}
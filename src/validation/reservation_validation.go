@@ -0,0 +1,59 @@
+package validation
+
+import "time"
+
+// CreateReservation books a table for a party. Without table_id the smallest free table that fits
+// the party is picked. Name, phone and email are taken from the customer when left empty.
+type CreateReservation struct {
+	CustomerID      string    `json:"customer_id" validate:"omitempty,uuid"`
+	TableID         string    `json:"table_id" validate:"omitempty,uuid"`
+	Name            string    `json:"name" validate:"required_without=CustomerID,max=100" example:"Budi"`
+	Phone           string    `json:"phone" validate:"omitempty,max=30" example:"+628123456789"`
+	Email           string    `json:"email" validate:"omitempty,email,max=255" example:"budi@example.com"`
+	PartySize       int       `json:"party_size" validate:"required,min=1,max=100" example:"4"`
+	ReservedAt      time.Time `json:"reserved_at" validate:"required" example:"2025-10-20T19:00:00+07:00"`
+	DurationMinutes int       `json:"duration_minutes" validate:"omitempty,min=15,max=720" example:"90"`
+	Note            string    `json:"note" validate:"omitempty,max=500" example:"Birthday, high chair"`
+}
+
+type UpdateReservation struct {
+	TableID         string     `json:"table_id" validate:"omitempty,uuid"`
+	Name            string     `json:"name" validate:"omitempty,max=100" example:"Budi"`
+	Phone           *string    `json:"phone" validate:"omitempty,max=30" example:"+628123456789"`
+	Email           *string    `json:"email" validate:"omitempty,len=0|email,max=255" example:"budi@example.com"`
+	PartySize       int        `json:"party_size" validate:"omitempty,min=1,max=100" example:"6"`
+	ReservedAt      *time.Time `json:"reserved_at" example:"2025-10-20T19:30:00+07:00"`
+	DurationMinutes int        `json:"duration_minutes" validate:"omitempty,min=15,max=720" example:"120"`
+	Note            *string    `json:"note" validate:"omitempty,max=500" example:"Birthday, high chair"`
+}
+
+// UpdateReservationStatus moves a booked reservation to confirmed, seated, cancelled or no_show, and a
+// seated one to completed.
+type UpdateReservationStatus struct {
+	Status string `json:"status" validate:"required,oneof=confirmed seated completed cancelled no_show"`
+}
+
+type QueryReservation struct {
+	Date   string `validate:"omitempty,datetime=2006-01-02"`
+	Status string `validate:"omitempty,oneof=booked confirmed seated completed cancelled no_show"`
+}
+
+type QueryTableSuggestion struct {
+	PartySize       int    `validate:"required,min=1,max=100"`
+	ReservedAt      string `validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	DurationMinutes int    `validate:"omitempty,min=15,max=720"`
+}
+
+type CreateWaitlistEntry struct {
+	CustomerID string `json:"customer_id" validate:"omitempty,uuid"`
+	Name       string `json:"name" validate:"required_without=CustomerID,max=100" example:"Sari"`
+	Phone      string `json:"phone" validate:"omitempty,max=30" example:"+628123456789"`
+	PartySize  int    `json:"party_size" validate:"required,min=1,max=100" example:"2"`
+	Note       string `json:"note" validate:"omitempty,max=500" example:"Prefers outdoor"`
+}
+
+// UpdateWaitlistEntry takes a party off the waitlist, seated at table_id or gone.
+type UpdateWaitlistEntry struct {
+	Status  string `json:"status" validate:"required,oneof=seated left" example:"seated"`
+	TableID string `json:"table_id" validate:"omitempty,uuid"`
+}
@@ -0,0 +1,20 @@
+package validation
+
+import "app/src/money"
+
+type CreateSalePayment struct {
+	PaymentMethodID string       `json:"payment_method_id" validate:"required,uuid"`
+	Amount          money.Amount `json:"amount" validate:"required,gt=0" swaggertype:"number" example:"50000"`
+	Reference       string       `json:"reference" validate:"omitempty,max=255"`
+	Pending         bool         `json:"pending"`
+	Tip             money.Amount `json:"tip" validate:"omitempty,min=0,excluded_with=TipPercent" swaggertype:"number"`
+	TipPercent      float64      `json:"tip_percent" validate:"omitempty,gt=0,max=100" example:"10"`
+	OutletStaffID   string       `json:"outlet_staff_id" validate:"omitempty,uuid"`
+}
+
+// UpdateSalePayment changes the status of a payment. Voiding a paid payment needs a void_payment
+// approval covering its amount.
+type UpdateSalePayment struct {
+	Status      string   `json:"status" validate:"required,oneof=paid failed void" example:"paid"`
+	ApprovalIDs []string `json:"approval_ids" validate:"omitempty,dive,uuid"`
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"app/src/money"
+	"time"
+)
+
+type SplitSale struct {
+	Mode   string       `json:"mode" validate:"required,oneof=items seats equal" example:"items"`
+	Checks []SplitCheck `json:"checks" validate:"required_if=Mode items,dive"`
+	Parts  int          `json:"parts" validate:"required_if=Mode equal,omitempty,min=2,max=20" example:"2"`
+}
+
+type SplitCheck struct {
+	Items []SplitCheckItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// SplitCheckItem moves a whole line when neither quantity nor fraction is given,
+// a number of units when quantity is given, or a share of the line when fraction is given.
+type SplitCheckItem struct {
+	SaleItemID string  `json:"sale_item_id" validate:"required,uuid"`
+	Quantity   int     `json:"quantity" validate:"omitempty,min=1,excluded_with=Fraction"`
+	Fraction   float64 `json:"fraction" validate:"omitempty,gt=0,lt=1" example:"0.5"`
+}
+
+// SaleOrder is how a sale is served, dine-in when the order type is empty. Dine-in sales need
+// a table and delivery sales an address and a contact, only they are charged a delivery fee.
+type SaleOrder struct {
+	OrderType       string       `json:"order_type" validate:"omitempty,oneof=dine_in takeaway delivery" example:"dine_in"`
+	TableID         string       `json:"table_id" validate:"omitempty,uuid"`
+	DeliveryAddress string       `json:"delivery_address" validate:"required_if=OrderType delivery,max=500"`
+	DeliveryContact string       `json:"delivery_contact" validate:"required_if=OrderType delivery,max=100"`
+	DeliveryPhone   string       `json:"delivery_phone" validate:"required_if=OrderType delivery,max=30"`
+	DeliveryFee     money.Amount `json:"delivery_fee" validate:"min=0" swaggertype:"number"`
+	PickupAt        *time.Time   `json:"pickup_at" validate:"excluded_unless=OrderType takeaway"`
+}
+
+type CreateSale struct {
+	SaleOrder
+	OutletID      string           `json:"outlet_id" validate:"required,uuid"`
+	OutletStaffID string           `json:"outlet_staff_id" validate:"required,uuid"`
+	CustomerID    string           `json:"customer_id" validate:"omitempty,uuid"`
+	Note          string           `json:"note" validate:"omitempty,max=500"`
+	Items         []CreateSaleItem `json:"items" validate:"omitempty,dive"`
+	ApprovalIDs   []string         `json:"approval_ids" validate:"omitempty,dive,uuid"`
+}
+
+// CreateSaleItem is priced from the catalog unless Price overrides it, which needs a manager's approval.
+type CreateSaleItem struct {
+	ProductID  string        `json:"product_id" validate:"required,uuid"`
+	Quantity   int           `json:"quantity" validate:"required,min=1" example:"1"`
+	Discount   money.Amount  `json:"discount" validate:"omitempty,min=0" swaggertype:"number" example:"0"`
+	SeatNumber *int          `json:"seat_number" validate:"omitempty,min=1"`
+	Price      *money.Amount `json:"price" validate:"omitempty,min=0" swaggertype:"number"`
+	Note       *string       `json:"note" validate:"omitempty,max=255" example:"No onions"`
+	Modifiers  []string      `json:"modifiers" validate:"omitempty,max=20,dive,required,max=100"`
+}
+
+type AddSaleItems struct {
+	Items       []CreateSaleItem `json:"items" validate:"required,min=1,dive"`
+	ApprovalIDs []string         `json:"approval_ids" validate:"omitempty,dive,uuid"`
+}
+
+type VoidSale struct {
+	Reason     string `json:"reason" validate:"required,max=500"`
+	ApprovalID string `json:"approval_id" validate:"omitempty,uuid"`
+}
+
+type TransferSale struct {
+	OutletStaffID string `json:"outlet_staff_id" validate:"required,uuid"`
+}
+
+type QuerySale struct {
+	Page          int    `validate:"omitempty,number,max=50"`
+	Limit         int    `validate:"omitempty,number,max=50"`
+	OutletID      string `validate:"omitempty,uuid"`
+	TableID       string `validate:"omitempty,uuid"`
+	OrderType     string `validate:"omitempty,oneof=dine_in takeaway delivery"`
+	OutletStaffID string `validate:"omitempty,uuid"`
+	Status        string `validate:"omitempty,oneof=open hold unpaid paid void refunded merged"`
+}
@@ -0,0 +1,35 @@
+package validation
+
+// SelfOrderItem is a product guests put in their cart, priced from the menu of the table's outlet.
+type SelfOrderItem struct {
+	ProductID string   `json:"product_id" validate:"required,uuid"`
+	Quantity  int      `json:"quantity" validate:"required,min=1,max=50" example:"1"`
+	Note      *string  `json:"note" validate:"omitempty,max=255" example:"No onions"`
+	Modifiers []string `json:"modifiers" validate:"omitempty,max=20,dive,required,max=100"`
+}
+
+type SelfOrderCart struct {
+	Items []SelfOrderItem `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+type CreateTableOrder struct {
+	Name  string          `json:"name" validate:"omitempty,max=100" example:"Sari"`
+	Note  string          `json:"note" validate:"omitempty,max=500" example:"Birthday, bring the cake last"`
+	Items []SelfOrderItem `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+// ConfirmTableOrder needs the staff to open the sale with when the table has no open sale.
+type ConfirmTableOrder struct {
+	OutletStaffID string `json:"outlet_staff_id" validate:"omitempty,uuid"`
+}
+
+type RejectTableOrder struct {
+	Reason string `json:"reason" validate:"required,max=255" example:"Kitchen is closed"`
+}
+
+type QueryTableOrder struct {
+	Page    int    `validate:"omitempty,number,max=50"`
+	Limit   int    `validate:"omitempty,number,max=50"`
+	Status  string `validate:"omitempty,oneof=pending confirmed rejected"`
+	TableID string `validate:"omitempty,uuid"`
+}
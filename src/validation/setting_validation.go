@@ -0,0 +1,5 @@
+package validation
+
+type UpsertSetting struct {
+	Value string `json:"value" validate:"required,max=1000" example:"720"`
+}
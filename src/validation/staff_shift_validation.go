@@ -0,0 +1,12 @@
+package validation
+
+type ClockIn struct {
+	OutletStaffID string `json:"outlet_staff_id" validate:"required,uuid"`
+}
+
+type QueryStaffShift struct {
+	Page          int    `validate:"omitempty,number,max=50"`
+	Limit         int    `validate:"omitempty,number,max=50"`
+	OutletStaffID string `validate:"omitempty,uuid"`
+	Date          string `validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"app/src/money"
+	"time"
+)
+
+type QuerySync struct {
+	Cursor int64 `validate:"min=0"`
+}
+
+// SyncSales is a batch of sales made offline. Every sale is checked on its own so one bad
+// record does not hold back the rest of the batch.
+type SyncSales struct {
+	Sales []SyncSale `json:"sales" validate:"required,min=1,max=100"`
+}
+
+type SyncSale struct {
+	ID string `json:"id" validate:"required,uuid"`
+	SaleOrder
+	OutletStaffID string            `json:"outlet_staff_id" validate:"required,uuid"`
+	CustomerID    string            `json:"customer_id" validate:"omitempty,uuid"`
+	Note          string            `json:"note" validate:"omitempty,max=500"`
+	SaleDate      time.Time         `json:"sale_date" validate:"required"`
+	Items         []SyncSaleItem    `json:"items" validate:"required,min=1,dive"`
+	Payments      []SyncSalePayment `json:"payments" validate:"omitempty,dive"`
+	ApprovalIDs   []string          `json:"approval_ids" validate:"omitempty,dive,uuid"`
+}
+
+// SyncSaleItem carries the price the terminal charged, which is kept even when the catalog
+// price changed in the meantime.
+type SyncSaleItem struct {
+	ProductID  string       `json:"product_id" validate:"required,uuid"`
+	Quantity   int          `json:"quantity" validate:"required,min=1" example:"1"`
+	Price      money.Amount `json:"price" validate:"min=0" swaggertype:"number" example:"25000"`
+	Discount   money.Amount `json:"discount" validate:"omitempty,min=0" swaggertype:"number" example:"0"`
+	SeatNumber *int         `json:"seat_number" validate:"omitempty,min=1"`
+}
+
+type SyncSalePayment struct {
+	PaymentMethodID string       `json:"payment_method_id" validate:"required,uuid"`
+	Amount          money.Amount `json:"amount" validate:"required,gt=0" swaggertype:"number" example:"50000"`
+	Tip             money.Amount `json:"tip" validate:"omitempty,min=0" swaggertype:"number"`
+	Reference       string       `json:"reference" validate:"omitempty,max=255"`
+	PaidAt          time.Time    `json:"paid_at" validate:"required"`
+}
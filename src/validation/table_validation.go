@@ -0,0 +1,75 @@
+package validation
+
+type CreateFloorArea struct {
+	Name      string `json:"name" validate:"required,max=100" example:"Terrace"`
+	SortOrder int    `json:"sort_order" validate:"min=0" example:"1"`
+	Width     int    `json:"width" validate:"omitempty,min=100,max=10000" example:"1000"`
+	Height    int    `json:"height" validate:"omitempty,min=100,max=10000" example:"800"`
+}
+
+type UpdateFloorArea struct {
+	Name      string `json:"name" validate:"omitempty,max=100" example:"Terrace"`
+	SortOrder *int   `json:"sort_order" validate:"omitempty,min=0" example:"1"`
+	Width     int    `json:"width" validate:"omitempty,min=100,max=10000" example:"1000"`
+	Height    int    `json:"height" validate:"omitempty,min=100,max=10000" example:"800"`
+}
+
+// CreateTable places a table on the floor plan, positions are in the units of the floor area's canvas.
+type CreateTable struct {
+	FloorAreaID string `json:"floor_area_id" validate:"omitempty,uuid"`
+	Name        string `json:"name" validate:"required,max=255" example:"A1"`
+	Location    string `json:"location" validate:"omitempty,max=255" example:"Near the window"`
+	Capacity    int    `json:"capacity" validate:"required,min=1,max=100" example:"4"`
+	Shape       string `json:"shape" validate:"omitempty,oneof=square round rectangle" example:"round"`
+	PosX        int    `json:"pos_x" validate:"min=0" example:"120"`
+	PosY        int    `json:"pos_y" validate:"min=0" example:"80"`
+	Width       int    `json:"width" validate:"omitempty,min=10,max=1000" example:"100"`
+	Height      int    `json:"height" validate:"omitempty,min=10,max=1000" example:"100"`
+	Rotation    int    `json:"rotation" validate:"min=0,max=359" example:"0"`
+}
+
+// UpdateTable changes the given fields only, an empty floor_area_id takes the table off the floor plan.
+type UpdateTable struct {
+	FloorAreaID *string `json:"floor_area_id" validate:"omitempty,len=0|uuid"`
+	Name        string  `json:"name" validate:"omitempty,max=255" example:"A1"`
+	Location    *string `json:"location" validate:"omitempty,max=255" example:"Near the window"`
+	Capacity    int     `json:"capacity" validate:"omitempty,min=1,max=100" example:"4"`
+	Shape       string  `json:"shape" validate:"omitempty,oneof=square round rectangle" example:"round"`
+	PosX        *int    `json:"pos_x" validate:"omitempty,min=0" example:"120"`
+	PosY        *int    `json:"pos_y" validate:"omitempty,min=0" example:"80"`
+	Width       int     `json:"width" validate:"omitempty,min=10,max=1000" example:"100"`
+	Height      int     `json:"height" validate:"omitempty,min=10,max=1000" example:"100"`
+	Rotation    *int    `json:"rotation" validate:"omitempty,min=0,max=359" example:"0"`
+}
+
+// UpdateTableStatus is set by staff, a table becomes occupied by opening a sale at it.
+type UpdateTableStatus struct {
+	Status string `json:"status" validate:"required,oneof=available needs_cleaning reserved" example:"available"`
+}
+
+type QueryTable struct {
+	FloorAreaID string `validate:"omitempty,uuid"`
+	Status      string `validate:"omitempty,oneof=available occupied needs_cleaning reserved"`
+}
+
+type MoveSale struct {
+	TableID string `json:"table_id" validate:"required,uuid"`
+	Reason  string `json:"reason" validate:"omitempty,max=255" example:"Guests asked for a window seat"`
+}
+
+// MergeTables joins the given tables to the table in the path, their open sales are merged into its sale.
+type MergeTables struct {
+	TableIDs []string `json:"table_ids" validate:"required,min=1,max=20,unique,dive,uuid"`
+	Reason   string   `json:"reason" validate:"omitempty,max=255" example:"Group of ten"`
+}
+
+type UnmergeTables struct {
+	Reason string `json:"reason" validate:"omitempty,max=255" example:"Group left"`
+}
+
+type QueryTableMove struct {
+	Page    int    `validate:"omitempty,number,max=50"`
+	Limit   int    `validate:"omitempty,number,max=50"`
+	TableID string `validate:"omitempty,uuid"`
+	SaleID  string `validate:"omitempty,uuid"`
+}
@@ -0,0 +1,22 @@
+package validation
+
+type CreateTax struct {
+	OutletID          string   `json:"outlet_id" validate:"omitempty,uuid"`
+	Name              string   `json:"name" validate:"required,max=100" example:"PB1"`
+	Type              string   `json:"type" validate:"required,oneof=tax service_charge" example:"tax"`
+	Rate              float64  `json:"rate" validate:"required,gt=0,max=100" example:"10"`
+	Inclusive         bool     `json:"inclusive" example:"false"`
+	Taxable           bool     `json:"taxable" example:"false"`
+	ExemptCategoryIDs []string `json:"exempt_category_ids" validate:"omitempty,dive,uuid"`
+	OrderTypes        []string `json:"order_types" validate:"omitempty,dive,oneof=dine_in takeaway delivery"`
+}
+
+type UpdateTax struct {
+	Name              string    `json:"name" validate:"omitempty,max=100" example:"PB1"`
+	Rate              float64   `json:"rate" validate:"omitempty,gt=0,max=100" example:"10"`
+	Inclusive         *bool     `json:"inclusive" example:"false"`
+	Taxable           *bool     `json:"taxable" example:"false"`
+	IsActive          *bool     `json:"is_active" example:"true"`
+	ExemptCategoryIDs *[]string `json:"exempt_category_ids" validate:"omitempty,dive,uuid"`
+	OrderTypes        *[]string `json:"order_types" validate:"omitempty,dive,oneof=dine_in takeaway delivery"`
+}
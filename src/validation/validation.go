@@ -20,6 +20,7 @@ var customMessages = map[string]string{
 	"password": "Field %s must contain at least 1 letter and 1 number",
 	"unique":   "Field %s must be unique",
 	"url":      "Field %s must be a valid URL",
+	"uuid":     "Field %s must be a valid UUID",
 }
 
 func CustomErrorMessages(err error) map[string]string {
@@ -0,0 +1,74 @@
+package fixture
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"time"
+)
+
+var Business = &model.Business{
+	Name:    "Test Business",
+	Domain:  "test-business",
+	Address: "Jl. Test 1",
+}
+
+var Outlet = &model.Outlet{
+	Name:    "Test Outlet",
+	Address: "Jl. Test 1",
+}
+
+var Cashier = &model.OutletStaff{
+	Name:     "Cashier",
+	Password: "password1",
+	Role:     "cashier",
+}
+
+var Manager = &model.OutletStaff{
+	Name:     "Manager",
+	Password: "password1",
+	Role:     config.StaffRoleManager,
+}
+
+var TableOne = &model.Table{
+	Name:     "A1",
+	Capacity: 4,
+	Width:    100,
+	Height:   100,
+}
+
+var TableTwo = &model.Table{
+	Name:     "A2",
+	Capacity: 2,
+	Width:    100,
+	Height:   100,
+}
+
+var Drinks = &model.ProductCategory{
+	Name: "Drinks",
+}
+
+var Coffee = &model.Product{
+	Name:  "Coffee",
+	Price: money.Amount(2050),
+}
+
+var Cash = &model.PaymentMethod{
+	Name: "Cash",
+	Type: config.PaymentTypeCash,
+}
+
+var Card = &model.PaymentMethod{
+	Name: "Card",
+	Type: "card",
+}
+
+var Welcome = &model.Coupon{
+	Code:          "WELCOME5",
+	DiscountType:  config.CouponDiscountFixed,
+	DiscountValue: money.FromUnits(5),
+	MaxUses:       1,
+	StartDate:     time.Now().Add(-time.Hour * 24),
+	EndDate:       time.Now().Add(time.Hour * 24),
+	IsActive:      true,
+}
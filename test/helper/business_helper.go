@@ -0,0 +1,182 @@
+package helper
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/utils"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func ClearBusinesses(db *gorm.DB) {
+	// Refunds and payments keep their payment method, they are removed before the outlets
+	for _, value := range []interface{}{&model.Refund{}, &model.SalePayment{}, &model.Business{}, &model.SyncTombstone{}} {
+		err := db.Where("id is not null").Delete(value).Error
+		if err != nil {
+			logrus.Fatalf("Failed clear business data : %+v", err)
+		}
+	}
+}
+
+func InsertBusiness(db *gorm.DB, business *model.Business, owners ...*model.User) {
+	if err := db.Create(business).Error; err != nil {
+		logrus.Errorf("Failed to create business: %+v", err)
+		return
+	}
+
+	for _, owner := range owners {
+		businessUser := &model.BusinessUser{
+			BusinessID: business.ID,
+			UserID:     owner.ID,
+			Role:       config.BusinessRoleOwner,
+		}
+
+		if err := db.Create(businessUser).Error; err != nil {
+			logrus.Errorf("Failed to create business user: %+v", err)
+		}
+	}
+}
+
+func InsertBusinessUser(db *gorm.DB, business *model.Business, user *model.User, role string) {
+	businessUser := &model.BusinessUser{
+		BusinessID: business.ID,
+		UserID:     user.ID,
+		Role:       role,
+	}
+
+	if err := db.Create(businessUser).Error; err != nil {
+		logrus.Errorf("Failed to create business user: %+v", err)
+	}
+}
+
+func InsertOutlet(db *gorm.DB, business *model.Business, outlet *model.Outlet) {
+	outlet.BusinessID = business.ID
+
+	if err := db.Create(outlet).Error; err != nil {
+		logrus.Errorf("Failed to create outlet: %+v", err)
+	}
+}
+
+func InsertOutletStaff(db *gorm.DB, outlet *model.Outlet, staff ...*model.OutletStaff) {
+	for _, member := range staff {
+		hashedPassword, err := utils.HashPassword(member.Password)
+		if err != nil {
+			logrus.Errorf("Failed to hash password: %+v", err)
+			continue
+		}
+		member.Password = hashedPassword
+		member.OutletID = outlet.ID
+
+		if errDB := db.Create(member).Error; errDB != nil {
+			logrus.Errorf("Failed to create outlet staff: %+v", errDB)
+		}
+	}
+}
+
+func SetStaffPin(db *gorm.DB, staff *model.OutletStaff, pin string) {
+	hashedPin, err := utils.HashPassword(pin)
+	if err != nil {
+		logrus.Errorf("Failed to hash pin: %+v", err)
+		return
+	}
+
+	if err := db.Model(staff).Update("pin", hashedPin).Error; err != nil {
+		logrus.Errorf("Failed to set staff pin: %+v", err)
+	}
+}
+
+func InsertTables(db *gorm.DB, outlet *model.Outlet, tables ...*model.Table) {
+	for _, table := range tables {
+		table.OutletID = outlet.ID
+
+		if err := db.Create(table).Error; err != nil {
+			logrus.Errorf("Failed to create table: %+v", err)
+		}
+	}
+}
+
+func InsertPaymentMethods(db *gorm.DB, outlet *model.Outlet, paymentMethods ...*model.PaymentMethod) {
+	for _, paymentMethod := range paymentMethods {
+		paymentMethod.OutletID = outlet.ID
+
+		if err := db.Create(paymentMethod).Error; err != nil {
+			logrus.Errorf("Failed to create payment method: %+v", err)
+		}
+	}
+}
+
+func InsertProducts(
+	db *gorm.DB, business *model.Business, category *model.ProductCategory, products ...*model.Product,
+) {
+	category.BusinessID = business.ID
+
+	if err := db.Create(category).Error; err != nil {
+		logrus.Errorf("Failed to create product category: %+v", err)
+		return
+	}
+
+	for _, product := range products {
+		product.BusinessID = business.ID
+		product.CategoryID = category.ID
+
+		if err := db.Create(product).Error; err != nil {
+			logrus.Errorf("Failed to create product: %+v", err)
+		}
+	}
+}
+
+func InsertCoupons(db *gorm.DB, business *model.Business, coupons ...*model.Coupon) {
+	for _, coupon := range coupons {
+		coupon.BusinessID = business.ID
+
+		if err := db.Create(coupon).Error; err != nil {
+			logrus.Errorf("Failed to create coupon: %+v", err)
+		}
+	}
+}
+
+func InsertSetting(db *gorm.DB, outlet *model.Outlet, key, value string) {
+	setting := &model.Setting{
+		OutletID: outlet.ID,
+		Key:      key,
+		Value:    value,
+	}
+
+	if err := db.Create(setting).Error; err != nil {
+		logrus.Errorf("Failed to create setting: %+v", err)
+	}
+}
+
+func GetSaleByID(db *gorm.DB, id string) (*model.Sale, error) {
+	sale := new(model.Sale)
+
+	result := db.Preload("SalePayments").First(sale, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+
+	if result.Error != nil {
+		logrus.Errorf("Failed get sale by id: %+v", result.Error)
+	}
+
+	return sale, result.Error
+}
+
+func GetCouponByID(db *gorm.DB, id string) (*model.Coupon, error) {
+	coupon := new(model.Coupon)
+
+	result := db.First(coupon, "id = ?", id)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+
+	if result.Error != nil {
+		logrus.Errorf("Failed get coupon by id: %+v", result.Error)
+	}
+
+	return coupon, result.Error
+}
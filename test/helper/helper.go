@@ -14,6 +14,7 @@ import (
 )
 
 func ClearAll(db *gorm.DB) {
+	ClearBusinesses(db)
 	ClearToken(db)
 	ClearUsers(db)
 }
@@ -0,0 +1,187 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalRoutes(t *testing.T) {
+	insertManager := func() {
+		insertOutlet()
+		helper.InsertOutletStaff(test.DB, fixture.Outlet, fixture.Manager)
+		helper.SetStaffPin(test.DB, fixture.Manager, "1234")
+	}
+
+	requestApproval := func(
+		t *testing.T, accessToken, approverID, pin string,
+	) (*http.Response, *response.SuccessWithApproval) {
+		apiResponse, bytes := sendRequest(t, http.MethodPost,
+			"/v1/outlets/"+fixture.Outlet.ID.String()+"/approvals", accessToken, validation.CreateApproval{
+				Action:      config.ApprovalActionDiscount,
+				Reason:      "Regular customer",
+				RequestedBy: fixture.Cashier.ID.String(),
+				ApproverID:  approverID,
+				Pin:         pin,
+			})
+
+		responseBody := new(response.SuccessWithApproval)
+
+		err := json.Unmarshal(bytes, responseBody)
+		assert.Nil(t, err)
+
+		return apiResponse, responseBody
+	}
+
+	t.Run("POST /v1/outlets/:outletId/approvals", func(t *testing.T) {
+		t.Run("should return 201 and approve right away with the manager's PIN", func(t *testing.T) {
+			insertManager()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, responseBody := requestApproval(t, accessToken, fixture.Manager.ID.String(), "1234")
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, config.ApprovalStatusApproved, responseBody.Approval.Status)
+			assert.Equal(t, config.ApprovalMethodPin, responseBody.Approval.Method)
+			assert.Equal(t, fixture.Manager.ID, *responseBody.Approval.ApprovedByStaffID)
+		})
+
+		t.Run("should return 429 error after too many wrong PINs, even for the right PIN", func(t *testing.T) {
+			insertManager()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			for range config.StaffPinMaxAttempts {
+				apiResponse, _ := requestApproval(t, accessToken, fixture.Manager.ID.String(), "9999")
+				assert.Equal(t, http.StatusUnauthorized, apiResponse.StatusCode)
+			}
+
+			apiResponse, _ := requestApproval(t, accessToken, fixture.Manager.ID.String(), "1234")
+
+			assert.Equal(t, http.StatusTooManyRequests, apiResponse.StatusCode)
+		})
+
+		t.Run("should start counting over after the right PIN", func(t *testing.T) {
+			insertManager()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			for range config.StaffPinMaxAttempts - 1 {
+				apiResponse, _ := requestApproval(t, accessToken, fixture.Manager.ID.String(), "9999")
+				assert.Equal(t, http.StatusUnauthorized, apiResponse.StatusCode)
+			}
+
+			apiResponse, _ := requestApproval(t, accessToken, fixture.Manager.ID.String(), "1234")
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, _ = requestApproval(t, accessToken, fixture.Manager.ID.String(), "9999")
+			assert.Equal(t, http.StatusUnauthorized, apiResponse.StatusCode)
+
+			apiResponse, _ = requestApproval(t, accessToken, fixture.Manager.ID.String(), "1234")
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 403 error if the approver is not a manager", func(t *testing.T) {
+			insertManager()
+			helper.SetStaffPin(test.DB, fixture.Cashier, "4321")
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, _ := requestApproval(t, accessToken, fixture.Cashier.ID.String(), "4321")
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("GET /v1/approvals/:approvalId", func(t *testing.T) {
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertManager()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			_, created := requestApproval(t, accessToken, "", "")
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodGet,
+				"/v1/approvals/"+created.Approval.ID.String(), otherToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("POST /v1/approvals/:approvalId/approve", func(t *testing.T) {
+		t.Run("should return 200 if another owner approves", func(t *testing.T) {
+			insertManager()
+			helper.InsertBusinessUser(test.DB, fixture.Business, fixture.UserTwo, config.BusinessRoleOwner)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			_, created := requestApproval(t, accessToken, "", "")
+			assert.Equal(t, config.ApprovalStatusPending, created.Approval.Status)
+
+			ownerToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost,
+				"/v1/approvals/"+created.Approval.ID.String()+"/approve", ownerToken, nil)
+
+			responseBody := new(response.SuccessWithApproval)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.ApprovalStatusApproved, responseBody.Approval.Status)
+			assert.Equal(t, fixture.UserTwo.ID, *responseBody.Approval.ApprovedByUserID)
+		})
+
+		t.Run("should return 403 error if the user asked for the approval", func(t *testing.T) {
+			insertManager()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			_, created := requestApproval(t, accessToken, "", "")
+
+			apiResponse, _ := sendRequest(t, http.MethodPost,
+				"/v1/approvals/"+created.Approval.ID.String()+"/approve", accessToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 403 error if the user is staff of the business", func(t *testing.T) {
+			insertManager()
+			helper.InsertBusinessUser(test.DB, fixture.Business, fixture.UserTwo, config.BusinessRoleStaff)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			_, created := requestApproval(t, accessToken, "", "")
+
+			staffToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost,
+				"/v1/approvals/"+created.Approval.ID.String()+"/approve", staffToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
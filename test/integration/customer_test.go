@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"app/src/response"
+	"app/src/validation"
+	"app/test/fixture"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerRoutes(t *testing.T) {
+	createCustomer := func(t *testing.T, accessToken, name string) *response.SuccessWithCustomer {
+		apiResponse, bytes := sendRequest(t, http.MethodPost,
+			"/v1/businesses/"+fixture.Business.ID.String()+"/customers", accessToken,
+			validation.CreateCustomer{Name: name})
+
+		responseBody := new(response.SuccessWithCustomer)
+
+		err := json.Unmarshal(bytes, responseBody)
+		assert.Nil(t, err)
+
+		assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+		return responseBody
+	}
+
+	t.Run("/v1/customers/:customerId", func(t *testing.T) {
+		t.Run("should return 403 error if the user has no access to the business", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			customer := createCustomer(t, accessToken, "Budi").Customer
+			duplicate := createCustomer(t, accessToken, "Budi").Customer
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			url := "/v1/customers/" + customer.ID.String()
+
+			apiResponse, _ := sendRequest(t, http.MethodGet, url, otherToken, nil)
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodGet, url+"/visits", otherToken, nil)
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPatch, url, otherToken, validation.UpdateCustomer{Name: "Andi"})
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, url+"/merge", otherToken,
+				validation.MergeCustomers{CustomerIDs: []string{duplicate.ID.String()}})
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodDelete, url, otherToken, nil)
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodGet, url, accessToken, nil)
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+		})
+	})
+}
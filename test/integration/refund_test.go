@@ -0,0 +1,217 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefundRoutes(t *testing.T) {
+	t.Run("POST /v1/sales/:saleId/refunds", func(t *testing.T) {
+		refundItem := func(sale *model.Sale, quantity int) validation.CreateRefund {
+			return validation.CreateRefund{
+				PaymentMethodID: fixture.Card.ID.String(),
+				ReasonCode:      config.RefundReasonCustomerRequest,
+				Items: []validation.CreateRefundItem{
+					{SaleItemID: sale.SaleItems[0].ID.String(), Quantity: quantity},
+				},
+			}
+		}
+
+		t.Run("should return 201 and refund the items without the delivery fee", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, money.FromUnits(5))
+			assert.Equal(t, money.Amount(2550), sale.GrandTotal)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+				accessToken, refundItem(sale, 1))
+
+			responseBody := new(response.SuccessWithRefund)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, money.Amount(2050), responseBody.Refund.Total)
+			assert.Len(t, responseBody.Refund.RefundItems, 1)
+
+			refundedSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusRefunded, refundedSale.Status)
+		})
+
+		t.Run("should not refund more than was paid when cash was rounded down", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertSetting(test.DB, fixture.Outlet, config.SettingCashRoundingIncrement, "5")
+			helper.InsertSetting(test.DB, fixture.Outlet, config.SettingCashRoundingMode, config.CashRoundingDown)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 2, 0)
+
+			apiResponse, paid := payForSale(t, accessToken, sale, fixture.Cash, money.FromUnits(40))
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, money.FromUnits(40), paid.Summary.Paid)
+
+			var refunded money.Amount
+			for range 2 {
+				apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+					accessToken, refundItem(sale, 1))
+
+				responseBody := new(response.SuccessWithRefund)
+
+				err = json.Unmarshal(bytes, responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+				refunded += responseBody.Refund.Total
+			}
+
+			assert.Equal(t, money.FromUnits(40), refunded)
+
+			refundedSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusRefunded, refundedSale.Status)
+		})
+
+		t.Run("should refund every share of a split without restocking the goods", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/split",
+				accessToken, validation.SplitSale{Mode: "equal", Parts: 3})
+
+			split := new(response.SuccessWithSales)
+
+			err = json.Unmarshal(bytes, split)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Len(t, split.Sales, 3)
+
+			var refunded money.Amount
+			for i := range split.Sales {
+				share := &split.Sales[i]
+
+				apiResponse, _ = payForSale(t, accessToken, share, fixture.Card, share.GrandTotal)
+				assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+				restock := refundItem(share, 1)
+				restock.Restock = true
+
+				apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/sales/"+share.ID.String()+"/refunds",
+					accessToken, restock)
+				assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+
+				apiResponse, bytes = sendRequest(t, http.MethodPost, "/v1/sales/"+share.ID.String()+"/refunds",
+					accessToken, refundItem(share, 1))
+
+				responseBody := new(response.SuccessWithRefund)
+
+				err = json.Unmarshal(bytes, responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+				refunded += responseBody.Refund.Total
+
+				refundedSale, err := helper.GetSaleByID(test.DB, share.ID.String())
+				assert.Nil(t, err)
+
+				assert.Equal(t, config.SaleStatusRefunded, refundedSale.Status)
+			}
+
+			assert.Equal(t, fixture.Coffee.Price, refunded)
+
+			var movements int64
+			err = test.DB.Model(&model.StockMovement{}).Count(&movements).Error
+			assert.Nil(t, err)
+
+			assert.Equal(t, int64(0), movements)
+		})
+
+		t.Run("should return 400 error if the sale is not paid", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+				accessToken, refundItem(sale, 1))
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 400 error if more is refunded than was sold", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+				accessToken, refundItem(sale, 2))
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusPaid, paidSale.Status)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+				otherToken, refundItem(sale, 1))
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodGet, "/v1/sales/"+sale.ID.String()+"/refunds",
+				otherToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,223 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/money"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaleCouponRoutes(t *testing.T) {
+	t.Run("POST /v1/sales/:saleId/coupons", func(t *testing.T) {
+		t.Run("should return 200 and take a use of the coupon", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			// Codes are matched case insensitively
+			apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: "welcome5"})
+
+			responseBody := new(response.SuccessWithSale)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, money.FromUnits(5), responseBody.Sale.Discount)
+			assert.Equal(t, money.Amount(1550), responseBody.Sale.GrandTotal)
+
+			coupon, err := helper.GetCouponByID(test.DB, fixture.Welcome.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, 1, coupon.UsedCount)
+		})
+
+		t.Run("should return 409 error if the coupon is used up", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			first := createSale(t, accessToken, 1, 0)
+			second := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+first.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: fixture.Welcome.Code})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/sales/"+second.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: fixture.Welcome.Code})
+
+			assert.Equal(t, http.StatusConflict, apiResponse.StatusCode)
+
+			coupon, err := helper.GetCouponByID(test.DB, fixture.Welcome.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, 1, coupon.UsedCount)
+		})
+
+		t.Run("should return 404 error if the code does not exist", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: "UNKNOWN"})
+
+			assert.Equal(t, http.StatusNotFound, apiResponse.StatusCode)
+		})
+
+		t.Run("should give the use back when the sale is refunded in full", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: fixture.Welcome.Code})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			discounted := new(response.SuccessWithSale)
+
+			err = json.Unmarshal(bytes, discounted)
+			assert.Nil(t, err)
+
+			apiResponse, _ = payForSale(t, accessToken, sale, fixture.Card, discounted.Sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, bytes = sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds",
+				accessToken, validation.CreateRefund{
+					PaymentMethodID: fixture.Card.ID.String(),
+					ReasonCode:      config.RefundReasonCustomerRequest,
+					Items: []validation.CreateRefundItem{
+						{SaleItemID: sale.SaleItems[0].ID.String(), Quantity: 1},
+					},
+				})
+
+			refund := new(response.SuccessWithRefund)
+
+			err = json.Unmarshal(bytes, refund)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, discounted.Sale.GrandTotal, refund.Refund.Total)
+
+			coupon, err := helper.GetCouponByID(test.DB, fixture.Welcome.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, 0, coupon.UsedCount)
+		})
+	})
+
+	t.Run("DELETE /v1/sales/:saleId/coupons/:couponId", func(t *testing.T) {
+		t.Run("should return 200 and give the use back", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/coupons",
+				accessToken, validation.ApplyCoupon{Code: fixture.Welcome.Code})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			apiResponse, bytes := sendRequest(t, http.MethodDelete,
+				"/v1/sales/"+sale.ID.String()+"/coupons/"+fixture.Welcome.ID.String(), accessToken, nil)
+
+			responseBody := new(response.SuccessWithSale)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, money.Amount(0), responseBody.Sale.Discount)
+			assert.Equal(t, fixture.Coffee.Price, responseBody.Sale.GrandTotal)
+
+			coupon, err := helper.GetCouponByID(test.DB, fixture.Welcome.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, 0, coupon.UsedCount)
+		})
+	})
+
+	t.Run("POST /v1/coupons/validate", func(t *testing.T) {
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/coupons/validate", accessToken,
+				validation.ValidateCoupon{
+					Code:     fixture.Welcome.Code,
+					OutletID: fixture.Outlet.ID.String(),
+					Total:    money.FromUnits(50),
+				})
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 400 error if the customer belongs to another business", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/coupons/validate", accessToken,
+				validation.ValidateCoupon{
+					Code:       fixture.Welcome.Code,
+					OutletID:   fixture.Outlet.ID.String(),
+					CustomerID: uuid.New().String(),
+					Total:      money.FromUnits(50),
+				})
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("/v1/coupons/:couponId", func(t *testing.T) {
+		t.Run("should return 403 error if the user has no access to the business", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertCoupons(test.DB, fixture.Business, fixture.Welcome)
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			url := "/v1/coupons/" + fixture.Welcome.ID.String()
+
+			apiResponse, _ := sendRequest(t, http.MethodGet, url, accessToken, nil)
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodGet, url+"/usage", accessToken, nil)
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPatch, url, accessToken, validation.UpdateCoupon{})
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
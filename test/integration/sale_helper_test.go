@@ -0,0 +1,123 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// insertOutlet gives UserOne a business with an outlet, its cashier, cash and card, and coffee on
+// the menu. UserTwo is inserted as well, without access to the business.
+func insertOutlet() {
+	helper.ClearAll(test.DB)
+	helper.InsertUser(test.DB, fixture.UserOne, fixture.UserTwo)
+	helper.InsertBusiness(test.DB, fixture.Business, fixture.UserOne)
+	helper.InsertOutlet(test.DB, fixture.Business, fixture.Outlet)
+	helper.InsertOutletStaff(test.DB, fixture.Outlet, fixture.Cashier)
+	helper.InsertPaymentMethods(test.DB, fixture.Outlet, fixture.Cash, fixture.Card)
+	helper.InsertProducts(test.DB, fixture.Business, fixture.Drinks, fixture.Coffee)
+}
+
+// sendRequest sends body as JSON with the access token and returns the response and its body.
+func sendRequest(t *testing.T, method, url, accessToken string, body interface{}) (*http.Response, []byte) {
+	bodyJSON, err := json.Marshal(body)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(method, url, strings.NewReader(string(bodyJSON)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	apiResponse, err := test.App.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(apiResponse.Body)
+	assert.Nil(t, err)
+
+	return apiResponse, bytes
+}
+
+// createSale opens a sale of quantity coffees, for delivery when there is a delivery fee and to
+// take away otherwise.
+func createSale(t *testing.T, accessToken string, quantity int, deliveryFee money.Amount) *model.Sale {
+	order := validation.SaleOrder{OrderType: config.OrderTypeTakeaway}
+	if deliveryFee > 0 {
+		order = validation.SaleOrder{
+			OrderType:       config.OrderTypeDelivery,
+			DeliveryAddress: "Jl. Test 2",
+			DeliveryContact: "Test",
+			DeliveryPhone:   "08123456789",
+			DeliveryFee:     deliveryFee,
+		}
+	}
+
+	apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales", accessToken, validation.CreateSale{
+		SaleOrder:     order,
+		OutletID:      fixture.Outlet.ID.String(),
+		OutletStaffID: fixture.Cashier.ID.String(),
+		Items: []validation.CreateSaleItem{
+			{ProductID: fixture.Coffee.ID.String(), Quantity: quantity},
+		},
+	})
+
+	responseBody := new(response.SuccessWithSale)
+
+	err := json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+	return &responseBody.Sale
+}
+
+// createTableSale opens a dine-in sale of one coffee at the table.
+func createTableSale(t *testing.T, accessToken string, table *model.Table) *model.Sale {
+	apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales", accessToken, validation.CreateSale{
+		SaleOrder:     validation.SaleOrder{OrderType: config.OrderTypeDineIn, TableID: table.ID.String()},
+		OutletID:      fixture.Outlet.ID.String(),
+		OutletStaffID: fixture.Cashier.ID.String(),
+		Items: []validation.CreateSaleItem{
+			{ProductID: fixture.Coffee.ID.String(), Quantity: 1},
+		},
+	})
+
+	responseBody := new(response.SuccessWithSale)
+
+	err := json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+	return &responseBody.Sale
+}
+
+// payForSale pays amount of the sale with the payment method.
+func payForSale(
+	t *testing.T, accessToken string, sale *model.Sale, paymentMethod *model.PaymentMethod, amount money.Amount,
+) (*http.Response, *response.SuccessWithSalePayment) {
+	apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/payments", accessToken,
+		validation.CreateSalePayment{
+			PaymentMethodID: paymentMethod.ID.String(),
+			Amount:          amount,
+		})
+
+	responseBody := new(response.SuccessWithSalePayment)
+
+	err := json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return apiResponse, responseBody
+}
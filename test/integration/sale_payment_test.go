@@ -0,0 +1,207 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/money"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSalePaymentRoutes(t *testing.T) {
+	t.Run("POST /v1/sales/:saleId/payments", func(t *testing.T) {
+		t.Run("should return 201 and mark the sale paid once payments cover it", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 2, 0)
+			assert.Equal(t, money.Amount(4100), sale.GrandTotal)
+
+			apiResponse, responseBody := payForSale(t, accessToken, sale, fixture.Card, money.FromUnits(20))
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, config.PaymentStatusPaid, responseBody.Payment.Status)
+			assert.Equal(t, money.Amount(2100), responseBody.Summary.Remaining)
+			assert.Equal(t, config.SaleStatusUnpaid, responseBody.Summary.SaleStatus)
+
+			apiResponse, responseBody = payForSale(t, accessToken, sale, fixture.Card, money.Amount(2100))
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, money.Amount(0), responseBody.Summary.Remaining)
+			assert.Equal(t, config.SaleStatusPaid, responseBody.Summary.SaleStatus)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusPaid, paidSale.Status)
+			assert.Len(t, paidSale.SalePayments, 2)
+		})
+
+		t.Run("should round cash to the outlet's increment and give change", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertSetting(test.DB, fixture.Outlet, config.SettingCashRoundingIncrement, "1")
+			helper.InsertSetting(test.DB, fixture.Outlet, config.SettingCashRoundingMode, config.CashRoundingDown)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, responseBody := payForSale(t, accessToken, sale, fixture.Cash, money.FromUnits(50))
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, money.FromUnits(20), responseBody.Payment.Amount)
+			assert.Equal(t, money.FromUnits(30), responseBody.Payment.Change)
+			assert.Equal(t, money.Amount(-50), responseBody.Summary.CashRounding)
+			assert.Equal(t, config.SaleStatusPaid, responseBody.Summary.SaleStatus)
+		})
+
+		t.Run("should return 400 error if a card pays more than the balance", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, money.FromUnits(50))
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 400 error if the sale is already paid", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, _ = payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := payForSale(t, otherToken, sale, fixture.Card, sale.GrandTotal)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			openSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusUnpaid, openSale.Status)
+		})
+	})
+
+	t.Run("PATCH /v1/sales/:saleId/payments/:paymentId", func(t *testing.T) {
+		t.Run("should return 200 and keep the sale open when a pending payment fails", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/payments",
+				accessToken, validation.CreateSalePayment{
+					PaymentMethodID: fixture.Card.ID.String(),
+					Amount:          sale.GrandTotal,
+					Pending:         true,
+				})
+
+			created := new(response.SuccessWithSalePayment)
+
+			err = json.Unmarshal(bytes, created)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+			assert.Equal(t, config.PaymentStatusPending, created.Payment.Status)
+
+			apiResponse, bytes = sendRequest(t, http.MethodPatch,
+				"/v1/sales/"+sale.ID.String()+"/payments/"+created.Payment.ID.String(), accessToken,
+				validation.UpdateSalePayment{Status: config.PaymentStatusFailed})
+
+			responseBody := new(response.SuccessWithSalePayment)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.PaymentStatusFailed, responseBody.Payment.Status)
+			assert.Equal(t, sale.GrandTotal, responseBody.Summary.Remaining)
+			assert.Equal(t, config.SaleStatusUnpaid, responseBody.Summary.SaleStatus)
+		})
+
+		t.Run("should return 403 error if a paid payment is voided without an approval", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 1, 0)
+
+			_, paid := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+
+			apiResponse, _ := sendRequest(t, http.MethodPatch,
+				"/v1/sales/"+sale.ID.String()+"/payments/"+paid.Payment.ID.String(), accessToken,
+				validation.UpdateSalePayment{Status: config.PaymentStatusVoid})
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusPaid, paidSale.Status)
+		})
+
+		t.Run("should return 400 error if the sale has refunds", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createSale(t, accessToken, 2, 0)
+
+			_, paid := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+sale.ID.String()+"/refunds", accessToken,
+				validation.CreateRefund{
+					PaymentMethodID: fixture.Card.ID.String(),
+					ReasonCode:      config.RefundReasonCustomerRequest,
+					Items: []validation.CreateRefundItem{
+						{SaleItemID: sale.SaleItems[0].ID.String(), Quantity: 1},
+					},
+				})
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPatch,
+				"/v1/sales/"+sale.ID.String()+"/payments/"+paid.Payment.ID.String(), accessToken,
+				validation.UpdateSalePayment{Status: config.PaymentStatusVoid})
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+	})
+}
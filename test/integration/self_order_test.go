@@ -0,0 +1,136 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfOrderRoutes(t *testing.T) {
+	orderAtTable := func(t *testing.T, accessToken string, tableID string) *response.SuccessWithTableOrder {
+		apiResponse, bytes := sendRequest(t, http.MethodGet, "/v1/tables/"+tableID+"/ordering-link",
+			accessToken, nil)
+		assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+		link := new(response.SuccessWithOrderingLink)
+
+		err := json.Unmarshal(bytes, link)
+		assert.Nil(t, err)
+
+		apiResponse, bytes = sendRequest(t, http.MethodPost,
+			"/v1/self-order/"+link.OrderingLink.Token+"/orders", "", validation.CreateTableOrder{
+				Items: []validation.SelfOrderItem{
+					{ProductID: fixture.Coffee.ID.String(), Quantity: 1},
+				},
+			})
+		assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+		order := new(response.SuccessWithTableOrder)
+
+		err = json.Unmarshal(bytes, order)
+		assert.Nil(t, err)
+
+		return order
+	}
+
+	t.Run("POST /v1/table-orders/:orderId/confirm", func(t *testing.T) {
+		t.Run("should return 200 and add the order to the open sale of the table", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createTableSale(t, accessToken, fixture.TableOne)
+			order := orderAtTable(t, accessToken, fixture.TableOne.ID.String())
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost,
+				"/v1/table-orders/"+order.TableOrder.ID.String()+"/confirm", accessToken,
+				validation.ConfirmTableOrder{})
+
+			responseBody := new(response.SuccessWithConfirmedTableOrder)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.TableOrderStatusConfirmed, responseBody.TableOrder.Status)
+			assert.Equal(t, sale.ID, responseBody.Sale.ID)
+			assert.Len(t, responseBody.Sale.SaleItems, 2)
+		})
+
+		t.Run("should return 200 and add the order of a merged table to the host's sale", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne, fixture.TableTwo)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createTableSale(t, accessToken, fixture.TableOne)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/tables/"+fixture.TableOne.ID.String()+"/merge",
+				accessToken, validation.MergeTables{TableIDs: []string{fixture.TableTwo.ID.String()}})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			order := orderAtTable(t, accessToken, fixture.TableTwo.ID.String())
+			assert.Equal(t, sale.ID, *order.TableOrder.SaleID)
+
+			apiResponse, bytes := sendRequest(t, http.MethodPost,
+				"/v1/table-orders/"+order.TableOrder.ID.String()+"/confirm", accessToken,
+				validation.ConfirmTableOrder{})
+
+			responseBody := new(response.SuccessWithConfirmedTableOrder)
+
+			err = json.Unmarshal(bytes, responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, sale.ID, responseBody.Sale.ID)
+			assert.Equal(t, fixture.TableOne.ID, *responseBody.Sale.TableID)
+			assert.Len(t, responseBody.Sale.SaleItems, 2)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			createTableSale(t, accessToken, fixture.TableOne)
+			order := orderAtTable(t, accessToken, fixture.TableOne.ID.String())
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost,
+				"/v1/table-orders/"+order.TableOrder.ID.String()+"/confirm", otherToken,
+				validation.ConfirmTableOrder{})
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("GET /v1/tables/:tableId/ordering-link", func(t *testing.T) {
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodGet,
+				"/v1/tables/"+fixture.TableOne.ID.String()+"/ordering-link", accessToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
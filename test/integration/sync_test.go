@@ -0,0 +1,230 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/money"
+	"app/src/response"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRoutes(t *testing.T) {
+	getChanges := func(t *testing.T, accessToken string, cursor int64) (*http.Response, *response.SuccessWithSyncChanges) {
+		apiResponse, bytes := sendRequest(t, http.MethodGet,
+			"/v1/outlets/"+fixture.Outlet.ID.String()+"/sync?cursor="+strconv.FormatInt(cursor, 10), accessToken, nil)
+
+		responseBody := new(response.SuccessWithSyncChanges)
+
+		err := json.Unmarshal(bytes, responseBody)
+		assert.Nil(t, err)
+
+		return apiResponse, responseBody
+	}
+
+	uploadSales := func(
+		t *testing.T, accessToken string, sales ...validation.SyncSale,
+	) (*http.Response, *response.SuccessWithSyncResults) {
+		apiResponse, bytes := sendRequest(t, http.MethodPost,
+			"/v1/outlets/"+fixture.Outlet.ID.String()+"/sync/sales", accessToken, validation.SyncSales{Sales: sales})
+
+		responseBody := new(response.SuccessWithSyncResults)
+
+		err := json.Unmarshal(bytes, responseBody)
+		assert.Nil(t, err)
+
+		return apiResponse, responseBody
+	}
+
+	offlineSale := func(price money.Amount, saleDate time.Time) validation.SyncSale {
+		return validation.SyncSale{
+			ID:            uuid.New().String(),
+			SaleOrder:     validation.SaleOrder{OrderType: config.OrderTypeTakeaway},
+			OutletStaffID: fixture.Cashier.ID.String(),
+			SaleDate:      saleDate,
+			Items: []validation.SyncSaleItem{
+				{ProductID: fixture.Coffee.ID.String(), Quantity: 1, Price: price},
+			},
+			Payments: []validation.SyncSalePayment{
+				{PaymentMethodID: fixture.Card.ID.String(), Amount: price, PaidAt: saleDate},
+			},
+		}
+	}
+
+	t.Run("GET /v1/outlets/:outletId/sync", func(t *testing.T) {
+		t.Run("should return 200 and everything when there is no cursor", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, responseBody := getChanges(t, accessToken, 0)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.True(t, responseBody.Changes.Full)
+			assert.Positive(t, responseBody.Changes.Cursor)
+			assert.Len(t, responseBody.Changes.Products, 1)
+			assert.Equal(t, fixture.Coffee.ID, responseBody.Changes.Products[0].ID)
+			assert.Len(t, responseBody.Changes.ProductCategories, 1)
+			assert.Len(t, responseBody.Changes.Staff, 1)
+			assert.Len(t, responseBody.Changes.PaymentMethods, 2)
+		})
+
+		t.Run("should only return what changed after the cursor", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			_, full := getChanges(t, accessToken, 0)
+
+			apiResponse, unchanged := getChanges(t, accessToken, full.Changes.Cursor)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.False(t, unchanged.Changes.Full)
+			assert.Empty(t, unchanged.Changes.Products)
+			assert.Empty(t, unchanged.Changes.PaymentMethods)
+			assert.GreaterOrEqual(t, unchanged.Changes.Cursor, full.Changes.Cursor)
+
+			err = test.DB.Model(&model.Product{}).Where("id = ?", fixture.Coffee.ID).
+				Update("price", money.FromUnits(25)).Error
+			assert.Nil(t, err)
+
+			err = test.DB.Delete(fixture.Card).Error
+			assert.Nil(t, err)
+
+			apiResponse, changed := getChanges(t, accessToken, unchanged.Changes.Cursor)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Len(t, changed.Changes.Products, 1)
+			assert.Equal(t, money.FromUnits(25), changed.Changes.Products[0].Price)
+			assert.Len(t, changed.Changes.Deleted, 1)
+			assert.Equal(t, fixture.Card.ID, changed.Changes.Deleted[0].RecordID)
+			assert.Greater(t, changed.Changes.Cursor, unchanged.Changes.Cursor)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := getChanges(t, accessToken, 0)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("POST /v1/outlets/:outletId/sync/sales", func(t *testing.T) {
+		t.Run("should return 200 and create the sale once when it is sent again", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := offlineSale(fixture.Coffee.Price, time.Now())
+
+			apiResponse, responseBody := uploadSales(t, accessToken, sale)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Len(t, responseBody.Results, 1)
+			assert.Equal(t, config.SyncStatusCreated, responseBody.Results[0].Status)
+			assert.NotEmpty(t, responseBody.Results[0].InvoiceNumber)
+
+			apiResponse, resent := uploadSales(t, accessToken, sale)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.SyncStatusDuplicate, resent.Results[0].Status)
+			assert.Equal(t, responseBody.Results[0].InvoiceNumber, resent.Results[0].InvoiceNumber)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID)
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusPaid, paidSale.Status)
+			assert.Len(t, paidSale.SalePayments, 1)
+		})
+
+		t.Run("should keep the price charged offline when the catalog changed since", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := offlineSale(fixture.Coffee.Price, time.Now().Add(-time.Second))
+
+			err = test.DB.Model(&model.Product{}).Where("id = ?", fixture.Coffee.ID).
+				Update("price", money.FromUnits(25)).Error
+			assert.Nil(t, err)
+
+			apiResponse, responseBody := uploadSales(t, accessToken, sale)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.SyncStatusCreated, responseBody.Results[0].Status)
+			assert.Len(t, responseBody.Results[0].Conflicts, 1)
+			assert.Equal(t, config.SyncConflictPriceChanged, responseBody.Results[0].Conflicts[0].Code)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID)
+			assert.Nil(t, err)
+
+			assert.Equal(t, money.Amount(2050), paidSale.GrandTotal)
+		})
+
+		t.Run("should reject a sale sold below the catalog price without an approval", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := offlineSale(money.FromUnits(10), time.Now())
+
+			apiResponse, responseBody := uploadSales(t, accessToken, sale)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.SyncStatusRejected, responseBody.Results[0].Status)
+			assert.Contains(t, responseBody.Results[0].Message, "Manager approval is required")
+
+			_, err = helper.GetSaleByID(test.DB, sale.ID)
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should reject a sale for a customer of another business", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := offlineSale(fixture.Coffee.Price, time.Now())
+			sale.CustomerID = uuid.New().String()
+
+			apiResponse, responseBody := uploadSales(t, accessToken, sale)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+			assert.Equal(t, config.SyncStatusRejected, responseBody.Results[0].Status)
+			assert.Equal(t, "Customer does not belong to this business", responseBody.Results[0].Message)
+
+			_, err = helper.GetSaleByID(test.DB, sale.ID)
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := uploadSales(t, accessToken, offlineSale(fixture.Coffee.Price, time.Now()))
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"app/src/config"
+	"app/src/model"
+	"app/src/validation"
+	"app/test"
+	"app/test/fixture"
+	"app/test/helper"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableRoutes(t *testing.T) {
+	t.Run("DELETE /v1/tables/:tableId", func(t *testing.T) {
+		t.Run("should return 200 and delete a table without sales", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodDelete, "/v1/tables/"+fixture.TableOne.ID.String(),
+				accessToken, nil)
+
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			var tables int64
+			err = test.DB.Model(&model.Table{}).Where("id = ?", fixture.TableOne.ID).Count(&tables).Error
+			assert.Nil(t, err)
+
+			assert.Equal(t, int64(0), tables)
+		})
+
+		t.Run("should return 409 error and keep the table if it has sales history", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			sale := createTableSale(t, accessToken, fixture.TableOne)
+
+			apiResponse, _ := payForSale(t, accessToken, sale, fixture.Card, sale.GrandTotal)
+			assert.Equal(t, http.StatusCreated, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodDelete, "/v1/tables/"+fixture.TableOne.ID.String(),
+				accessToken, nil)
+
+			assert.Equal(t, http.StatusConflict, apiResponse.StatusCode)
+
+			paidSale, err := helper.GetSaleByID(test.DB, sale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, fixture.TableOne.ID, *paidSale.TableID)
+		})
+
+		t.Run("should return 400 error if the table has open sales", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			createTableSale(t, accessToken, fixture.TableOne)
+
+			apiResponse, _ := sendRequest(t, http.MethodDelete, "/v1/tables/"+fixture.TableOne.ID.String(),
+				accessToken, nil)
+
+			assert.Equal(t, http.StatusBadRequest, apiResponse.StatusCode)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne)
+
+			accessToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodDelete, "/v1/tables/"+fixture.TableOne.ID.String(),
+				accessToken, nil)
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+
+	t.Run("POST /v1/tables/:tableId/unmerge", func(t *testing.T) {
+		t.Run("should give a held sale back on hold", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne, fixture.TableTwo)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			hostSale := createTableSale(t, accessToken, fixture.TableOne)
+			heldSale := createTableSale(t, accessToken, fixture.TableTwo)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/sales/"+heldSale.ID.String()+"/hold",
+				accessToken, nil)
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/tables/"+fixture.TableOne.ID.String()+"/merge",
+				accessToken, validation.MergeTables{TableIDs: []string{fixture.TableTwo.ID.String()}})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			mergedSale, err := helper.GetSaleByID(test.DB, heldSale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusMerged, mergedSale.Status)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/tables/"+fixture.TableOne.ID.String()+"/unmerge",
+				accessToken, validation.UnmergeTables{})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			restoredSale, err := helper.GetSaleByID(test.DB, heldSale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusHold, restoredSale.Status)
+
+			openSale, err := helper.GetSaleByID(test.DB, hostSale.ID.String())
+			assert.Nil(t, err)
+
+			assert.Equal(t, config.SaleStatusUnpaid, openSale.Status)
+		})
+
+		t.Run("should return 403 error if the user has no access to the outlet", func(t *testing.T) {
+			insertOutlet()
+			helper.InsertTables(test.DB, fixture.Outlet, fixture.TableOne, fixture.TableTwo)
+
+			accessToken, err := fixture.AccessToken(fixture.UserOne)
+			assert.Nil(t, err)
+
+			apiResponse, _ := sendRequest(t, http.MethodPost, "/v1/tables/"+fixture.TableOne.ID.String()+"/merge",
+				accessToken, validation.MergeTables{TableIDs: []string{fixture.TableTwo.ID.String()}})
+			assert.Equal(t, http.StatusOK, apiResponse.StatusCode)
+
+			otherToken, err := fixture.AccessToken(fixture.UserTwo)
+			assert.Nil(t, err)
+
+			apiResponse, _ = sendRequest(t, http.MethodPost, "/v1/tables/"+fixture.TableOne.ID.String()+"/unmerge",
+				otherToken, validation.UnmergeTables{})
+
+			assert.Equal(t, http.StatusForbidden, apiResponse.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package escpos_test
+
+import (
+	"app/src/escpos"
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	t.Run("PaperFor", func(t *testing.T) {
+		t.Run("should pick the layout of the paper width", func(t *testing.T) {
+			assert.Equal(t, escpos.Paper58, escpos.PaperFor(58))
+			assert.Equal(t, escpos.Paper80, escpos.PaperFor(80))
+			assert.Equal(t, escpos.Paper80, escpos.PaperFor(0))
+		})
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Run("should justify rows to the paper width", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Row("Subtotal", "12,500", escpos.Style{})
+
+			assert.Equal(t, "Subtotal"+strings.Repeat(" ", 18)+"12,500\n", doc.String())
+		})
+
+		t.Run("should wrap long lines at word boundaries", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Line("Nasi goreng spesial dengan telur mata sapi", escpos.Style{})
+
+			assert.Equal(t, "Nasi goreng spesial dengan telur\nmata sapi\n", doc.String())
+		})
+
+		t.Run("should give a long label its own line", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Row("Es teh manis dengan gula aren asli", "5,000", escpos.Style{})
+
+			lines := strings.Split(strings.TrimSuffix(doc.String(), "\n"), "\n")
+			assert.Len(t, lines, 2)
+			assert.Equal(t, "Es teh manis dengan gula aren", lines[0])
+			assert.Len(t, lines[1], 32)
+			assert.True(t, strings.HasSuffix(lines[1], " 5,000"))
+		})
+
+		t.Run("should center text and show placeholders", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Line("TOTAL", escpos.Style{Align: escpos.AlignCenter, Double: true})
+			doc.QRCode("INV-1")
+
+			assert.Equal(t, strings.Repeat(" ", 13)+"TOTAL\n"+strings.Repeat(" ", 10)+"[QR: INV-1]\n", doc.String())
+		})
+
+		t.Run("should keep the indentation of rows", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Row("  2 x 15,000", "30,000", escpos.Style{})
+
+			assert.Equal(t, "  2 x 15,000"+strings.Repeat(" ", 14)+"30,000\n", doc.String())
+		})
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		t.Run("should initialize the printer and cut the paper", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper80)
+			doc.Line("Hello", escpos.Style{Bold: true})
+			data := doc.Bytes()
+
+			assert.True(t, bytes.HasPrefix(data, []byte{0x1b, '@', 0x1b, 't', 0}))
+			assert.True(t, bytes.HasSuffix(data, []byte{0x1d, 'V', 66, 3}))
+			assert.Contains(t, string(data), "\x1bE\x01")
+			assert.Contains(t, string(data), "Hello\n")
+		})
+
+		t.Run("should encode text to the code page", func(t *testing.T) {
+			doc := escpos.NewDocument(escpos.Paper80)
+			doc.CodePage, _ = escpos.LookupCodePage("wpc1252")
+			doc.Line("Café 東", escpos.Style{})
+			data := doc.Bytes()
+
+			assert.Contains(t, string(data), "Caf\xe9 ?\n")
+			assert.True(t, bytes.HasPrefix(data, []byte{0x1b, '@', 0x1b, 't', 16}))
+		})
+
+		t.Run("should scale images down to the paper", func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 768, 100))
+			img.Set(0, 0, color.Black)
+
+			doc := escpos.NewDocument(escpos.Paper58)
+			doc.Image(img)
+			data := doc.Bytes()
+
+			// 384 dots are 48 bytes per row, the height is halved to 50 rows
+			header := []byte{0x1d, 'v', '0', 0, 48, 0, 50, 0, 0x80}
+			assert.True(t, bytes.Contains(data, header))
+		})
+	})
+
+	t.Run("LookupCodePage", func(t *testing.T) {
+		t.Run("should reject unknown code pages", func(t *testing.T) {
+			_, ok := escpos.LookupCodePage("utf8")
+			assert.False(t, ok)
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package escpos_test
+
+import (
+	"app/src/escpos"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	t.Run("should write the print data to the printer", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		received := make(chan []byte, 1)
+		go func() {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			defer conn.Close()
+			data, _ := io.ReadAll(conn)
+			received <- data
+		}()
+
+		doc := escpos.NewDocument(escpos.Paper80)
+		doc.Line("Hello", escpos.Style{})
+
+		err = escpos.Send(context.Background(), listener.Addr().String(), doc.Bytes(), time.Second)
+		require.NoError(t, err)
+
+		select {
+		case data := <-received:
+			assert.Equal(t, doc.Bytes(), data)
+		case <-time.After(time.Second):
+			t.Fatal("printer did not receive the data")
+		}
+	})
+
+	t.Run("should fail when the printer is offline", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		err = escpos.Send(context.Background(), address, []byte("test"), time.Second)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package middleware_test
+
+import (
+	"app/src/config"
+	"app/src/middleware"
+	"app/src/model"
+	"app/test/fixture"
+	"app/test/helper"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotency keeps the keys in a map instead of the database.
+type memoryIdempotency struct {
+	records map[string]*model.IdempotencyKey
+}
+
+func (s *memoryIdempotency) Reserve(
+	_ *fiber.Ctx, scope, key, requestHash string,
+) (*model.IdempotencyKey, bool, error) {
+	if record, ok := s.records[scope+" "+key]; ok {
+		return record, false, nil
+	}
+
+	record := &model.IdempotencyKey{Scope: scope, Key: key, RequestHash: requestHash}
+	s.records[scope+" "+key] = record
+
+	return record, true, nil
+}
+
+func (s *memoryIdempotency) Complete(_ *fiber.Ctx, _ *model.IdempotencyKey) error {
+	return nil
+}
+
+func (s *memoryIdempotency) Release(_ *fiber.Ctx, record *model.IdempotencyKey) error {
+	delete(s.records, record.Scope+" "+record.Key)
+	return nil
+}
+
+func (s *memoryIdempotency) DeleteExpired(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotency(t *testing.T) {
+	setup := func() (*fiber.App, *memoryIdempotency, *int) {
+		store := &memoryIdempotency{records: map[string]*model.IdempotencyKey{}}
+		calls := 0
+
+		app := fiber.New()
+		app.Use(middleware.Idempotency(store))
+		app.Post("/sales", func(c *fiber.Ctx) error {
+			calls++
+			return c.Status(fiber.StatusCreated).JSON(fiber.Map{"call": calls})
+		})
+		app.Post("/fail", func(_ *fiber.Ctx) error {
+			calls++
+			return fiber.NewError(fiber.StatusInternalServerError, "Internal Server Error")
+		})
+
+		return app, store, &calls
+	}
+
+	send := func(t *testing.T, app *fiber.App, url, key, accessToken, body string) (*http.Response, string) {
+		request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(middleware.IdempotencyKeyHeader, key)
+		if accessToken != "" {
+			request.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+
+		response, err := app.Test(request)
+		assert.Nil(t, err)
+
+		bytes, err := io.ReadAll(response.Body)
+		assert.Nil(t, err)
+
+		return response, string(bytes)
+	}
+
+	t.Run("should replay the stored response to a retry", func(t *testing.T) {
+		app, _, calls := setup()
+
+		first, firstBody := send(t, app, "/sales", "key-1", "", `{"total":10}`)
+		retry, retryBody := send(t, app, "/sales", "key-1", "", `{"total":10}`)
+
+		assert.Equal(t, http.StatusCreated, first.StatusCode)
+		assert.Equal(t, http.StatusCreated, retry.StatusCode)
+		assert.Equal(t, firstBody, retryBody)
+		assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("should return 409 error if the key is reused for a different request", func(t *testing.T) {
+		app, _, calls := setup()
+
+		first, _ := send(t, app, "/sales", "key-1", "", `{"total":10}`)
+		reused, _ := send(t, app, "/sales", "key-1", "", `{"total":20}`)
+
+		assert.Equal(t, http.StatusCreated, first.StatusCode)
+		assert.Equal(t, http.StatusConflict, reused.StatusCode)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("should release the key when the request fails on the server", func(t *testing.T) {
+		app, store, calls := setup()
+
+		first, _ := send(t, app, "/fail", "key-1", "", `{}`)
+		retry, _ := send(t, app, "/fail", "key-1", "", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.StatusCode)
+		assert.Equal(t, http.StatusInternalServerError, retry.StatusCode)
+		assert.Equal(t, 2, *calls)
+		assert.Empty(t, store.records)
+	})
+
+	t.Run("should keep the keys of every user apart", func(t *testing.T) {
+		// Unit tests run without a config file
+		config.JWTSecret = "idempotency-test-secret"
+		expires := time.Now().Add(time.Minute)
+		app, store, calls := setup()
+
+		userOne, err := helper.GenerateToken(fixture.UserOne.ID.String(), expires, config.TokenTypeAccess)
+		assert.Nil(t, err)
+
+		userTwo, err := helper.GenerateToken(fixture.UserTwo.ID.String(), expires, config.TokenTypeAccess)
+		assert.Nil(t, err)
+
+		first, _ := send(t, app, "/sales", "key-1", userOne, `{"total":10}`)
+		other, _ := send(t, app, "/sales", "key-1", userTwo, `{"total":10}`)
+
+		assert.Equal(t, http.StatusCreated, first.StatusCode)
+		assert.Equal(t, http.StatusCreated, other.StatusCode)
+		assert.Equal(t, "", other.Header.Get("Idempotent-Replayed"))
+		assert.Equal(t, 2, *calls)
+		assert.Contains(t, store.records, fixture.UserOne.ID.String()+" key-1")
+		assert.Contains(t, store.records, fixture.UserTwo.ID.String()+" key-1")
+	})
+}
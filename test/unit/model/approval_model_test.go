@@ -0,0 +1,68 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalModel(t *testing.T) {
+	t.Run("Create approval validation", func(t *testing.T) {
+		var newApproval = validation.CreateApproval{
+			Action:      "discount",
+			Amount:      1500000,
+			Reason:      "Loyal customer",
+			RequestedBy: uuid.NewString(),
+			ApproverID:  uuid.NewString(),
+			Pin:         "1234",
+		}
+
+		t.Run("should correctly validate a valid approval", func(t *testing.T) {
+			err := validate.Struct(newApproval)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should correctly validate a push approval without approver and pin", func(t *testing.T) {
+			push := newApproval
+			push.ApproverID = ""
+			push.Pin = ""
+			err := validate.Struct(push)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the pin has no approver", func(t *testing.T) {
+			invalid := newApproval
+			invalid.ApproverID = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the pin is not numeric", func(t *testing.T) {
+			invalid := newApproval
+			invalid.Pin = "12ab"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the action is unknown", func(t *testing.T) {
+			invalid := newApproval
+			invalid.Action = "refund"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Void sale validation", func(t *testing.T) {
+		t.Run("should throw a validation error if the reason is missing", func(t *testing.T) {
+			err := validate.Struct(validation.VoidSale{ApprovalID: uuid.NewString()})
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the approval id is not a uuid", func(t *testing.T) {
+			err := validate.Struct(validation.VoidSale{Reason: "Wrong table", ApprovalID: "abc"})
+			assert.Error(t, err)
+		})
+	})
+}
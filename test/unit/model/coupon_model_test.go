@@ -0,0 +1,180 @@
+package model_test
+
+import (
+	"app/src/money"
+	"app/src/validation"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCouponModel(t *testing.T) {
+	start := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	offer := validation.CouponOffer{
+		DiscountType:       "percentage",
+		DiscountValue:      money.FromUnits(10),
+		MaxUsesPerCustomer: 1,
+		CustomerTiers:      []string{"gold"},
+		StartDate:          start,
+		EndDate:            start.AddDate(0, 1, 0),
+	}
+
+	t.Run("Create coupon validation", func(t *testing.T) {
+		var newCoupon = validation.CreateCoupon{CouponOffer: offer, Code: "WELCOME10", MaxUses: 100}
+
+		t.Run("should correctly validate a valid coupon", func(t *testing.T) {
+			err := validate.Struct(newCoupon)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the code is not alphanumeric", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.Code = "WELCOME 10"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the discount type is unknown", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.DiscountType = "free"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the end date is before the start date", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.EndDate = start.AddDate(0, 0, -1)
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should allow limiting the coupon to outlets", func(t *testing.T) {
+			valid := newCoupon
+			valid.OutletIDs = []string{uuid.NewString(), uuid.NewString()}
+			err := validate.Struct(valid)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if an outlet id is invalid", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.OutletIDs = []string{"outlet"}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a customer id is invalid", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.CustomerIDs = []string{"customer"}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the per customer limit is negative", func(t *testing.T) {
+			invalid := newCoupon
+			invalid.MaxUsesPerCustomer = -1
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Generate coupons validation", func(t *testing.T) {
+		var generate = validation.GenerateCoupons{CouponOffer: offer, Campaign: "Newsletter", Prefix: "NOV", Count: 500}
+
+		t.Run("should correctly validate a valid campaign", func(t *testing.T) {
+			err := validate.Struct(generate)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the campaign is missing", func(t *testing.T) {
+			invalid := generate
+			invalid.Campaign = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the count is too high", func(t *testing.T) {
+			invalid := generate
+			invalid.Count = 5001
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the prefix is not alphanumeric", func(t *testing.T) {
+			invalid := generate
+			invalid.Prefix = "NOV-"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update coupon validation", func(t *testing.T) {
+		t.Run("should allow removing the customer limits", func(t *testing.T) {
+			zero := 0
+			err := validate.Struct(validation.UpdateCoupon{MaxUsesPerCustomer: &zero, CustomerIDs: &[]string{}})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the discount value is zero", func(t *testing.T) {
+			zero := money.Amount(0)
+			err := validate.Struct(validation.UpdateCoupon{DiscountValue: &zero})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Validate coupon validation", func(t *testing.T) {
+		var check = validation.ValidateCoupon{
+			Code:     "WELCOME10",
+			OutletID: uuid.NewString(),
+			Total:    50000,
+		}
+
+		t.Run("should correctly validate a valid check", func(t *testing.T) {
+			err := validate.Struct(check)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should allow checking against a sale", func(t *testing.T) {
+			valid := check
+			valid.Total = 0
+			valid.SaleID = uuid.NewString()
+			err := validate.Struct(valid)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the code is missing", func(t *testing.T) {
+			invalid := check
+			invalid.Code = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the outlet id is invalid", func(t *testing.T) {
+			invalid := check
+			invalid.OutletID = "outlet"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the total is negative", func(t *testing.T) {
+			invalid := check
+			invalid.Total = -1
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Apply coupon validation", func(t *testing.T) {
+		t.Run("should correctly validate a valid code", func(t *testing.T) {
+			err := validate.Struct(validation.ApplyCoupon{Code: "WELCOME10"})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the code is too long", func(t *testing.T) {
+			err := validate.Struct(validation.ApplyCoupon{Code: strings.Repeat("A", 101)})
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerModel(t *testing.T) {
+	t.Run("Create customer validation", func(t *testing.T) {
+		var newCustomer = validation.CreateCustomer{
+			OutletID: uuid.NewString(),
+			Name:     "Budi",
+			Email:    "budi@example.com",
+			Phone:    "+628123456789",
+			Tier:     "gold",
+		}
+
+		t.Run("should correctly validate a valid customer", func(t *testing.T) {
+			err := validate.Struct(newCustomer)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should allow a customer without email", func(t *testing.T) {
+			valid := newCustomer
+			valid.Email = ""
+			err := validate.Struct(valid)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the name is missing", func(t *testing.T) {
+			invalid := newCustomer
+			invalid.Name = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the email is invalid", func(t *testing.T) {
+			invalid := newCustomer
+			invalid.Email = "budi"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update customer validation", func(t *testing.T) {
+		t.Run("should allow clearing the email", func(t *testing.T) {
+			empty := ""
+			err := validate.Struct(validation.UpdateCustomer{Email: &empty})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the email is invalid", func(t *testing.T) {
+			email := "budi"
+			err := validate.Struct(validation.UpdateCustomer{Email: &email})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Merge customers validation", func(t *testing.T) {
+		t.Run("should correctly validate customer ids", func(t *testing.T) {
+			err := validate.Struct(validation.MergeCustomers{CustomerIDs: []string{uuid.NewString()}})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if no customers are given", func(t *testing.T) {
+			err := validate.Struct(validation.MergeCustomers{})
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a customer id is invalid", func(t *testing.T) {
+			err := validate.Struct(validation.MergeCustomers{CustomerIDs: []string{"customer"}})
+			assert.Error(t, err)
+		})
+	})
+}
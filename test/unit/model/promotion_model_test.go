@@ -0,0 +1,78 @@
+package model_test
+
+import (
+	"app/src/money"
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionModel(t *testing.T) {
+	t.Run("Create promotion validation", func(t *testing.T) {
+		var newPromotion = validation.CreatePromotion{
+			Name:        "Happy hour",
+			DaysOfWeek:  []int{1, 2, 3, 4, 5},
+			StartTime:   "15:00",
+			EndTime:     "18:00",
+			CategoryIDs: []string{uuid.NewString()},
+			Action:      "percent_off",
+			Value:       money.FromUnits(20),
+		}
+
+		t.Run("should correctly validate a valid promotion", func(t *testing.T) {
+			err := validate.Struct(newPromotion)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the action is unknown", func(t *testing.T) {
+			invalid := newPromotion
+			invalid.Action = "free_lunch"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a day is out of range", func(t *testing.T) {
+			invalid := newPromotion
+			invalid.DaysOfWeek = []int{7}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the start time is not a time", func(t *testing.T) {
+			invalid := newPromotion
+			invalid.StartTime = "3pm"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a category id is invalid", func(t *testing.T) {
+			invalid := newPromotion
+			invalid.CategoryIDs = []string{"drinks"}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the value is negative", func(t *testing.T) {
+			invalid := newPromotion
+			invalid.Value = -1
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update promotion validation", func(t *testing.T) {
+		t.Run("should allow clearing the daily window", func(t *testing.T) {
+			empty := ""
+			err := validate.Struct(validation.UpdatePromotion{StartTime: &empty, EndTime: &empty})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the buy quantity is zero", func(t *testing.T) {
+			zero := 0
+			err := validate.Struct(validation.UpdatePromotion{BuyQuantity: &zero})
+			assert.Error(t, err)
+		})
+	})
+}
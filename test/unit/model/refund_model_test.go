@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefundModel(t *testing.T) {
+	t.Run("Create refund validation", func(t *testing.T) {
+		var newRefund = validation.CreateRefund{
+			PaymentMethodID: uuid.NewString(),
+			ReasonCode:      "damaged",
+			Restock:         true,
+			Items: []validation.CreateRefundItem{
+				{SaleItemID: uuid.NewString(), Quantity: 1},
+			},
+		}
+
+		t.Run("should correctly validate a valid refund", func(t *testing.T) {
+			err := validate.Struct(newRefund)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if reason code is unknown", func(t *testing.T) {
+			invalid := newRefund
+			invalid.ReasonCode = "changed_mind"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if payment method is not a uuid", func(t *testing.T) {
+			invalid := newRefund
+			invalid.PaymentMethodID = "cash"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if there are no items", func(t *testing.T) {
+			invalid := newRefund
+			invalid.Items = nil
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if an item quantity is zero", func(t *testing.T) {
+			invalid := newRefund
+			invalid.Items = []validation.CreateRefundItem{{SaleItemID: uuid.NewString(), Quantity: 0}}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+}
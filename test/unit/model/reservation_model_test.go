@@ -0,0 +1,108 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationModel(t *testing.T) {
+	t.Run("Create reservation validation", func(t *testing.T) {
+		var newReservation = validation.CreateReservation{
+			Name:       "Budi",
+			Email:      "budi@example.com",
+			PartySize:  4,
+			ReservedAt: time.Now().Add(24 * time.Hour),
+		}
+
+		t.Run("should correctly validate a valid reservation", func(t *testing.T) {
+			err := validate.Struct(newReservation)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if name and customer are missing", func(t *testing.T) {
+			invalid := newReservation
+			invalid.Name = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should allow a missing name for a customer", func(t *testing.T) {
+			valid := newReservation
+			valid.Name = ""
+			valid.CustomerID = uuid.NewString()
+			err := validate.Struct(valid)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if party size is missing", func(t *testing.T) {
+			invalid := newReservation
+			invalid.PartySize = 0
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the duration is too short", func(t *testing.T) {
+			invalid := newReservation
+			invalid.DurationMinutes = 10
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update reservation validation", func(t *testing.T) {
+		t.Run("should allow clearing the email", func(t *testing.T) {
+			email := ""
+			err := validate.Struct(validation.UpdateReservation{Email: &email})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the email is invalid", func(t *testing.T) {
+			email := "budi"
+			err := validate.Struct(validation.UpdateReservation{Email: &email})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update reservation status validation", func(t *testing.T) {
+		t.Run("should correctly validate no_show", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateReservationStatus{Status: "no_show"})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the status is booked", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateReservationStatus{Status: "booked"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Table suggestion validation", func(t *testing.T) {
+		t.Run("should correctly validate an RFC 3339 time", func(t *testing.T) {
+			err := validate.Struct(validation.QueryTableSuggestion{
+				PartySize:  2,
+				ReservedAt: "2025-10-20T19:00:00+07:00",
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the time has no zone", func(t *testing.T) {
+			err := validate.Struct(validation.QueryTableSuggestion{PartySize: 2, ReservedAt: "2025-10-20 19:00"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Waitlist validation", func(t *testing.T) {
+		t.Run("should correctly validate a walk-in party", func(t *testing.T) {
+			err := validate.Struct(validation.CreateWaitlistEntry{Name: "Sari", PartySize: 2})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the status is waiting", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateWaitlistEntry{Status: "waiting"})
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaleModel(t *testing.T) {
+	t.Run("Create sale validation", func(t *testing.T) {
+		var newSale = validation.CreateSale{
+			OutletID:      uuid.NewString(),
+			OutletStaffID: uuid.NewString(),
+			SaleOrder:     validation.SaleOrder{TableID: uuid.NewString()},
+			Items: []validation.CreateSaleItem{
+				{ProductID: uuid.NewString(), Quantity: 2},
+			},
+		}
+
+		t.Run("should correctly validate a valid sale", func(t *testing.T) {
+			err := validate.Struct(newSale)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should correctly validate a sale without items", func(t *testing.T) {
+			empty := newSale
+			empty.Items = nil
+			err := validate.Struct(empty)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if staff is missing", func(t *testing.T) {
+			invalid := newSale
+			invalid.OutletStaffID = ""
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if an item discount is negative", func(t *testing.T) {
+			invalid := newSale
+			invalid.Items = []validation.CreateSaleItem{{ProductID: uuid.NewString(), Quantity: 1, Discount: -1}}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should correctly validate a takeaway sale without a table", func(t *testing.T) {
+			takeaway := newSale
+			pickupAt := time.Now().Add(time.Hour)
+			takeaway.SaleOrder = validation.SaleOrder{OrderType: "takeaway", PickupAt: &pickupAt}
+			err := validate.Struct(takeaway)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if a delivery has no address", func(t *testing.T) {
+			invalid := newSale
+			invalid.SaleOrder = validation.SaleOrder{
+				OrderType:       "delivery",
+				DeliveryContact: "Budi",
+				DeliveryPhone:   "08123456789",
+			}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a delivery has a pickup time", func(t *testing.T) {
+			invalid := newSale
+			pickupAt := time.Now()
+			invalid.SaleOrder = validation.SaleOrder{
+				OrderType:       "delivery",
+				DeliveryAddress: "Jl. Sudirman 1",
+				DeliveryContact: "Budi",
+				DeliveryPhone:   "08123456789",
+				PickupAt:        &pickupAt,
+			}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a modifier is empty", func(t *testing.T) {
+			invalid := newSale
+			invalid.Items = []validation.CreateSaleItem{
+				{ProductID: uuid.NewString(), Quantity: 1, Modifiers: []string{"no onion", ""}},
+			}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Create kitchen route validation", func(t *testing.T) {
+		t.Run("should correctly validate a category route", func(t *testing.T) {
+			err := validate.Struct(validation.CreateKitchenRoute{
+				PrinterID:         uuid.NewString(),
+				ProductCategoryID: uuid.NewString(),
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if no category or product is given", func(t *testing.T) {
+			err := validate.Struct(validation.CreateKitchenRoute{PrinterID: uuid.NewString()})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update kitchen item validation", func(t *testing.T) {
+		t.Run("should accept bumping an item to ready", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateKitchenItem{Status: "ready"})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the item is moved back to new", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateKitchenItem{Status: "new"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Query sale validation", func(t *testing.T) {
+		t.Run("should accept the open status", func(t *testing.T) {
+			err := validate.Struct(validation.QuerySale{Page: 1, Limit: 10, Status: "open"})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if status is unknown", func(t *testing.T) {
+			err := validate.Struct(validation.QuerySale{Page: 1, Limit: 10, Status: "parked"})
+			assert.Error(t, err)
+		})
+	})
+}
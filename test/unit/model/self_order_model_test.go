@@ -0,0 +1,65 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfOrderModel(t *testing.T) {
+	t.Run("Create table order validation", func(t *testing.T) {
+		var newOrder = validation.CreateTableOrder{
+			Name: "Sari",
+			Items: []validation.SelfOrderItem{
+				{ProductID: uuid.NewString(), Quantity: 2, Modifiers: []string{"Extra spicy"}},
+			},
+		}
+
+		t.Run("should correctly validate a valid order", func(t *testing.T) {
+			err := validate.Struct(newOrder)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if there are no items", func(t *testing.T) {
+			invalid := newOrder
+			invalid.Items = nil
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the quantity is too large", func(t *testing.T) {
+			invalid := newOrder
+			invalid.Items = []validation.SelfOrderItem{{ProductID: uuid.NewString(), Quantity: 51}}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the product id is invalid", func(t *testing.T) {
+			invalid := newOrder
+			invalid.Items = []validation.SelfOrderItem{{ProductID: "latte", Quantity: 1}}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Confirm table order validation", func(t *testing.T) {
+		t.Run("should allow confirming without staff", func(t *testing.T) {
+			err := validate.Struct(validation.ConfirmTableOrder{})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the staff id is invalid", func(t *testing.T) {
+			err := validate.Struct(validation.ConfirmTableOrder{OutletStaffID: "staff"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Reject table order validation", func(t *testing.T) {
+		t.Run("should throw a validation error if the reason is missing", func(t *testing.T) {
+			err := validate.Struct(validation.RejectTableOrder{})
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncModel(t *testing.T) {
+	t.Run("Sync sale validation", func(t *testing.T) {
+		var offlineSale = validation.SyncSale{
+			ID:            uuid.NewString(),
+			OutletStaffID: uuid.NewString(),
+			SaleOrder:     validation.SaleOrder{TableID: uuid.NewString()},
+			SaleDate:      time.Now(),
+			Items: []validation.SyncSaleItem{
+				{ProductID: uuid.NewString(), Quantity: 1, Price: 2500000},
+			},
+			Payments: []validation.SyncSalePayment{
+				{PaymentMethodID: uuid.NewString(), Amount: 2500000, PaidAt: time.Now()},
+			},
+		}
+
+		t.Run("should correctly validate a valid offline sale", func(t *testing.T) {
+			err := validate.Struct(offlineSale)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the terminal id is not a uuid", func(t *testing.T) {
+			invalid := offlineSale
+			invalid.ID = "offline-1"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the sale has no items", func(t *testing.T) {
+			invalid := offlineSale
+			invalid.Items = nil
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a payment has no time", func(t *testing.T) {
+			invalid := offlineSale
+			invalid.Payments = []validation.SyncSalePayment{{PaymentMethodID: uuid.NewString(), Amount: 100}}
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Sync batch validation", func(t *testing.T) {
+		t.Run("should throw a validation error for an empty batch", func(t *testing.T) {
+			err := validate.Struct(validation.SyncSales{})
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error for a negative cursor", func(t *testing.T) {
+			err := validate.Struct(validation.QuerySync{Cursor: -1})
+			assert.Error(t, err)
+		})
+	})
+}
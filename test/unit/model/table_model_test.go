@@ -0,0 +1,99 @@
+package model_test
+
+import (
+	"app/src/validation"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableModel(t *testing.T) {
+	t.Run("Create table validation", func(t *testing.T) {
+		var newTable = validation.CreateTable{
+			FloorAreaID: uuid.NewString(),
+			Name:        "A1",
+			Capacity:    4,
+			Shape:       "round",
+			PosX:        120,
+			PosY:        80,
+		}
+
+		t.Run("should correctly validate a valid table", func(t *testing.T) {
+			err := validate.Struct(newTable)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if capacity is missing", func(t *testing.T) {
+			invalid := newTable
+			invalid.Capacity = 0
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the shape is unknown", func(t *testing.T) {
+			invalid := newTable
+			invalid.Shape = "hexagon"
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if the position is negative", func(t *testing.T) {
+			invalid := newTable
+			invalid.PosX = -10
+			err := validate.Struct(invalid)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update table validation", func(t *testing.T) {
+		t.Run("should correctly validate an empty floor area to take the table off the floor plan", func(t *testing.T) {
+			area := ""
+			err := validate.Struct(validation.UpdateTable{FloorAreaID: &area})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the floor area is not a uuid", func(t *testing.T) {
+			area := "terrace"
+			err := validate.Struct(validation.UpdateTable{FloorAreaID: &area})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Update table status validation", func(t *testing.T) {
+		t.Run("should correctly validate a cleaned table", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateTableStatus{Status: "available"})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if the table is set to occupied", func(t *testing.T) {
+			err := validate.Struct(validation.UpdateTableStatus{Status: "occupied"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Move sale validation", func(t *testing.T) {
+		t.Run("should throw a validation error if the table is missing", func(t *testing.T) {
+			err := validate.Struct(validation.MoveSale{Reason: "Window seat"})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Merge tables validation", func(t *testing.T) {
+		t.Run("should correctly validate merging two tables", func(t *testing.T) {
+			err := validate.Struct(validation.MergeTables{TableIDs: []string{uuid.NewString(), uuid.NewString()}})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should throw a validation error if there are no tables", func(t *testing.T) {
+			err := validate.Struct(validation.MergeTables{TableIDs: []string{}})
+			assert.Error(t, err)
+		})
+
+		t.Run("should throw a validation error if a table is repeated", func(t *testing.T) {
+			tableID := uuid.NewString()
+			err := validate.Struct(validation.MergeTables{TableIDs: []string{tableID, tableID}})
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package money_test
+
+import (
+	"app/src/money"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmount(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		t.Run("should read amounts without going through float", func(t *testing.T) {
+			amount, err := money.Parse("0.29")
+			assert.NoError(t, err)
+			assert.Equal(t, money.Amount(29), amount)
+
+			amount, err = money.Parse("-12.5")
+			assert.NoError(t, err)
+			assert.Equal(t, money.Amount(-1250), amount)
+
+			amount, err = money.Parse("15000")
+			assert.NoError(t, err)
+			assert.Equal(t, money.FromUnits(15000), amount)
+		})
+
+		t.Run("should reject more than two decimals", func(t *testing.T) {
+			_, err := money.Parse("1.005")
+			assert.Error(t, err)
+		})
+
+		t.Run("should reject text", func(t *testing.T) {
+			_, err := money.Parse("ten")
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Run("should marshal in currency units", func(t *testing.T) {
+			data, err := json.Marshal(map[string]money.Amount{"total": 123456})
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"total": 1234.56}`, string(data))
+		})
+
+		t.Run("should unmarshal numbers and numeric strings", func(t *testing.T) {
+			var body struct {
+				Amount   money.Amount `json:"amount"`
+				Discount money.Amount `json:"discount"`
+			}
+
+			err := json.Unmarshal([]byte(`{"amount": 50000.10, "discount": "2.5"}`), &body)
+			assert.NoError(t, err)
+			assert.Equal(t, money.Amount(5000010), body.Amount)
+			assert.Equal(t, money.Amount(250), body.Discount)
+		})
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		t.Run("should read bigint columns and numeric aggregates", func(t *testing.T) {
+			var amount money.Amount
+
+			assert.NoError(t, amount.Scan(int64(1250)))
+			assert.Equal(t, money.Amount(1250), amount)
+
+			assert.NoError(t, amount.Scan([]byte("98765")))
+			assert.Equal(t, money.Amount(98765), amount)
+		})
+	})
+
+	t.Run("RoundTo", func(t *testing.T) {
+		t.Run("should round to a multiple of the increment", func(t *testing.T) {
+			amount := money.FromUnits(12345)
+			increment := money.FromUnits(500)
+			assert.Equal(t, money.FromUnits(12500), amount.RoundTo(increment, money.RoundHalfUp))
+			assert.Equal(t, money.FromUnits(12000), amount.RoundTo(increment, money.RoundDown))
+			assert.Equal(t, money.FromUnits(12500), amount.RoundTo(increment, money.RoundUp))
+			assert.Equal(t, money.FromUnits(12000), money.FromUnits(12249).RoundTo(increment, money.RoundHalfUp))
+		})
+
+		t.Run("should keep the amount without an increment", func(t *testing.T) {
+			assert.Equal(t, money.Amount(12345), money.Amount(12345).RoundTo(0, money.RoundHalfUp))
+		})
+	})
+
+	t.Run("should not drift when summing many lines", func(t *testing.T) {
+		var total money.Amount
+		for range 10000 {
+			total += money.Amount(10)
+		}
+		assert.Equal(t, "1000.00", total.String())
+	})
+}
+
+func TestCurrency(t *testing.T) {
+	usd := money.NewCurrency("usd", money.RoundHalfUp)
+	idr := money.NewCurrency("IDR", money.RoundHalfUp)
+
+	t.Run("NewCurrency", func(t *testing.T) {
+		t.Run("should know currencies without decimals", func(t *testing.T) {
+			assert.Equal(t, 0, idr.Decimals)
+			assert.Equal(t, 2, usd.Decimals)
+		})
+
+		t.Run("should fall back to half up for unknown rounding modes", func(t *testing.T) {
+			assert.Equal(t, money.RoundHalfUp, money.NewCurrency("USD", "nearest").Rounding)
+		})
+	})
+
+	t.Run("Round", func(t *testing.T) {
+		t.Run("should round to the smallest unit of the currency", func(t *testing.T) {
+			assert.Equal(t, money.FromUnits(1656), idr.Round(165550))
+			assert.Equal(t, money.Amount(165550), usd.Round(165550))
+		})
+
+		t.Run("should follow the rounding mode", func(t *testing.T) {
+			halfEven := money.NewCurrency("IDR", money.RoundHalfEven)
+			down := money.NewCurrency("IDR", money.RoundDown)
+			up := money.NewCurrency("IDR", money.RoundUp)
+
+			assert.Equal(t, money.FromUnits(2), halfEven.Round(250))
+			assert.Equal(t, money.FromUnits(4), halfEven.Round(350))
+			assert.Equal(t, money.FromUnits(2), down.Round(299))
+			assert.Equal(t, money.FromUnits(3), up.Round(201))
+			assert.Equal(t, money.FromUnits(-3), idr.Round(-250))
+		})
+	})
+
+	t.Run("Percent", func(t *testing.T) {
+		t.Run("should charge a percentage rounded to the currency", func(t *testing.T) {
+			assert.Equal(t, money.FromUnits(1656), idr.Percent(money.FromUnits(15050), 11))
+			assert.Equal(t, money.Amount(1155), usd.Percent(money.Amount(11000), 10.5))
+		})
+	})
+
+	t.Run("Allocate", func(t *testing.T) {
+		t.Run("should split proportionally to the weights", func(t *testing.T) {
+			shares := usd.Allocate(10000, []money.Amount{1, 3})
+			assert.Equal(t, []money.Amount{2500, 7500}, shares)
+		})
+
+		t.Run("should give the rounding remainder to the last share", func(t *testing.T) {
+			shares := usd.Allocate(10000, []money.Amount{1, 1, 1})
+			assert.Equal(t, []money.Amount{3333, 3333, 3334}, shares)
+		})
+
+		t.Run("should keep the remainder off shares without weight", func(t *testing.T) {
+			shares := idr.Allocate(money.FromUnits(100), []money.Amount{1, 1, 0})
+			assert.Equal(t, []money.Amount{money.FromUnits(50), money.FromUnits(50), 0}, shares)
+		})
+
+		t.Run("should split equally when every weight is zero", func(t *testing.T) {
+			shares := usd.Allocate(1000, []money.Amount{0, 0})
+			assert.Equal(t, []money.Amount{500, 500}, shares)
+		})
+
+		t.Run("should return no shares without weights", func(t *testing.T) {
+			assert.Empty(t, usd.Allocate(1000, nil))
+		})
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		t.Run("should group thousands and print the currency decimals", func(t *testing.T) {
+			assert.Equal(t, "1,234,567", idr.Format(money.FromUnits(1234567)))
+			assert.Equal(t, "-1,234.50", usd.Format(-123450))
+			assert.Equal(t, "0.05", usd.Format(5))
+			assert.Equal(t, "999", idr.Format(money.FromUnits(999)))
+		})
+
+		t.Run("should round to the currency", func(t *testing.T) {
+			assert.Equal(t, "1,656", idr.Format(165550))
+		})
+	})
+}
@@ -0,0 +1,22 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Run("should double the delay on every attempt", func(t *testing.T) {
+		assert.Equal(t, 5*time.Second, utils.Backoff(1, 5*time.Second, time.Minute))
+		assert.Equal(t, 10*time.Second, utils.Backoff(2, 5*time.Second, time.Minute))
+		assert.Equal(t, 40*time.Second, utils.Backoff(4, 5*time.Second, time.Minute))
+	})
+
+	t.Run("should not go over the limit", func(t *testing.T) {
+		assert.Equal(t, time.Minute, utils.Backoff(5, 5*time.Second, time.Minute))
+		assert.Equal(t, time.Minute, utils.Backoff(100, 5*time.Second, time.Minute))
+	})
+}
@@ -0,0 +1,43 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	t.Run("should deliver messages to the subscribers of a topic", func(t *testing.T) {
+		broker := utils.NewBroker(1)
+		kitchen, stopKitchen := broker.Subscribe("kitchen")
+		defer stopKitchen()
+		bar, stopBar := broker.Subscribe("bar")
+		defer stopBar()
+
+		assert.Equal(t, 1, broker.Publish("kitchen", []byte("order")))
+		assert.Equal(t, []byte("order"), <-kitchen)
+		assert.Empty(t, bar)
+	})
+
+	t.Run("should drop messages for a subscriber that is behind", func(t *testing.T) {
+		broker := utils.NewBroker(1)
+		messages, stop := broker.Subscribe("kitchen")
+		defer stop()
+
+		assert.Equal(t, 1, broker.Publish("kitchen", []byte("first")))
+		assert.Equal(t, 0, broker.Publish("kitchen", []byte("second")))
+		assert.Equal(t, []byte("first"), <-messages)
+	})
+
+	t.Run("should close the channel when unsubscribing", func(t *testing.T) {
+		broker := utils.NewBroker(1)
+		messages, stop := broker.Subscribe("kitchen")
+		stop()
+		stop()
+
+		_, open := <-messages
+		assert.False(t, open)
+		assert.Equal(t, 0, broker.Publish("kitchen", []byte("order")))
+	})
+}
@@ -0,0 +1,148 @@
+package utils_test
+
+import (
+	"app/src/money"
+	"app/src/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoupon(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	terms := utils.CouponTerms{
+		IsActive:  true,
+		StartDate: now.AddDate(0, 0, -7),
+		EndDate:   now.AddDate(0, 0, 7),
+		MaxUses:   10,
+		UsedCount: 3,
+	}
+
+	t.Run("CheckCoupon", func(t *testing.T) {
+		t.Run("should accept a coupon within its limits", func(t *testing.T) {
+			assert.NoError(t, utils.CheckCoupon(terms, now))
+		})
+
+		t.Run("should reject an inactive coupon", func(t *testing.T) {
+			inactive := terms
+			inactive.IsActive = false
+			assert.ErrorIs(t, utils.CheckCoupon(inactive, now), utils.ErrCouponInactive)
+		})
+
+		t.Run("should reject a coupon outside its dates", func(t *testing.T) {
+			assert.ErrorIs(t, utils.CheckCoupon(terms, terms.StartDate.Add(-time.Second)), utils.ErrCouponNotStarted)
+			assert.ErrorIs(t, utils.CheckCoupon(terms, terms.EndDate.Add(time.Second)), utils.ErrCouponExpired)
+		})
+
+		t.Run("should reject a coupon used up", func(t *testing.T) {
+			usedUp := terms
+			usedUp.UsedCount = usedUp.MaxUses
+			assert.ErrorIs(t, utils.CheckCoupon(usedUp, now), utils.ErrCouponUsedUp)
+		})
+
+		t.Run("should not limit the uses of a coupon without a maximum", func(t *testing.T) {
+			unlimited := terms
+			unlimited.MaxUses = 0
+			unlimited.UsedCount = 1000
+			assert.NoError(t, utils.CheckCoupon(unlimited, now))
+		})
+	})
+
+	t.Run("CouponDiscount", func(t *testing.T) {
+		idr := money.NewCurrency("IDR", money.RoundHalfUp)
+
+		t.Run("should take a percentage of the total rounded to the currency", func(t *testing.T) {
+			discount := utils.CouponDiscount(true, money.FromUnits(15), money.FromUnits(33333), idr)
+			assert.Equal(t, money.FromUnits(5000), discount)
+		})
+
+		t.Run("should take a fixed amount off", func(t *testing.T) {
+			discount := utils.CouponDiscount(false, money.FromUnits(10000), money.FromUnits(50000), idr)
+			assert.Equal(t, money.FromUnits(10000), discount)
+		})
+
+		t.Run("should not take more than the total", func(t *testing.T) {
+			discount := utils.CouponDiscount(false, money.FromUnits(10000), money.FromUnits(7500), idr)
+			assert.Equal(t, money.FromUnits(7500), discount)
+		})
+	})
+
+	t.Run("CheckCouponOutlet", func(t *testing.T) {
+		outletID := uuid.New()
+
+		t.Run("should accept every outlet when the coupon lists none", func(t *testing.T) {
+			assert.NoError(t, utils.CheckCouponOutlet(nil, outletID))
+		})
+
+		t.Run("should only accept the listed outlets", func(t *testing.T) {
+			assert.NoError(t, utils.CheckCouponOutlet([]uuid.UUID{uuid.New(), outletID}, outletID))
+			assert.ErrorIs(t, utils.CheckCouponOutlet([]uuid.UUID{uuid.New()}, outletID), utils.ErrCouponOutlet)
+		})
+	})
+
+	t.Run("CheckCouponCustomer", func(t *testing.T) {
+		customer := &utils.CouponCustomer{ID: uuid.New(), Tier: "gold"}
+
+		t.Run("should accept anyone when the coupon is not limited to customers", func(t *testing.T) {
+			assert.NoError(t, utils.CheckCouponCustomer(utils.CouponAudience{}, nil))
+		})
+
+		t.Run("should need a customer when the coupon is limited to customers", func(t *testing.T) {
+			audience := utils.CouponAudience{MaxUsesPerCustomer: 1}
+			assert.ErrorIs(t, utils.CheckCouponCustomer(audience, nil), utils.ErrCouponNoCustomer)
+		})
+
+		t.Run("should accept listed customers and customers of a listed tier", func(t *testing.T) {
+			listed := utils.CouponAudience{CustomerIDs: []uuid.UUID{customer.ID}}
+			assert.NoError(t, utils.CheckCouponCustomer(listed, customer))
+
+			tier := utils.CouponAudience{CustomerIDs: []uuid.UUID{uuid.New()}, CustomerTiers: []string{"gold"}}
+			assert.NoError(t, utils.CheckCouponCustomer(tier, customer))
+		})
+
+		t.Run("should reject customers the coupon is not for", func(t *testing.T) {
+			audience := utils.CouponAudience{CustomerTiers: []string{"platinum"}}
+			assert.ErrorIs(t, utils.CheckCouponCustomer(audience, customer), utils.ErrCouponNotEligible)
+		})
+
+		t.Run("should only accept a first order", func(t *testing.T) {
+			audience := utils.CouponAudience{FirstOrderOnly: true}
+			assert.NoError(t, utils.CheckCouponCustomer(audience, customer))
+
+			returning := *customer
+			returning.Orders = 1
+			assert.ErrorIs(t, utils.CheckCouponCustomer(audience, &returning), utils.ErrCouponFirstOrder)
+		})
+
+		t.Run("should limit the uses per customer", func(t *testing.T) {
+			audience := utils.CouponAudience{MaxUsesPerCustomer: 2}
+			used := *customer
+			used.Uses = 1
+			assert.NoError(t, utils.CheckCouponCustomer(audience, &used))
+
+			used.Uses = 2
+			assert.ErrorIs(t, utils.CheckCouponCustomer(audience, &used), utils.ErrCouponCustomerUsedUp)
+		})
+	})
+
+	t.Run("GenerateCouponCode", func(t *testing.T) {
+		t.Run("should put the prefix before the random part", func(t *testing.T) {
+			code, err := utils.GenerateCouponCode("XMAS", 8)
+			assert.NoError(t, err)
+			assert.Len(t, code, 12)
+			assert.True(t, strings.HasPrefix(code, "XMAS"))
+		})
+
+		t.Run("should leave out characters that are easily mixed up", func(t *testing.T) {
+			code, err := utils.GenerateCouponCode("", 200)
+			assert.NoError(t, err)
+			assert.NotContains(t, code, "0")
+			assert.NotContains(t, code, "O")
+			assert.NotContains(t, code, "1")
+			assert.NotContains(t, code, "I")
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomer(t *testing.T) {
+	t.Run("PhoneDigits", func(t *testing.T) {
+		t.Run("should drop everything but the digits", func(t *testing.T) {
+			assert.Equal(t, "6281234567890", utils.PhoneDigits("+62 (812) 3456-7890"))
+		})
+
+		t.Run("should return nothing for a number without digits", func(t *testing.T) {
+			assert.Empty(t, utils.PhoneDigits("n/a"))
+		})
+	})
+
+	t.Run("CustomerNameKey", func(t *testing.T) {
+		t.Run("should ignore case and extra spaces", func(t *testing.T) {
+			assert.Equal(t, "jane doe", utils.CustomerNameKey("  Jane   DOE "))
+		})
+	})
+
+	t.Run("DuplicateGroups", func(t *testing.T) {
+		t.Run("should group the indexes of repeated keys in order", func(t *testing.T) {
+			groups := utils.DuplicateGroups([]string{"b", "a", "c", "a", "b", "a"})
+			assert.Equal(t, [][]int{{0, 4}, {1, 3, 5}}, groups)
+		})
+
+		t.Run("should skip empty keys", func(t *testing.T) {
+			assert.Empty(t, utils.DuplicateGroups([]string{"", "", "a"}))
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKitchen(t *testing.T) {
+	bar, kitchen, expo := uuid.New(), uuid.New(), uuid.New()
+	drinks, food := uuid.New(), uuid.New()
+	coffee, tea, rice := uuid.New(), uuid.New(), uuid.New()
+
+	rules := []utils.KitchenRule{
+		{PrinterID: bar, CategoryID: drinks},
+		{PrinterID: kitchen, CategoryID: food},
+		{PrinterID: expo, CategoryID: food},
+		{PrinterID: kitchen, ProductID: tea},
+	}
+
+	t.Run("RouteKitchenLines", func(t *testing.T) {
+		t.Run("should send lines to every printer of their category", func(t *testing.T) {
+			lines := []utils.KitchenLine{
+				{ProductID: coffee, CategoryID: drinks},
+				{ProductID: rice, CategoryID: food},
+			}
+
+			stations := utils.RouteKitchenLines(lines, rules)
+			assert.Equal(t, []utils.KitchenStation{
+				{PrinterID: bar, Lines: []int{0}},
+				{PrinterID: kitchen, Lines: []int{1}},
+				{PrinterID: expo, Lines: []int{1}},
+			}, stations)
+		})
+
+		t.Run("should prefer the routes of the product over its category", func(t *testing.T) {
+			lines := []utils.KitchenLine{{ProductID: tea, CategoryID: drinks}}
+
+			stations := utils.RouteKitchenLines(lines, rules)
+			assert.Equal(t, []utils.KitchenStation{{PrinterID: kitchen, Lines: []int{0}}}, stations)
+		})
+
+		t.Run("should leave out lines without a route", func(t *testing.T) {
+			lines := []utils.KitchenLine{{ProductID: uuid.New(), CategoryID: uuid.New()}}
+
+			assert.Empty(t, utils.RouteKitchenLines(lines, rules))
+		})
+	})
+}
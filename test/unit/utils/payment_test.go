@@ -0,0 +1,89 @@
+package utils_test
+
+import (
+	"app/src/money"
+	"app/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment(t *testing.T) {
+	t.Run("CashRounding", func(t *testing.T) {
+		t.Run("should round the balance to the increment", func(t *testing.T) {
+			rounding := utils.CashRounding(money.Amount(2050), money.FromUnits(50), money.FromUnits(1), money.RoundDown)
+			assert.Equal(t, money.Amount(-50), rounding)
+
+			rounding = utils.CashRounding(money.Amount(2050), money.FromUnits(50), money.FromUnits(1), money.RoundUp)
+			assert.Equal(t, money.Amount(50), rounding)
+		})
+
+		t.Run("should not round without an increment", func(t *testing.T) {
+			rounding := utils.CashRounding(money.Amount(2050), money.FromUnits(50), 0, money.RoundDown)
+			assert.Equal(t, money.Amount(0), rounding)
+		})
+
+		t.Run("should not round a balance that is a multiple of the increment", func(t *testing.T) {
+			rounding := utils.CashRounding(money.FromUnits(20), money.FromUnits(50), money.FromUnits(5), money.RoundUp)
+			assert.Equal(t, money.Amount(0), rounding)
+		})
+
+		t.Run("should not round when the tender does not cover the rounded balance", func(t *testing.T) {
+			rounding := utils.CashRounding(money.Amount(2050), money.FromUnits(10), money.FromUnits(1), money.RoundDown)
+			assert.Equal(t, money.Amount(0), rounding)
+		})
+
+		t.Run("should not round a balance down to nothing", func(t *testing.T) {
+			rounding := utils.CashRounding(money.Amount(200), money.FromUnits(5), money.FromUnits(5), money.RoundDown)
+			assert.Equal(t, money.Amount(0), rounding)
+		})
+	})
+
+	t.Run("SplitTender", func(t *testing.T) {
+		t.Run("should apply a tender up to the balance in full", func(t *testing.T) {
+			applied, change, ok := utils.SplitTender(money.FromUnits(20), money.FromUnits(15), false)
+			assert.True(t, ok)
+			assert.Equal(t, money.FromUnits(15), applied)
+			assert.Equal(t, money.Amount(0), change)
+		})
+
+		t.Run("should give change on cash over the balance", func(t *testing.T) {
+			applied, change, ok := utils.SplitTender(money.FromUnits(20), money.FromUnits(50), true)
+			assert.True(t, ok)
+			assert.Equal(t, money.FromUnits(20), applied)
+			assert.Equal(t, money.FromUnits(30), change)
+		})
+
+		t.Run("should refuse other tenders over the balance", func(t *testing.T) {
+			_, _, ok := utils.SplitTender(money.FromUnits(20), money.FromUnits(50), false)
+			assert.False(t, ok)
+		})
+	})
+
+	t.Run("SettleSale", func(t *testing.T) {
+		t.Run("should mark an open sale paid once its payments cover it", func(t *testing.T) {
+			settlement := utils.SettleSale(true, false, money.FromUnits(20), money.FromUnits(20))
+			assert.Equal(t, utils.SettlementPaid, settlement)
+		})
+
+		t.Run("should keep an open sale open while it is partly paid", func(t *testing.T) {
+			settlement := utils.SettleSale(true, false, money.FromUnits(10), money.FromUnits(20))
+			assert.Equal(t, utils.SettlementNone, settlement)
+		})
+
+		t.Run("should reopen a paid sale that is no longer covered", func(t *testing.T) {
+			settlement := utils.SettleSale(false, true, money.FromUnits(10), money.FromUnits(20))
+			assert.Equal(t, utils.SettlementReopened, settlement)
+		})
+
+		t.Run("should leave a paid sale that is still covered", func(t *testing.T) {
+			settlement := utils.SettleSale(false, true, money.FromUnits(20), money.FromUnits(20))
+			assert.Equal(t, utils.SettlementNone, settlement)
+		})
+
+		t.Run("should leave closed sales alone", func(t *testing.T) {
+			settlement := utils.SettleSale(false, false, money.FromUnits(20), money.FromUnits(20))
+			assert.Equal(t, utils.SettlementNone, settlement)
+		})
+	})
+}
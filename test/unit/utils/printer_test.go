@@ -0,0 +1,32 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinterAddress(t *testing.T) {
+	t.Run("should use the printer port when the address has none", func(t *testing.T) {
+		address, err := utils.PrinterAddress("192.168.1.50", "9100")
+		assert.NoError(t, err)
+		assert.Equal(t, "192.168.1.50:9100", address)
+
+		address, err = utils.PrinterAddress("10.0.0.7:9100", "9100")
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.7:9100", address)
+	})
+
+	t.Run("should refuse other ports", func(t *testing.T) {
+		_, err := utils.PrinterAddress("192.168.1.50:22", "9100")
+		assert.ErrorIs(t, err, utils.ErrPrinterAddress)
+	})
+
+	t.Run("should refuse public, loopback and named hosts", func(t *testing.T) {
+		for _, address := range []string{"8.8.8.8", "127.0.0.1", "[::1]:9100", "0.0.0.0", "printer.example.com", ""} {
+			_, err := utils.PrinterAddress(address, "9100")
+			assert.ErrorIs(t, err, utils.ErrPrinterAddress, address)
+		}
+	})
+}
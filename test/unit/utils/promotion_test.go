@@ -0,0 +1,176 @@
+package utils_test
+
+import (
+	"app/src/money"
+	"app/src/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotion(t *testing.T) {
+	coffee := uuid.New()
+	cake := uuid.New()
+	drinks := uuid.New()
+	food := uuid.New()
+
+	usd := money.NewCurrency("USD", money.RoundHalfUp)
+	// A Monday afternoon
+	at := time.Date(2025, 10, 20, 16, 0, 0, 0, time.UTC)
+	sale := utils.PromotionSale{At: at}
+
+	lines := []utils.PromotionLine{
+		{ProductID: coffee, CategoryID: drinks, Quantity: 3, Amount: money.FromUnits(15)},
+		{ProductID: cake, CategoryID: food, Quantity: 1, Amount: money.FromUnits(8)},
+	}
+
+	rule := func(action string, value money.Amount) utils.PromotionRule {
+		return utils.PromotionRule{ID: uuid.New(), Name: action, Stackable: true, Action: action, Value: value}
+	}
+
+	t.Run("ApplyPromotions", func(t *testing.T) {
+		t.Run("should take a percentage off the whole sale", func(t *testing.T) {
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{
+				rule(utils.PromotionPercentOff, money.FromUnits(10)),
+			}, sale, usd)
+
+			assert.Len(t, discounts, 1)
+			assert.Equal(t, money.FromFloat(2.3), discounts[0].Discount)
+		})
+
+		t.Run("should only look at the products and categories of the rule", func(t *testing.T) {
+			drinksOff := rule(utils.PromotionPercentOff, money.FromUnits(20))
+			drinksOff.CategoryIDs = []uuid.UUID{drinks}
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{drinksOff}, sale, usd)
+
+			assert.Equal(t, money.FromUnits(3), discounts[0].Discount)
+		})
+
+		t.Run("should skip a rule below its minimum spend", func(t *testing.T) {
+			amountOff := rule(utils.PromotionAmountOff, money.FromUnits(5))
+			amountOff.MinSpend = money.FromUnits(50)
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{amountOff}, sale, usd)
+
+			assert.Empty(t, discounts)
+		})
+
+		t.Run("should give the cheapest units of every set away with buy x get y", func(t *testing.T) {
+			buyTwo := rule(utils.PromotionBuyXGetY, 0)
+			buyTwo.BuyQuantity = 2
+			buyTwo.GetQuantity = 1
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{buyTwo}, sale, usd)
+
+			// 8, 5, 5 make a set with one 5 free, the last coffee does not complete another set
+			assert.Equal(t, money.FromUnits(5), discounts[0].Discount)
+		})
+
+		t.Run("should give the cheapest unit away", func(t *testing.T) {
+			cheapest := rule(utils.PromotionCheapestFree, 0)
+			cheapest.ProductIDs = []uuid.UUID{cake}
+
+			assert.Empty(t, utils.ApplyPromotions(lines, []utils.PromotionRule{cheapest}, sale, usd))
+
+			cheapest.ProductIDs = nil
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{cheapest}, sale, usd)
+			assert.Equal(t, money.FromUnits(5), discounts[0].Discount)
+		})
+
+		t.Run("should price complete bundles at the bundle price", func(t *testing.T) {
+			bundle := rule(utils.PromotionBundlePrice, money.FromUnits(10))
+			bundle.ProductIDs = []uuid.UUID{coffee, cake}
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{bundle}, sale, usd)
+
+			assert.Equal(t, money.FromUnits(3), discounts[0].Discount)
+		})
+
+		t.Run("should apply the highest priority first and stop at a rule that does not stack", func(t *testing.T) {
+			stackable := rule(utils.PromotionAmountOff, money.FromUnits(1))
+			exclusive := rule(utils.PromotionAmountOff, money.FromUnits(4))
+			exclusive.Stackable = false
+			exclusive.Priority = 10
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{stackable, exclusive}, sale, usd)
+
+			assert.Len(t, discounts, 1)
+			assert.Equal(t, exclusive.ID, discounts[0].RuleID)
+		})
+
+		t.Run("should skip a rule that does not stack once another was applied", func(t *testing.T) {
+			first := rule(utils.PromotionAmountOff, money.FromUnits(1))
+			first.Priority = 10
+			exclusive := rule(utils.PromotionAmountOff, money.FromUnits(4))
+			exclusive.Stackable = false
+			last := rule(utils.PromotionAmountOff, money.FromUnits(2))
+
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{first, exclusive, last}, sale, usd)
+
+			assert.Len(t, discounts, 2)
+			assert.Equal(t, first.ID, discounts[0].RuleID)
+			assert.Equal(t, last.ID, discounts[1].RuleID)
+		})
+
+		t.Run("should never take off more than the sale total", func(t *testing.T) {
+			discounts := utils.ApplyPromotions(lines, []utils.PromotionRule{
+				rule(utils.PromotionAmountOff, money.FromUnits(20)),
+				rule(utils.PromotionAmountOff, money.FromUnits(20)),
+			}, sale, usd)
+
+			assert.Equal(t, money.FromUnits(20), discounts[0].Discount)
+			assert.Equal(t, money.FromUnits(3), discounts[1].Discount)
+		})
+
+		t.Run("should only apply a tiered rule to customers of that tier", func(t *testing.T) {
+			members := rule(utils.PromotionPercentOff, money.FromUnits(10))
+			members.CustomerTiers = []string{"gold"}
+
+			assert.Empty(t, utils.ApplyPromotions(lines, []utils.PromotionRule{members}, sale, usd))
+
+			gold := utils.PromotionSale{At: at, CustomerTier: "gold"}
+			assert.Len(t, utils.ApplyPromotions(lines, []utils.PromotionRule{members}, gold, usd), 1)
+		})
+	})
+
+	t.Run("PromotionSchedule", func(t *testing.T) {
+		t.Run("should run within its dates", func(t *testing.T) {
+			start := at.AddDate(0, 0, -1)
+			end := at.AddDate(0, 0, 1)
+
+			assert.True(t, utils.PromotionSchedule{StartDate: &start, EndDate: &end}.Runs(at))
+			assert.False(t, utils.PromotionSchedule{StartDate: &end}.Runs(at))
+			assert.False(t, utils.PromotionSchedule{EndDate: &start}.Runs(at))
+		})
+
+		t.Run("should run on its days within its daily window", func(t *testing.T) {
+			happyHour := utils.PromotionSchedule{Days: []int{1, 2}, StartTime: "15:00", EndTime: "18:00"}
+
+			assert.True(t, happyHour.Runs(at))
+			assert.False(t, happyHour.Runs(at.Add(3*time.Hour)))
+			assert.False(t, happyHour.Runs(at.AddDate(0, 0, 2)))
+		})
+
+		t.Run("should keep a window past midnight on the day it started", func(t *testing.T) {
+			lateNight := utils.PromotionSchedule{Days: []int{1}, StartTime: "22:00", EndTime: "02:00"}
+			mondayNight := time.Date(2025, 10, 20, 23, 0, 0, 0, time.UTC)
+
+			assert.True(t, lateNight.Runs(mondayNight))
+			assert.True(t, lateNight.Runs(mondayNight.Add(2*time.Hour)))
+			assert.False(t, lateNight.Runs(mondayNight.Add(-3*time.Hour)))
+			assert.False(t, lateNight.Runs(mondayNight.AddDate(0, 0, -1).Add(2*time.Hour)))
+		})
+
+		t.Run("should read the daily window in the location of the time", func(t *testing.T) {
+			happyHour := utils.PromotionSchedule{StartTime: "15:00", EndTime: "18:00"}
+			jakarta := time.FixedZone("WIB", 7*60*60)
+			afternoon := time.Date(2025, 10, 20, 16, 0, 0, 0, jakarta)
+
+			assert.True(t, happyHour.Runs(afternoon))
+			assert.False(t, happyHour.Runs(afternoon.UTC()))
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableToken(t *testing.T) {
+	const secret = "thisisasamplesecret"
+	tableID := uuid.New()
+
+	t.Run("should parse a signed token", func(t *testing.T) {
+		token := utils.SignTableToken(secret, tableID, 3)
+
+		id, version, err := utils.ParseTableToken(secret, token)
+		assert.NoError(t, err)
+		assert.Equal(t, tableID, id)
+		assert.Equal(t, 3, version)
+		assert.Equal(t, tableID.String(), utils.TableTokenKey(secret, token))
+	})
+
+	t.Run("should reject a token signed with another secret", func(t *testing.T) {
+		token := utils.SignTableToken("anothersecret", tableID, 1)
+
+		_, _, err := utils.ParseTableToken(secret, token)
+		assert.ErrorIs(t, err, utils.ErrInvalidTableToken)
+		assert.Empty(t, utils.TableTokenKey(secret, token))
+	})
+
+	t.Run("should reject a token for another table or version", func(t *testing.T) {
+		token := utils.SignTableToken(secret, tableID, 1)
+		signature := token[strings.LastIndexByte(token, '.'):]
+
+		_, _, err := utils.ParseTableToken(secret, uuid.NewString()+".1"+signature)
+		assert.ErrorIs(t, err, utils.ErrInvalidTableToken)
+
+		_, _, err = utils.ParseTableToken(secret, tableID.String()+".2"+signature)
+		assert.ErrorIs(t, err, utils.ErrInvalidTableToken)
+	})
+
+	t.Run("should reject a malformed token", func(t *testing.T) {
+		_, _, err := utils.ParseTableToken(secret, "not-a-token")
+		assert.ErrorIs(t, err, utils.ErrInvalidTableToken)
+	})
+}
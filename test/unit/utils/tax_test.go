@@ -0,0 +1,118 @@
+package utils_test
+
+import (
+	"app/src/money"
+	"app/src/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTax(t *testing.T) {
+	food := uuid.New()
+	drinks := uuid.New()
+
+	usd := money.NewCurrency("USD", money.RoundHalfUp)
+	vat := utils.TaxRule{ID: uuid.New(), Name: "VAT", Rate: 11}
+	pb1 := utils.TaxRule{ID: uuid.New(), Name: "PB1", Rate: 10}
+	service := utils.TaxRule{ID: uuid.New(), Name: "Service", ServiceCharge: true, Rate: 5}
+
+	t.Run("CalculateTaxes", func(t *testing.T) {
+		t.Run("should add exclusive taxes on top of every line", func(t *testing.T) {
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(100)},
+				{CategoryID: drinks, Amount: money.FromUnits(50)},
+			}, []utils.TaxRule{vat}, usd)
+
+			assert.Equal(t, money.Amount(1650), result.Tax)
+			assert.Equal(t, money.Amount(0), result.TaxIncluded)
+			assert.Equal(t, money.Amount(1100), result.Lines[0].Tax)
+			assert.Equal(t, money.Amount(550), result.Lines[1].Tax)
+		})
+
+		t.Run("should extract inclusive taxes from the line amount", func(t *testing.T) {
+			inclusive := pb1
+			inclusive.Inclusive = true
+
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(110)},
+			}, []utils.TaxRule{inclusive}, usd)
+
+			assert.Equal(t, money.FromUnits(10), result.Tax)
+			assert.Equal(t, money.FromUnits(10), result.TaxIncluded)
+			assert.Equal(t, money.FromUnits(100), result.Lines[0].Charges[0].Base)
+		})
+
+		t.Run("should tax a taxable service charge", func(t *testing.T) {
+			taxable := service
+			taxable.Taxable = true
+
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(100)},
+			}, []utils.TaxRule{pb1, taxable}, usd)
+
+			assert.Equal(t, money.FromUnits(5), result.ServiceCharge)
+			assert.Equal(t, money.Amount(1050), result.Tax)
+			assert.Len(t, result.Lines[0].Charges, 2)
+		})
+
+		t.Run("should not tax a service charge that is not taxable", func(t *testing.T) {
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(100)},
+			}, []utils.TaxRule{pb1, service}, usd)
+
+			assert.Equal(t, money.FromUnits(5), result.ServiceCharge)
+			assert.Equal(t, money.FromUnits(10), result.Tax)
+		})
+
+		t.Run("should skip exempt categories", func(t *testing.T) {
+			exempt := vat
+			exempt.ExemptCategoryIDs = map[uuid.UUID]bool{drinks: true}
+
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(100)},
+				{CategoryID: drinks, Amount: money.FromUnits(50)},
+			}, []utils.TaxRule{exempt}, usd)
+
+			assert.Equal(t, money.FromUnits(11), result.Tax)
+			assert.Equal(t, money.Amount(0), result.Lines[1].Tax)
+			assert.Empty(t, result.Lines[1].Charges)
+		})
+
+		t.Run("should round once per sale and keep the lines adding up", func(t *testing.T) {
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: 4},
+				{CategoryID: food, Amount: 4},
+				{CategoryID: food, Amount: 4},
+			}, []utils.TaxRule{vat}, usd)
+
+			var lines money.Amount
+			for _, line := range result.Lines {
+				lines += line.Tax
+			}
+
+			assert.Equal(t, money.Amount(1), result.Tax)
+			assert.Equal(t, result.Tax, lines)
+		})
+
+		t.Run("should round to the currency", func(t *testing.T) {
+			idr := money.NewCurrency("IDR", money.RoundHalfUp)
+
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(15050)},
+			}, []utils.TaxRule{vat}, idr)
+
+			assert.Equal(t, money.FromUnits(1656), result.Tax)
+		})
+
+		t.Run("should charge nothing without rules", func(t *testing.T) {
+			result := utils.CalculateTaxes([]utils.TaxableLine{
+				{CategoryID: food, Amount: money.FromUnits(100)},
+			}, nil, usd)
+
+			assert.Equal(t, money.Amount(0), result.Tax)
+			assert.Equal(t, money.Amount(0), result.ServiceCharge)
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package utils_test
+
+import (
+	"app/src/money"
+	"app/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTip(t *testing.T) {
+	idr := money.NewCurrency("IDR", money.RoundHalfUp)
+	usd := money.NewCurrency("USD", money.RoundHalfUp)
+
+	t.Run("DistributeTips", func(t *testing.T) {
+		t.Run("should split the pool proportionally to the weights", func(t *testing.T) {
+			shares := utils.DistributeTips(money.FromUnits(90000), []float64{1, 2}, idr)
+			assert.Equal(t, []money.Amount{money.FromUnits(30000), money.FromUnits(60000)}, shares)
+		})
+
+		t.Run("should add up to the pool after rounding", func(t *testing.T) {
+			shares := utils.DistributeTips(money.FromUnits(10000), []float64{1, 1, 1}, idr)
+			assert.Equal(t, []money.Amount{
+				money.FromUnits(3333), money.FromUnits(3333), money.FromUnits(3334),
+			}, shares)
+		})
+
+		t.Run("should weigh by hours worked", func(t *testing.T) {
+			shares := utils.DistributeTips(money.Amount(1000), []float64{7.5, 2.5}, usd)
+			assert.Equal(t, []money.Amount{750, 250}, shares)
+		})
+
+		t.Run("should leave out staff without a weight", func(t *testing.T) {
+			shares := utils.DistributeTips(money.Amount(1000), []float64{0, 1, 0}, usd)
+			assert.Equal(t, []money.Amount{0, 1000, 0}, shares)
+		})
+
+		t.Run("should not distribute when no one has a weight", func(t *testing.T) {
+			shares := utils.DistributeTips(money.Amount(1000), []float64{0, 0}, usd)
+			assert.Equal(t, []money.Amount{0, 0}, shares)
+		})
+	})
+}
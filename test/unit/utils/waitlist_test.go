@@ -0,0 +1,36 @@
+package utils_test
+
+import (
+	"app/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateWait(t *testing.T) {
+	now := time.Date(2025, 10, 19, 19, 0, 0, 0, time.UTC)
+	turn := time.Hour
+
+	t.Run("should not wait when a table is free", func(t *testing.T) {
+		wait := utils.EstimateWait([]time.Time{now.Add(-time.Minute), now.Add(30 * time.Minute)}, 0, turn, now)
+		assert.Equal(t, time.Duration(0), wait)
+	})
+
+	t.Run("should wait for the first table to free up", func(t *testing.T) {
+		wait := utils.EstimateWait([]time.Time{now.Add(40 * time.Minute), now.Add(20 * time.Minute)}, 0, turn, now)
+		assert.Equal(t, 20*time.Minute, wait)
+	})
+
+	t.Run("should give the tables to the parties ahead first", func(t *testing.T) {
+		freeAt := []time.Time{now.Add(10 * time.Minute), now.Add(20 * time.Minute)}
+
+		assert.Equal(t, 20*time.Minute, utils.EstimateWait(freeAt, 1, turn, now))
+		assert.Equal(t, 70*time.Minute, utils.EstimateWait(freeAt, 2, turn, now))
+		assert.Equal(t, 80*time.Minute, utils.EstimateWait(freeAt, 3, turn, now))
+	})
+
+	t.Run("should count a turn per party when no table fits", func(t *testing.T) {
+		assert.Equal(t, 2*time.Hour, utils.EstimateWait(nil, 1, turn, now))
+	})
+}